@@ -17,6 +17,9 @@ type Configuration struct {
 	OPENAI        OpenAIConfig
 	JWT           JWTConfig
 	SERVER        ServerConfig
+	SCHEDULER     SchedulerConfig
+	ENCRYPTION    EncryptionConfig
+	CRISIS        CrisisConfig
 }
 
 type (
@@ -49,12 +52,19 @@ type (
 	}
 
 	MidtransConfig struct {
-		MIDTRANS_SERVER_KEY string
-		MIDTRANS_CLIENT_KEY string
+		MIDTRANS_SERVER_KEY  string
+		MIDTRANS_CLIENT_KEY  string
+		MIDTRANS_ENVIRONMENT string
 	}
 
 	OpenAIConfig struct {
-		OPENAI_API_KEY string
+		OPENAI_PROVIDER       string
+		OPENAI_API_KEY        string
+		OPENAI_BASE_URL       string
+		OPENAI_MODEL          string
+		OPENAI_TIMEOUT        string
+		OPENAI_MAX_RETRIES    string
+		OPENAI_CONTEXT_TOKENS string
 	}
 
 	SMTPConfig struct {
@@ -65,13 +75,30 @@ type (
 	}
 
 	ServerConfig struct {
-		SERVER_HOST string
-		SERVER_PORT string
+		SERVER_HOST        string
+		SERVER_PORT        string
+		SERVER_ENVIRONMENT string
 	}
 
 	JWTConfig struct {
 		JWT_SECRET string
 	}
+
+	SchedulerConfig struct {
+		SCHEDULER_ENABLED        string
+		SCHEDULER_REMINDER_DAYS  string
+		SCHEDULER_RETENTION_DAYS string
+	}
+
+	EncryptionConfig struct {
+		ENCRYPTION_KEY_ID        string
+		ENCRYPTION_KEY           string
+		ENCRYPTION_PREVIOUS_KEYS string
+	}
+
+	CrisisConfig struct {
+		CRISIS_ONCALL_EMAILS string
+	}
 )
 
 func LoadConfig() (*Configuration, error) {
@@ -112,8 +139,9 @@ func LoadConfig() (*Configuration, error) {
 			AWS_BUCKET_NAME:       os.Getenv("AWS_BUCKET_NAME"),
 		},
 		MIDTRANS: MidtransConfig{
-			MIDTRANS_SERVER_KEY: os.Getenv("MIDTRANS_SERVER_KEY"),
-			MIDTRANS_CLIENT_KEY: os.Getenv("MIDTRANS_CLIENT_KEY"),
+			MIDTRANS_SERVER_KEY:  os.Getenv("MIDTRANS_SERVER_KEY"),
+			MIDTRANS_CLIENT_KEY:  os.Getenv("MIDTRANS_CLIENT_KEY"),
+			MIDTRANS_ENVIRONMENT: os.Getenv("MIDTRANS_ENVIRONMENT"),
 		},
 		SMTP: SMTPConfig{
 			SMTP_USER: os.Getenv("SMTP_USER"),
@@ -122,14 +150,34 @@ func LoadConfig() (*Configuration, error) {
 			SMTP_HOST: os.Getenv("SMTP_HOST"),
 		},
 		OPENAI: OpenAIConfig{
-			OPENAI_API_KEY: os.Getenv("OPENAI_API_KEY"),
+			OPENAI_PROVIDER:       os.Getenv("OPENAI_PROVIDER"),
+			OPENAI_API_KEY:        os.Getenv("OPENAI_API_KEY"),
+			OPENAI_BASE_URL:       os.Getenv("OPENAI_BASE_URL"),
+			OPENAI_MODEL:          os.Getenv("OPENAI_MODEL"),
+			OPENAI_TIMEOUT:        os.Getenv("OPENAI_TIMEOUT"),
+			OPENAI_MAX_RETRIES:    os.Getenv("OPENAI_MAX_RETRIES"),
+			OPENAI_CONTEXT_TOKENS: os.Getenv("OPENAI_CONTEXT_TOKENS"),
 		},
 		SERVER: ServerConfig{
-			SERVER_HOST: os.Getenv("SERVER_HOST"),
-			SERVER_PORT: os.Getenv("SERVER_PORT"),
+			SERVER_HOST:        os.Getenv("SERVER_HOST"),
+			SERVER_PORT:        os.Getenv("SERVER_PORT"),
+			SERVER_ENVIRONMENT: os.Getenv("SERVER_ENVIRONMENT"),
 		},
 		JWT: JWTConfig{
 			JWT_SECRET: os.Getenv("JWT_SECRET"),
 		},
+		SCHEDULER: SchedulerConfig{
+			SCHEDULER_ENABLED:        os.Getenv("SCHEDULER_ENABLED"),
+			SCHEDULER_REMINDER_DAYS:  os.Getenv("SCHEDULER_REMINDER_DAYS"),
+			SCHEDULER_RETENTION_DAYS: os.Getenv("SCHEDULER_RETENTION_DAYS"),
+		},
+		ENCRYPTION: EncryptionConfig{
+			ENCRYPTION_KEY_ID:        os.Getenv("ENCRYPTION_KEY_ID"),
+			ENCRYPTION_KEY:           os.Getenv("ENCRYPTION_KEY"),
+			ENCRYPTION_PREVIOUS_KEYS: os.Getenv("ENCRYPTION_PREVIOUS_KEYS"),
+		},
+		CRISIS: CrisisConfig{
+			CRISIS_ONCALL_EMAILS: os.Getenv("CRISIS_ONCALL_EMAILS"),
+		},
 	}, nil
 }
@@ -6,28 +6,88 @@ import (
 	"gorm.io/gorm"
 
 	am "talkspace-api/modules/admin/model"
+	apm "talkspace-api/modules/appointment/model"
+	asm "talkspace-api/modules/assessment/model"
 	cm "talkspace-api/modules/consultation/model"
 	dm "talkspace-api/modules/doctor/model"
+	em "talkspace-api/modules/escalation/model"
+	im "talkspace-api/modules/intake/model"
+	jm "talkspace-api/modules/job/model"
+	nm "talkspace-api/modules/note/model"
+	rm "talkspace-api/modules/review/model"
+	sm "talkspace-api/modules/subscription/model"
 	tm "talkspace-api/modules/talkbot/model"
+	tsm "talkspace-api/modules/transaction/model"
 	um "talkspace-api/modules/user/model"
 )
 
 func Migration(db *gorm.DB) {
 	db.AutoMigrate(
 		&um.User{},
+		&um.MoodEntry{},
 		&dm.Doctor{},
 		&am.Admin{},
 		&cm.Consultation{},
 		&cm.Message{},
+		&cm.MessageReceipt{},
+		&cm.Attachment{},
+		&tm.Conversation{},
 		&tm.Talkbot{},
+		&tsm.Transaction{},
+		&sm.Plan{},
+		&sm.Subscription{},
+		&sm.SubscriptionPayment{},
+		&jm.JobRun{},
+		&apm.Availability{},
+		&apm.AvailabilityException{},
+		&apm.Appointment{},
+		&rm.Review{},
+		&nm.SessionNote{},
+		&nm.SessionNoteVersion{},
+		&asm.Assessment{},
+		&em.Escalation{},
+		&im.IntakeForm{},
+		&im.Intake{},
 	)
 
 	migrator := db.Migrator()
-	tables := []string{"users", "admins", "doctors", "consultations", "messages", "talkbots"}
+	tables := []string{"users", "admins", "doctors", "consultations", "messages", "message_receipts", "attachments", "conversations", "talkbots", "transactions", "plans", "subscriptions", "subscription_payments", "job_runs", "availabilities", "availability_exceptions", "appointments", "reviews", "session_notes", "session_note_versions", "assessments", "mood_entries", "escalations", "intake_forms", "intakes"}
 	for _, table := range tables {
 		if !migrator.HasTable(table) {
 			log.Fatalf("table %s was not successfully created", table)
 		}
 	}
+
+	// consultations used to store their status as a boolean
+	db.Exec("UPDATE consultations SET status = 'booked' WHERE status = 'true'")
+	db.Exec("UPDATE consultations SET status = 'cancelled' WHERE status = 'false'")
+
+	// messages written before sequence numbers existed are numbered in the
+	// order they were sent
+	db.Exec(`UPDATE messages SET seq = numbered.seq
+		FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY consultation_id ORDER BY created_at, id) AS seq FROM messages) AS numbered
+		WHERE messages.id = numbered.id AND NOT EXISTS (SELECT 1 FROM messages WHERE seq > 0)`)
+	db.Exec(`UPDATE consultations SET last_seq = counted.last_seq
+		FROM (SELECT consultation_id, MAX(seq) AS last_seq FROM messages GROUP BY consultation_id) AS counted
+		WHERE consultations.id = counted.consultation_id AND consultations.last_seq < counted.last_seq`)
+	db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_consultation_seq ON messages (consultation_id, seq)")
+
+	// subscription payments applied before they were recorded are every
+	// subscription transaction that was paid
+	db.Exec(`INSERT INTO subscription_payments (id, subscription_id, transaction_id, created_at)
+		SELECT gen_random_uuid(), subscription_id, id, COALESCE(paid_at, updated_at) FROM transactions
+		WHERE type = 'subscription' AND subscription_id IS NOT NULL AND status IN ('paid', 'refunded')
+		ON CONFLICT DO NOTHING`)
+
+	// talkbot messages go with their conversation, replies that outlived a
+	// deleted conversation before the key existed are removed with it
+	if !migrator.HasConstraint(&tm.Talkbot{}, "fk_talkbots_conversation") {
+		db.Exec("UPDATE talkbots SET conversation_id = NULL WHERE conversation_id = ''")
+		db.Exec(`DELETE FROM talkbots WHERE conversation_id IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM conversations WHERE conversations.id = talkbots.conversation_id)`)
+		db.Exec(`ALTER TABLE talkbots ADD CONSTRAINT fk_talkbots_conversation
+			FOREIGN KEY (conversation_id) REFERENCES conversations (id) ON DELETE CASCADE`)
+	}
+
 	log.Println("all tables were successfully migrated")
 }
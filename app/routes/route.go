@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"context"
+
 	"github.com/labstack/echo/v4"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
@@ -10,23 +12,52 @@ import (
 	ur "talkspace-api/modules/user/router"
 	tr "talkspace-api/modules/talkbot/router"
 	cs "talkspace-api/modules/consultation/router"
+	tsr "talkspace-api/modules/transaction/router"
+	sr "talkspace-api/modules/subscription/router"
+	jr "talkspace-api/modules/job/router"
+	apr "talkspace-api/modules/appointment/router"
+	rr "talkspace-api/modules/review/router"
+	nr "talkspace-api/modules/note/router"
+	asr "talkspace-api/modules/assessment/router"
+	er "talkspace-api/modules/escalation/router"
+	ir "talkspace-api/modules/intake/router"
 )
 
-func SetupRoutes(e *echo.Echo, db *gorm.DB, rdb *redis.Client) {
+// SetupRoutes mounts every module and returns a function that closes the
+// long-lived connections they hold.
+func SetupRoutes(e *echo.Echo, db *gorm.DB, rdb *redis.Client) func(ctx context.Context) error {
 
 	user := e.Group("/users")
 	admin := e.Group("/admins")
 	doctor := e.Group("/doctors")
 	talkbot := e.Group("/talkbots")
 	consultation := e.Group("/consultations")
+	transaction := e.Group("/transactions")
+	subscription := e.Group("/subscriptions")
+	job := e.Group("/jobs")
+	appointment := e.Group("/appointments")
+	review := e.Group("/reviews")
+	note := e.Group("/notes")
+	assessment := e.Group("/assessments")
+	escalation := e.Group("/escalations")
+	intake := e.Group("/intakes")
 
 
 
 	ur.UserRoutes(user, db, rdb)
 	dr.DoctorRoutes(doctor, db, rdb)
 	ar.AdminRoutes(admin, db, rdb)
-	tr.TalkbotRoutes(talkbot, db)
-	cs.ConsultationRoutes(consultation, db)
-
-
+	tr.TalkbotRoutes(talkbot, db, rdb)
+	hub := cs.ConsultationRoutes(consultation, db, rdb)
+	tsr.TransactionRoutes(transaction, db, rdb)
+	sr.SubscriptionRoutes(subscription, db, rdb)
+	jr.JobRoutes(job, db)
+	apr.AppointmentRoutes(appointment, db, rdb)
+	rr.ReviewRoutes(review, db, rdb)
+	nr.SessionNoteRoutes(note, db)
+	asr.AssessmentRoutes(assessment, db)
+	er.EscalationRoutes(escalation, db)
+	ir.IntakeRoutes(intake, db)
+
+	return hub.Shutdown
 }
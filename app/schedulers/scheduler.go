@@ -0,0 +1,137 @@
+package schedulers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"talkspace-api/app/configs"
+	ar "talkspace-api/modules/appointment/repository"
+	au "talkspace-api/modules/appointment/usecase"
+	cr "talkspace-api/modules/consultation/repository"
+	cu "talkspace-api/modules/consultation/usecase"
+	dr "talkspace-api/modules/doctor/repository"
+	er "talkspace-api/modules/escalation/repository"
+	eu "talkspace-api/modules/escalation/usecase"
+	ir "talkspace-api/modules/intake/repository"
+	je "talkspace-api/modules/job/entity"
+	jr "talkspace-api/modules/job/repository"
+	ju "talkspace-api/modules/job/usecase"
+	sr "talkspace-api/modules/subscription/repository"
+	su "talkspace-api/modules/subscription/usecase"
+	tr "talkspace-api/modules/transaction/repository"
+	tu "talkspace-api/modules/transaction/usecase"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/helper/encryption"
+	"talkspace-api/utils/helper/midtrans"
+	"talkspace-api/utils/helper/risk"
+	"talkspace-api/utils/helper/scheduler"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// SetupSchedulers registers the background jobs. It returns nil when the
+// scheduler is disabled with SCHEDULER_ENABLED=false.
+func SetupSchedulers(db *gorm.DB, rdb *redis.Client) *scheduler.Scheduler {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		logrus.Fatalf("failed to load scheduler configuration: %v", err)
+	}
+
+	if config.SCHEDULER.SCHEDULER_ENABLED == "false" {
+		logrus.Info("scheduler is disabled")
+		return nil
+	}
+
+	reminderDays := 3
+	if value, err := strconv.Atoi(config.SCHEDULER.SCHEDULER_REMINDER_DAYS); err == nil && value > 0 {
+		reminderDays = value
+	}
+
+	retentionDays := 30
+	if value, err := strconv.Atoi(config.SCHEDULER.SCHEDULER_RETENTION_DAYS); err == nil && value > 0 {
+		retentionDays = value
+	}
+
+	jobQueryRepository := jr.NewJobQueryRepository(db)
+	jobCommandRepository := jr.NewJobCommandRepository(db)
+	jobCommandUsecase := ju.NewJobCommandUsecase(jobCommandRepository, jobQueryRepository)
+
+	subscriptionQueryRepository := sr.NewSubscriptionQueryRepository(db)
+	subscriptionCommandRepository := sr.NewSubscriptionCommandRepository(db, rdb)
+	transactionQueryRepository := tr.NewTransactionQueryRepository(db, rdb)
+	transactionCommandRepository := tr.NewTransactionCommandRepository(db, rdb)
+	doctorQueryRepository := dr.NewDoctorQueryRepository(db, rdb)
+	userQueryRepository := ur.NewUserQueryRepository(db, rdb)
+	consultationCommandRepository := cr.NewConsultationCommandRepository(db, rdb)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+	appointmentCommandRepository := ar.NewAppointmentCommandRepository(db)
+	appointmentQueryRepository := ar.NewAppointmentQueryRepository(db)
+	escalationCommandRepository := er.NewEscalationCommandRepository(db)
+	escalationQueryRepository := er.NewEscalationQueryRepository(db)
+	intakeQueryRepository := ir.NewIntakeQueryRepository(db, encryption.NewCipher())
+	paymentGateway := midtrans.NewPaymentGateway()
+
+	transactionCommandUsecase := tu.NewTransactionCommandUsecase(transactionCommandRepository, transactionQueryRepository, doctorQueryRepository, userQueryRepository, consultationCommandRepository, consultationQueryRepository, appointmentCommandRepository, subscriptionCommandRepository, subscriptionQueryRepository, paymentGateway)
+	subscriptionCommandUsecase := su.NewSubscriptionCommandUsecase(subscriptionCommandRepository, subscriptionQueryRepository, transactionCommandUsecase, userQueryRepository)
+	appointmentCommandUsecase := au.NewAppointmentCommandUsecase(appointmentCommandRepository, appointmentQueryRepository, doctorQueryRepository, consultationCommandRepository, consultationQueryRepository, transactionCommandUsecase, intakeQueryRepository)
+	escalationCommandUsecase := eu.NewEscalationCommandUsecase(escalationCommandRepository, escalationQueryRepository, risk.NewDetector())
+	consultationCommandUsecase := cu.NewConsultationCommandUsecase(consultationCommandRepository, consultationQueryRepository, escalationCommandUsecase)
+
+	s := scheduler.New(rdb, &jobRecorder{jobCommandUsecase: jobCommandUsecase})
+
+	register(s, "expire-subscriptions", "*/10 * * * *", 5*time.Minute, func(ctx context.Context) (string, error) {
+		expired, err := subscriptionCommandUsecase.ExpireSubscriptions()
+		return fmt.Sprintf("%d subscriptions expired", expired), err
+	})
+
+	register(s, "renewal-reminders", "0 9 * * *", 15*time.Minute, func(ctx context.Context) (string, error) {
+		reminded, err := subscriptionCommandUsecase.SendRenewalReminders(reminderDays)
+		return fmt.Sprintf("%d renewal reminders sent", reminded), err
+	})
+
+	register(s, "release-unpaid-appointments", "*/5 * * * *", 2*time.Minute, func(ctx context.Context) (string, error) {
+		released, err := appointmentCommandUsecase.ReleaseUnpaidAppointments()
+		return fmt.Sprintf("%d unpaid appointments released", released), err
+	})
+
+	register(s, "close-consultations", "* * * * *", time.Minute, func(ctx context.Context) (string, error) {
+		closed, err := consultationCommandUsecase.CloseLapsedConsultations()
+		return fmt.Sprintf("%d consultations closed", len(closed)), err
+	})
+
+	register(s, "prune-job-runs", "30 3 * * *", 10*time.Minute, func(ctx context.Context) (string, error) {
+		pruned, err := jobCommandUsecase.PruneJobRuns(retentionDays)
+		return fmt.Sprintf("%d job runs pruned", pruned), err
+	})
+
+	return s
+}
+
+func register(s *scheduler.Scheduler, name string, spec string, timeout time.Duration, job scheduler.Job) {
+	if err := s.Register(name, spec, timeout, job); err != nil {
+		logrus.Fatalf("failed to register job %s: %v", name, err)
+	}
+}
+
+// jobRecorder stores scheduler runs through the job module so admins can
+// read them back from /jobs/runs.
+type jobRecorder struct {
+	jobCommandUsecase ju.JobCommandUsecaseInterface
+}
+
+func (jr *jobRecorder) RecordRun(run scheduler.Run) error {
+	_, err := jr.jobCommandUsecase.RecordJobRun(je.JobRun{
+		Job:         run.Job,
+		Instance:    run.Instance,
+		Status:      run.Status,
+		Message:     run.Message,
+		ScheduledAt: run.ScheduledAt,
+		StartedAt:   run.StartedAt,
+		FinishedAt:  run.FinishedAt,
+	})
+	return err
+}
@@ -1,9 +1,17 @@
 package main
 
 import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"talkspace-api/app/configs"
 	"talkspace-api/app/databases"
 	"talkspace-api/app/routes"
+	"talkspace-api/app/schedulers"
 
 	// "talkspace-api/docs"
 	"talkspace-api/middlewares"
@@ -50,7 +58,12 @@ func main() {
 	middlewares.Recover(e)
 	middlewares.CORS(e)
 
-	routes.SetupRoutes(e, pdb, rdb)
+	closeConnections := routes.SetupRoutes(e, pdb, rdb)
+
+	jobs := schedulers.SetupSchedulers(pdb, rdb)
+	if jobs != nil {
+		jobs.Start()
+	}
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
@@ -64,8 +77,30 @@ func main() {
 	}
 	address := host + ":" + port
 
-	logrus.Info("server is running on address ", address)
-	if err := e.Start(address); err != nil {
-		logrus.Fatalf("error starting server: %v", err)
+	go func() {
+		logrus.Info("server is running on address ", address)
+		if err := e.Start(address); err != nil && err != http.ErrServerClosed {
+			logrus.Fatalf("error starting server: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+
+	logrus.Info("shutting down server")
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	if err := e.Shutdown(ctx); err != nil {
+		logrus.Errorf("error shutting down server: %v", err)
+	}
+	if err := closeConnections(ctx); err != nil {
+		logrus.Errorf("error closing connections: %v", err)
+	}
+	if jobs != nil {
+		if err := jobs.Stop(ctx); err != nil {
+			logrus.Errorf("error stopping scheduler: %v", err)
+		}
 	}
 }
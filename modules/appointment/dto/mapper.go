@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"errors"
+	"talkspace-api/modules/appointment/entity"
+	te "talkspace-api/modules/transaction/entity"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+// Request
+func AvailabilityUpdateRequestToAvailabilityEntities(request AvailabilityUpdateRequest) []entity.Availability {
+	availabilities := []entity.Availability{}
+	for _, availability := range request.Availabilities {
+		availabilities = append(availabilities, entity.Availability{
+			Weekday:     availability.Weekday,
+			StartTime:   availability.StartTime,
+			EndTime:     availability.EndTime,
+			SlotMinutes: availability.SlotMinutes,
+		})
+	}
+	return availabilities
+}
+
+func AvailabilityExceptionRequestToAvailabilityExceptionEntity(request AvailabilityExceptionRequest) entity.AvailabilityException {
+	return entity.AvailabilityException{
+		Date:        request.Date,
+		StartTime:   request.StartTime,
+		EndTime:     request.EndTime,
+		Available:   request.Available,
+		SlotMinutes: request.SlotMinutes,
+		Reason:      request.Reason,
+	}
+}
+
+func AppointmentRequestToAppointmentEntity(request AppointmentRequest) (entity.Appointment, error) {
+	startAt, errParse := ParseStartAt(request.StartAt)
+	if errParse != nil {
+		return entity.Appointment{}, errParse
+	}
+
+	return entity.Appointment{
+		DoctorID: request.DoctorID,
+		StartAt:  startAt,
+		IntakeID: request.IntakeID,
+	}, nil
+}
+
+// ParseStartAt reads an RFC 3339 slot start such as "2000-12-30T09:00:00+07:00".
+func ParseStartAt(value string) (time.Time, error) {
+	startAt, errParse := time.Parse(time.RFC3339, value)
+	if errParse != nil {
+		return time.Time{}, errors.New(constant.ERROR_DATETIME_FORMAT)
+	}
+	return startAt, nil
+}
+
+// Response
+func AvailabilityEntityToAvailabilityResponse(response entity.Availability) AvailabilityResponse {
+	return AvailabilityResponse{
+		ID:          response.ID,
+		Weekday:     response.Weekday,
+		StartTime:   response.StartTime,
+		EndTime:     response.EndTime,
+		SlotMinutes: response.SlotMinutes,
+	}
+}
+
+func ListAvailabilityEntityToAvailabilityResponse(response []entity.Availability) []AvailabilityResponse {
+	availabilityResponses := []AvailabilityResponse{}
+	for _, availability := range response {
+		availabilityResponse := AvailabilityEntityToAvailabilityResponse(availability)
+		availabilityResponses = append(availabilityResponses, availabilityResponse)
+	}
+	return availabilityResponses
+}
+
+func AvailabilityExceptionEntityToAvailabilityExceptionResponse(response entity.AvailabilityException) AvailabilityExceptionResponse {
+	return AvailabilityExceptionResponse{
+		ID:          response.ID,
+		Date:        response.Date,
+		StartTime:   response.StartTime,
+		EndTime:     response.EndTime,
+		Available:   response.Available,
+		SlotMinutes: response.SlotMinutes,
+		Reason:      response.Reason,
+	}
+}
+
+func ListAvailabilityExceptionEntityToAvailabilityExceptionResponse(response []entity.AvailabilityException) []AvailabilityExceptionResponse {
+	exceptionResponses := []AvailabilityExceptionResponse{}
+	for _, exception := range response {
+		exceptionResponse := AvailabilityExceptionEntityToAvailabilityExceptionResponse(exception)
+		exceptionResponses = append(exceptionResponses, exceptionResponse)
+	}
+	return exceptionResponses
+}
+
+func AvailabilityEntitiesToDoctorAvailabilityResponse(doctorID string, availabilities []entity.Availability, exceptions []entity.AvailabilityException) DoctorAvailabilityResponse {
+	return DoctorAvailabilityResponse{
+		DoctorID:       doctorID,
+		Timezone:       constant.APPOINTMENT_TIMEZONE,
+		Availabilities: ListAvailabilityEntityToAvailabilityResponse(availabilities),
+		Exceptions:     ListAvailabilityExceptionEntityToAvailabilityExceptionResponse(exceptions),
+	}
+}
+
+func ListSlotEntityToSlotResponse(response []entity.Slot) []SlotResponse {
+	slotResponses := []SlotResponse{}
+	for _, slot := range response {
+		slotResponses = append(slotResponses, SlotResponse{
+			StartAt: slot.StartAt,
+			EndAt:   slot.EndAt,
+		})
+	}
+	return slotResponses
+}
+
+func AppointmentEntityToAppointmentResponse(response entity.Appointment) AppointmentResponse {
+	return AppointmentResponse{
+		ID:            response.ID,
+		UserID:        response.UserID,
+		DoctorID:      response.DoctorID,
+		TransactionID: response.TransactionID,
+		IntakeID:      response.IntakeID,
+		StartAt:       response.StartAt,
+		EndAt:         response.EndAt,
+		Status:        response.Status,
+		CancelledBy:   response.CancelledBy,
+		CancelReason:  response.CancelReason,
+		CancelledAt:   response.CancelledAt,
+		CreatedAt:     response.CreatedAt,
+	}
+}
+
+func ListAppointmentEntityToAppointmentResponse(response []entity.Appointment) []AppointmentResponse {
+	appointmentResponses := []AppointmentResponse{}
+	for _, appointment := range response {
+		appointmentResponse := AppointmentEntityToAppointmentResponse(appointment)
+		appointmentResponses = append(appointmentResponses, appointmentResponse)
+	}
+	return appointmentResponses
+}
+
+func AppointmentEntityToAppointmentPaymentResponse(appointment entity.Appointment, transaction te.Transaction) AppointmentPaymentResponse {
+	return AppointmentPaymentResponse{
+		Appointment:     AppointmentEntityToAppointmentResponse(appointment),
+		TransactionID:   transaction.ID,
+		TransactionCode: transaction.Code,
+		Amount:          transaction.Amount,
+		PaymentToken:    transaction.PaymentToken,
+		PaymentURL:      transaction.PaymentURL,
+	}
+}
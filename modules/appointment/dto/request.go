@@ -0,0 +1,37 @@
+package dto
+
+type (
+	AvailabilityRequest struct {
+		Weekday     int    `json:"weekday" form:"weekday"`
+		StartTime   string `json:"start_time" form:"start_time"`
+		EndTime     string `json:"end_time" form:"end_time"`
+		SlotMinutes int    `json:"slot_minutes" form:"slot_minutes"`
+	}
+
+	AvailabilityUpdateRequest struct {
+		Availabilities []AvailabilityRequest `json:"availabilities" form:"availabilities"`
+	}
+
+	AvailabilityExceptionRequest struct {
+		Date        string `json:"date" form:"date"`
+		StartTime   string `json:"start_time" form:"start_time"`
+		EndTime     string `json:"end_time" form:"end_time"`
+		Available   bool   `json:"available" form:"available"`
+		SlotMinutes int    `json:"slot_minutes" form:"slot_minutes"`
+		Reason      string `json:"reason" form:"reason"`
+	}
+
+	AppointmentRequest struct {
+		DoctorID string `json:"doctor_id" form:"doctor_id"`
+		StartAt  string `json:"start_at" form:"start_at"`
+		IntakeID string `json:"intake_id" form:"intake_id"`
+	}
+
+	AppointmentRescheduleRequest struct {
+		StartAt string `json:"start_at" form:"start_at"`
+	}
+
+	AppointmentCancelRequest struct {
+		Reason string `json:"reason" form:"reason"`
+	}
+)
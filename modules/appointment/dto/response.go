@@ -0,0 +1,59 @@
+package dto
+
+import "time"
+
+type (
+	AvailabilityResponse struct {
+		ID          string `json:"id"`
+		Weekday     int    `json:"weekday"`
+		StartTime   string `json:"start_time"`
+		EndTime     string `json:"end_time"`
+		SlotMinutes int    `json:"slot_minutes"`
+	}
+
+	AvailabilityExceptionResponse struct {
+		ID          string `json:"id"`
+		Date        string `json:"date"`
+		StartTime   string `json:"start_time"`
+		EndTime     string `json:"end_time"`
+		Available   bool   `json:"available"`
+		SlotMinutes int    `json:"slot_minutes"`
+		Reason      string `json:"reason"`
+	}
+
+	DoctorAvailabilityResponse struct {
+		DoctorID       string                          `json:"doctor_id"`
+		Timezone       string                          `json:"timezone"`
+		Availabilities []AvailabilityResponse          `json:"availabilities"`
+		Exceptions     []AvailabilityExceptionResponse `json:"exceptions"`
+	}
+
+	SlotResponse struct {
+		StartAt time.Time `json:"start_at"`
+		EndAt   time.Time `json:"end_at"`
+	}
+
+	AppointmentResponse struct {
+		ID            string     `json:"id"`
+		UserID        string     `json:"user_id"`
+		DoctorID      string     `json:"doctor_id"`
+		TransactionID string     `json:"transaction_id"`
+		IntakeID      string     `json:"intake_id"`
+		StartAt       time.Time  `json:"start_at"`
+		EndAt         time.Time  `json:"end_at"`
+		Status        string     `json:"status"`
+		CancelledBy   string     `json:"cancelled_by"`
+		CancelReason  string     `json:"cancel_reason"`
+		CancelledAt   *time.Time `json:"cancelled_at"`
+		CreatedAt     time.Time  `json:"created_at"`
+	}
+
+	AppointmentPaymentResponse struct {
+		Appointment     AppointmentResponse `json:"appointment"`
+		TransactionID   string              `json:"transaction_id"`
+		TransactionCode string              `json:"transaction_code"`
+		Amount          float64             `json:"amount"`
+		PaymentToken    string              `json:"payment_token"`
+		PaymentURL      string              `json:"payment_url"`
+	}
+)
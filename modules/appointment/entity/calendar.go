@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"errors"
+	"sort"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+)
+
+// Location is the time zone doctors publish their availability in.
+func Location() *time.Location {
+	location, errLoad := time.LoadLocation(constant.APPOINTMENT_TIMEZONE)
+	if errLoad != nil {
+		return time.FixedZone("WIB", 7*60*60)
+	}
+	return location
+}
+
+// ParseClock converts a "15:04" wall clock time into minutes after midnight.
+func ParseClock(value string) (int, error) {
+	clock, errParse := time.Parse(clockLayout, value)
+	if errParse != nil {
+		return 0, errors.New(constant.ERROR_TIME_FORMAT)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+// ParseDate returns midnight of a "2006-01-02" date in the availability time
+// zone.
+func ParseDate(value string) (time.Time, error) {
+	day, errParse := time.ParseInLocation(dateLayout, value, Location())
+	if errParse != nil {
+		return time.Time{}, errors.New(constant.ERROR_DATE_FORMAT)
+	}
+	return day, nil
+}
+
+// FormatDate is the inverse of ParseDate for a moment in any time zone.
+func FormatDate(moment time.Time) string {
+	return moment.In(Location()).Format(dateLayout)
+}
+
+// DaySlots expands the weekly availability of a doctor into the slots of a
+// single day. Exceptions for that day either add extra hours or block time;
+// an exception without hours that is not available blocks the whole day.
+// Existing bookings are not taken into account.
+func DaySlots(day time.Time, availabilities []Availability, exceptions []AvailabilityException) []Slot {
+	type window struct {
+		start, end, length int
+	}
+
+	windows := []window{}
+	blocked := []window{}
+
+	for _, availability := range availabilities {
+		if availability.Weekday != int(day.Weekday()) {
+			continue
+		}
+		start, errStart := ParseClock(availability.StartTime)
+		end, errEnd := ParseClock(availability.EndTime)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		windows = append(windows, window{start, end, availability.SlotMinutes})
+	}
+
+	for _, exception := range exceptions {
+		if exception.Date != day.Format(dateLayout) {
+			continue
+		}
+		if !exception.Available && exception.StartTime == "" {
+			return []Slot{}
+		}
+		start, errStart := ParseClock(exception.StartTime)
+		end, errEnd := ParseClock(exception.EndTime)
+		if errStart != nil || errEnd != nil {
+			continue
+		}
+		if exception.Available {
+			windows = append(windows, window{start, end, exception.SlotMinutes})
+		} else {
+			blocked = append(blocked, window{start, end, 0})
+		}
+	}
+
+	seen := map[int]bool{}
+	slots := []Slot{}
+
+	for _, w := range windows {
+		if w.length <= 0 {
+			continue
+		}
+		for start := w.start; start+w.length <= w.end; start += w.length {
+			end := start + w.length
+			if seen[start] {
+				continue
+			}
+
+			isBlocked := false
+			for _, b := range blocked {
+				if start < b.end && end > b.start {
+					isBlocked = true
+					break
+				}
+			}
+			if isBlocked {
+				continue
+			}
+
+			seen[start] = true
+			slots = append(slots, Slot{
+				StartAt: clockOn(day, start),
+				EndAt:   clockOn(day, end),
+			})
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].StartAt.Before(slots[j].StartAt)
+	})
+
+	return slots
+}
+
+// FreeSlots drops the slots that start before notBefore or overlap one of
+// the given appointments.
+func FreeSlots(slots []Slot, appointments []Appointment, notBefore time.Time) []Slot {
+	freeSlots := []Slot{}
+	for _, slot := range slots {
+		if slot.StartAt.Before(notBefore) {
+			continue
+		}
+
+		isBooked := false
+		for _, appointment := range appointments {
+			if slot.StartAt.Before(appointment.EndAt) && slot.EndAt.After(appointment.StartAt) {
+				isBooked = true
+				break
+			}
+		}
+		if !isBooked {
+			freeSlots = append(freeSlots, slot)
+		}
+	}
+	return freeSlots
+}
+
+// clockOn builds the wall clock time of minutes after midnight on day, so
+// daylight saving changes do not shift the slots.
+func clockOn(day time.Time, minutes int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, day.Location())
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+const (
+	// MinimumNotice is how far ahead a slot has to start to be bookable.
+	MinimumNotice = time.Hour
+	// ChangeCutoff is how long before the start a user may still reschedule
+	// or cancel a paid appointment.
+	ChangeCutoff = 2 * time.Hour
+	// PaymentHold is how long an unpaid booking keeps its slot.
+	PaymentHold = 30 * time.Minute
+)
+
+type Availability struct {
+	ID          string
+	DoctorID    string
+	Weekday     int
+	StartTime   string
+	EndTime     string
+	SlotMinutes int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type AvailabilityException struct {
+	ID          string
+	DoctorID    string
+	Date        string
+	StartTime   string
+	EndTime     string
+	Available   bool
+	SlotMinutes int
+	Reason      string
+	CreatedAt   time.Time
+}
+
+type Appointment struct {
+	ID            string
+	UserID        string
+	DoctorID      string
+	TransactionID string
+	IntakeID      string
+	StartAt       time.Time
+	EndAt         time.Time
+	Status        string
+	CancelledBy   string
+	CancelReason  string
+	CancelledAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type Slot struct {
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+// IsActive reports whether the appointment still holds its slot.
+func (a Appointment) IsActive() bool {
+	return a.Status == constant.APPOINTMENT_PENDING || a.Status == constant.APPOINTMENT_CONFIRMED
+}
+
+// CanChange reports whether a user may still move or cancel the appointment.
+// Unpaid bookings can always be changed.
+func (a Appointment) CanChange(now time.Time) bool {
+	if a.Status == constant.APPOINTMENT_PENDING {
+		return true
+	}
+	return a.StartAt.Sub(now) >= ChangeCutoff
+}
+
+// IsParticipant reports whether the user or doctor behind id takes part in
+// the appointment.
+func (a Appointment) IsParticipant(id string) bool {
+	return a.UserID == id || a.DoctorID == id
+}
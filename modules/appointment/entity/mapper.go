@@ -0,0 +1,135 @@
+package entity
+
+import "talkspace-api/modules/appointment/model"
+
+func AvailabilityEntityToAvailabilityModel(availabilityEntity Availability) model.Availability {
+	availabilityModel := model.Availability{
+		ID:          availabilityEntity.ID,
+		DoctorID:    availabilityEntity.DoctorID,
+		Weekday:     availabilityEntity.Weekday,
+		StartTime:   availabilityEntity.StartTime,
+		EndTime:     availabilityEntity.EndTime,
+		SlotMinutes: availabilityEntity.SlotMinutes,
+		CreatedAt:   availabilityEntity.CreatedAt,
+		UpdatedAt:   availabilityEntity.UpdatedAt,
+	}
+	return availabilityModel
+}
+
+func ListAvailabilityEntityToAvailabilityModel(availabilityEntities []Availability) []model.Availability {
+	listAvailabilityModel := []model.Availability{}
+	for _, availability := range availabilityEntities {
+		availabilityModel := AvailabilityEntityToAvailabilityModel(availability)
+		listAvailabilityModel = append(listAvailabilityModel, availabilityModel)
+	}
+	return listAvailabilityModel
+}
+
+func AvailabilityModelToAvailabilityEntity(availabilityModel model.Availability) Availability {
+	availabilityEntity := Availability{
+		ID:          availabilityModel.ID,
+		DoctorID:    availabilityModel.DoctorID,
+		Weekday:     availabilityModel.Weekday,
+		StartTime:   availabilityModel.StartTime,
+		EndTime:     availabilityModel.EndTime,
+		SlotMinutes: availabilityModel.SlotMinutes,
+		CreatedAt:   availabilityModel.CreatedAt,
+		UpdatedAt:   availabilityModel.UpdatedAt,
+	}
+	return availabilityEntity
+}
+
+func ListAvailabilityModelToAvailabilityEntity(availabilityModels []model.Availability) []Availability {
+	listAvailabilityEntity := []Availability{}
+	for _, availability := range availabilityModels {
+		availabilityEntity := AvailabilityModelToAvailabilityEntity(availability)
+		listAvailabilityEntity = append(listAvailabilityEntity, availabilityEntity)
+	}
+	return listAvailabilityEntity
+}
+
+func AvailabilityExceptionEntityToAvailabilityExceptionModel(exceptionEntity AvailabilityException) model.AvailabilityException {
+	exceptionModel := model.AvailabilityException{
+		ID:          exceptionEntity.ID,
+		DoctorID:    exceptionEntity.DoctorID,
+		Date:        exceptionEntity.Date,
+		StartTime:   exceptionEntity.StartTime,
+		EndTime:     exceptionEntity.EndTime,
+		Available:   exceptionEntity.Available,
+		SlotMinutes: exceptionEntity.SlotMinutes,
+		Reason:      exceptionEntity.Reason,
+		CreatedAt:   exceptionEntity.CreatedAt,
+	}
+	return exceptionModel
+}
+
+func AvailabilityExceptionModelToAvailabilityExceptionEntity(exceptionModel model.AvailabilityException) AvailabilityException {
+	exceptionEntity := AvailabilityException{
+		ID:          exceptionModel.ID,
+		DoctorID:    exceptionModel.DoctorID,
+		Date:        exceptionModel.Date,
+		StartTime:   exceptionModel.StartTime,
+		EndTime:     exceptionModel.EndTime,
+		Available:   exceptionModel.Available,
+		SlotMinutes: exceptionModel.SlotMinutes,
+		Reason:      exceptionModel.Reason,
+		CreatedAt:   exceptionModel.CreatedAt,
+	}
+	return exceptionEntity
+}
+
+func ListAvailabilityExceptionModelToAvailabilityExceptionEntity(exceptionModels []model.AvailabilityException) []AvailabilityException {
+	listExceptionEntity := []AvailabilityException{}
+	for _, exception := range exceptionModels {
+		exceptionEntity := AvailabilityExceptionModelToAvailabilityExceptionEntity(exception)
+		listExceptionEntity = append(listExceptionEntity, exceptionEntity)
+	}
+	return listExceptionEntity
+}
+
+func AppointmentEntityToAppointmentModel(appointmentEntity Appointment) model.Appointment {
+	appointmentModel := model.Appointment{
+		ID:            appointmentEntity.ID,
+		UserID:        appointmentEntity.UserID,
+		DoctorID:      appointmentEntity.DoctorID,
+		TransactionID: appointmentEntity.TransactionID,
+		IntakeID:      appointmentEntity.IntakeID,
+		StartAt:       appointmentEntity.StartAt,
+		EndAt:         appointmentEntity.EndAt,
+		Status:        appointmentEntity.Status,
+		CancelledBy:   appointmentEntity.CancelledBy,
+		CancelReason:  appointmentEntity.CancelReason,
+		CancelledAt:   appointmentEntity.CancelledAt,
+		CreatedAt:     appointmentEntity.CreatedAt,
+		UpdatedAt:     appointmentEntity.UpdatedAt,
+	}
+	return appointmentModel
+}
+
+func AppointmentModelToAppointmentEntity(appointmentModel model.Appointment) Appointment {
+	appointmentEntity := Appointment{
+		ID:            appointmentModel.ID,
+		UserID:        appointmentModel.UserID,
+		DoctorID:      appointmentModel.DoctorID,
+		TransactionID: appointmentModel.TransactionID,
+		IntakeID:      appointmentModel.IntakeID,
+		StartAt:       appointmentModel.StartAt,
+		EndAt:         appointmentModel.EndAt,
+		Status:        appointmentModel.Status,
+		CancelledBy:   appointmentModel.CancelledBy,
+		CancelReason:  appointmentModel.CancelReason,
+		CancelledAt:   appointmentModel.CancelledAt,
+		CreatedAt:     appointmentModel.CreatedAt,
+		UpdatedAt:     appointmentModel.UpdatedAt,
+	}
+	return appointmentEntity
+}
+
+func ListAppointmentModelToAppointmentEntity(appointmentModels []model.Appointment) []Appointment {
+	listAppointmentEntity := []Appointment{}
+	for _, appointment := range appointmentModels {
+		appointmentEntity := AppointmentModelToAppointmentEntity(appointment)
+		listAppointmentEntity = append(listAppointmentEntity, appointmentEntity)
+	}
+	return listAppointmentEntity
+}
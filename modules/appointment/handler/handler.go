@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/appointment/dto"
+	"talkspace-api/modules/appointment/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type appointmentHandler struct {
+	appointmentCommandUsecase usecase.AppointmentCommandUsecaseInterface
+	appointmentQueryUsecase   usecase.AppointmentQueryUsecaseInterface
+}
+
+func NewAppointmentHandler(acu usecase.AppointmentCommandUsecaseInterface, aqu usecase.AppointmentQueryUsecaseInterface) *appointmentHandler {
+	return &appointmentHandler{
+		appointmentCommandUsecase: acu,
+		appointmentQueryUsecase:   aqu,
+	}
+}
+
+// Query
+func (ah *appointmentHandler) GetAvailability(c echo.Context) error {
+	doctorIDParam := c.Param("doctor_id")
+	if doctorIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	availabilities, exceptions, errGet := ah.appointmentQueryUsecase.GetAvailability(doctorIDParam)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	availabilityResponse := dto.AvailabilityEntitiesToDoctorAvailabilityResponse(doctorIDParam, availabilities, exceptions)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, availabilityResponse))
+}
+
+func (ah *appointmentHandler) GetAvailableSlots(c echo.Context) error {
+	doctorIDParam := c.Param("doctor_id")
+	if doctorIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	slots, errGet := ah.appointmentQueryUsecase.GetAvailableSlots(doctorIDParam, c.QueryParam("date"))
+	if errGet != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(slots) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	slotResponses := dto.ListSlotEntityToSlotResponse(slots)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, slotResponses))
+}
+
+func (ah *appointmentHandler) GetAppointmentByID(c echo.Context) error {
+	appointmentIDParam := c.Param("appointment_id")
+	if appointmentIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	appointment, errGetID := ah.appointmentQueryUsecase.GetAppointmentByID(appointmentIDParam)
+	if errGetID != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGetID.Error()))
+	}
+
+	if role != constant.ADMIN && !appointment.IsParticipant(tokenID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	appointmentResponse := dto.AppointmentEntityToAppointmentResponse(appointment)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, appointmentResponse))
+}
+
+func (ah *appointmentHandler) GetAppointmentsByUserID(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenUserID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN && (role != constant.USER || userIDParam != tokenUserID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	appointments, totalItems, errGet := ah.appointmentQueryUsecase.GetAppointmentsByUserID(userIDParam, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(appointments) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	appointmentResponses := dto.ListAppointmentEntityToAppointmentResponse(appointments)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		appointmentResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (ah *appointmentHandler) GetAppointmentsByDoctorID(c echo.Context) error {
+	doctorIDParam := c.Param("doctor_id")
+	if doctorIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenDoctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN && (role != constant.DOCTOR || doctorIDParam != tokenDoctorID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	appointments, totalItems, errGet := ah.appointmentQueryUsecase.GetAppointmentsByDoctorID(doctorIDParam, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(appointments) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	appointmentResponses := dto.ListAppointmentEntityToAppointmentResponse(appointments)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		appointmentResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Command
+func (ah *appointmentHandler) SetAvailabilities(c echo.Context) error {
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	availabilityRequest := dto.AvailabilityUpdateRequest{}
+
+	errBind := c.Bind(&availabilityRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	availabilityEntities := dto.AvailabilityUpdateRequestToAvailabilityEntities(availabilityRequest)
+
+	availabilities, errSet := ah.appointmentCommandUsecase.SetAvailabilities(doctorID, availabilityEntities)
+	if errSet != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errSet.Error()))
+	}
+
+	availabilityResponses := dto.ListAvailabilityEntityToAvailabilityResponse(availabilities)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, availabilityResponses))
+}
+
+func (ah *appointmentHandler) CreateAvailabilityException(c echo.Context) error {
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	exceptionRequest := dto.AvailabilityExceptionRequest{}
+
+	errBind := c.Bind(&exceptionRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	exceptionEntity := dto.AvailabilityExceptionRequestToAvailabilityExceptionEntity(exceptionRequest)
+	exceptionEntity.DoctorID = doctorID
+
+	exception, errCreate := ah.appointmentCommandUsecase.CreateAvailabilityException(exceptionEntity)
+	if errCreate != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCreate.Error()))
+	}
+
+	exceptionResponse := dto.AvailabilityExceptionEntityToAvailabilityExceptionResponse(exception)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, exceptionResponse))
+}
+
+func (ah *appointmentHandler) DeleteAvailabilityException(c echo.Context) error {
+	exceptionIDParam := c.Param("exception_id")
+	if exceptionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	errDelete := ah.appointmentCommandUsecase.DeleteAvailabilityException(exceptionIDParam, doctorID)
+	if errDelete != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errDelete.Error()))
+	}
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_DELETED, nil))
+}
+
+func (ah *appointmentHandler) CreateAppointment(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	appointmentRequest := dto.AppointmentRequest{}
+
+	errBind := c.Bind(&appointmentRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	appointmentEntity, errParse := dto.AppointmentRequestToAppointmentEntity(appointmentRequest)
+	if errParse != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errParse.Error()))
+	}
+	appointmentEntity.UserID = userID
+
+	appointment, transaction, errCreate := ah.appointmentCommandUsecase.CreateAppointment(appointmentEntity)
+	if errCreate != nil {
+		if errCreate.Error() == constant.ERROR_SLOT_BOOKED {
+			return c.JSON(http.StatusConflict, responses.ErrorResponse(errCreate.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCreate.Error()))
+	}
+
+	appointmentResponse := dto.AppointmentEntityToAppointmentPaymentResponse(appointment, transaction)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_APPOINTMENT, appointmentResponse))
+}
+
+func (ah *appointmentHandler) RescheduleAppointment(c echo.Context) error {
+	appointmentIDParam := c.Param("appointment_id")
+	if appointmentIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	rescheduleRequest := dto.AppointmentRescheduleRequest{}
+
+	errBind := c.Bind(&rescheduleRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	startAt, errParse := dto.ParseStartAt(rescheduleRequest.StartAt)
+	if errParse != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errParse.Error()))
+	}
+
+	appointment, errReschedule := ah.appointmentCommandUsecase.RescheduleAppointment(appointmentIDParam, tokenID, role, startAt)
+	if errReschedule != nil {
+		if errReschedule.Error() == constant.ERROR_SLOT_BOOKED {
+			return c.JSON(http.StatusConflict, responses.ErrorResponse(errReschedule.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errReschedule.Error()))
+	}
+
+	appointmentResponse := dto.AppointmentEntityToAppointmentResponse(appointment)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RESCHEDULED, appointmentResponse))
+}
+
+func (ah *appointmentHandler) CancelAppointment(c echo.Context) error {
+	appointmentIDParam := c.Param("appointment_id")
+	if appointmentIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	cancelRequest := dto.AppointmentCancelRequest{}
+
+	errBind := c.Bind(&cancelRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	appointment, errCancel := ah.appointmentCommandUsecase.CancelAppointment(appointmentIDParam, tokenID, role, cancelRequest.Reason)
+	if errCancel != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCancel.Error()))
+	}
+
+	appointmentResponse := dto.AppointmentEntityToAppointmentResponse(appointment)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_CANCELLED, appointmentResponse))
+}
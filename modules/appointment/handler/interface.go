@@ -0,0 +1,20 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type AppointmentHandlerInterface interface {
+	// Query
+	GetAvailability(c echo.Context) error
+	GetAvailableSlots(c echo.Context) error
+	GetAppointmentByID(c echo.Context) error
+	GetAppointmentsByUserID(c echo.Context) error
+	GetAppointmentsByDoctorID(c echo.Context) error
+
+	// Command
+	SetAvailabilities(c echo.Context) error
+	CreateAvailabilityException(c echo.Context) error
+	DeleteAvailabilityException(c echo.Context) error
+	CreateAppointment(c echo.Context) error
+	RescheduleAppointment(c echo.Context) error
+	CancelAppointment(c echo.Context) error
+}
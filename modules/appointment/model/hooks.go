@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (a *Availability) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	a.ID = UUID.String()
+	return nil
+}
+
+func (ae *AvailabilityException) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	ae.ID = UUID.String()
+	return nil
+}
+
+func (a *Appointment) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	a.ID = UUID.String()
+
+	if a.Status == "" {
+		a.Status = "pending"
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+type Availability struct {
+	ID          string `gorm:"primarykey"`
+	DoctorID    string `gorm:"index;not null"`
+	Weekday     int    `gorm:"not null"`
+	StartTime   string `gorm:"type:varchar(5);not null"`
+	EndTime     string `gorm:"type:varchar(5);not null"`
+	SlotMinutes int    `gorm:"not null;default:60"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type AvailabilityException struct {
+	ID          string `gorm:"primarykey"`
+	DoctorID    string `gorm:"index:idx_availability_exceptions_doctor_date;not null"`
+	Date        string `gorm:"type:varchar(10);index:idx_availability_exceptions_doctor_date;not null"`
+	StartTime   string `gorm:"type:varchar(5)"`
+	EndTime     string `gorm:"type:varchar(5)"`
+	Available   bool   `gorm:"not null;default:false"`
+	SlotMinutes int    `gorm:"not null;default:60"`
+	Reason      string
+	CreatedAt   time.Time
+}
+
+type Appointment struct {
+	ID            string    `gorm:"primarykey"`
+	UserID        string    `gorm:"index;not null"`
+	DoctorID      string    `gorm:"not null;uniqueIndex:idx_appointments_doctor_slot,where:status <> 'cancelled'"`
+	TransactionID string    `gorm:"index"`
+	IntakeID      string    `gorm:"index"`
+	StartAt       time.Time `gorm:"not null;uniqueIndex:idx_appointments_doctor_slot"`
+	EndAt         time.Time `gorm:"not null"`
+	Status        string    `gorm:"type:varchar(20);not null;default:'pending'"`
+	CancelledBy   string
+	CancelReason  string
+	CancelledAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/appointment/entity"
+	"talkspace-api/modules/appointment/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type appointmentCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewAppointmentCommandRepository(db *gorm.DB) AppointmentCommandRepositoryInterface {
+	return &appointmentCommandRepository{
+		db: db,
+	}
+}
+
+// ReplaceAvailabilities swaps the whole weekly schedule of a doctor at once
+// so windows never have to be diffed one by one.
+func (acr *appointmentCommandRepository) ReplaceAvailabilities(doctorID string, availabilities []entity.Availability) ([]entity.Availability, error) {
+	availabilityModels := entity.ListAvailabilityEntityToAvailabilityModel(availabilities)
+
+	errTx := acr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("doctor_id = ?", doctorID).Delete(&model.Availability{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(availabilityModels) == 0 {
+			return nil
+		}
+
+		return tx.Create(&availabilityModels).Error
+	})
+	if errTx != nil {
+		return nil, errTx
+	}
+
+	availabilityEntities := entity.ListAvailabilityModelToAvailabilityEntity(availabilityModels)
+
+	return availabilityEntities, nil
+}
+
+func (acr *appointmentCommandRepository) CreateAvailabilityException(exception entity.AvailabilityException) (entity.AvailabilityException, error) {
+	exceptionModel := entity.AvailabilityExceptionEntityToAvailabilityExceptionModel(exception)
+
+	result := acr.db.Create(&exceptionModel)
+	if result.Error != nil {
+		return entity.AvailabilityException{}, result.Error
+	}
+
+	exceptionEntity := entity.AvailabilityExceptionModelToAvailabilityExceptionEntity(exceptionModel)
+
+	return exceptionEntity, nil
+}
+
+func (acr *appointmentCommandRepository) DeleteAvailabilityException(id string, doctorID string) error {
+	result := acr.db.Where("id = ? AND doctor_id = ?", id, doctorID).Delete(&model.AvailabilityException{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	return nil
+}
+
+// CreateAppointment books a slot. Bookings for the same doctor are
+// serialized with a transaction scoped advisory lock, so two concurrent
+// requests for one slot cannot both pass the overlap check.
+func (acr *appointmentCommandRepository) CreateAppointment(appointment entity.Appointment) (entity.Appointment, error) {
+	appointmentModel := entity.AppointmentEntityToAppointmentModel(appointment)
+
+	errTx := acr.db.Transaction(func(tx *gorm.DB) error {
+		errCheck := lockAndCheckSlot(tx, appointmentModel)
+		if errCheck != nil {
+			return errCheck
+		}
+
+		return tx.Create(&appointmentModel).Error
+	})
+	if errTx != nil {
+		return entity.Appointment{}, errTx
+	}
+
+	appointmentEntity := entity.AppointmentModelToAppointmentEntity(appointmentModel)
+
+	return appointmentEntity, nil
+}
+
+func (acr *appointmentCommandRepository) UpdateAppointmentTransaction(id string, transactionID string) (entity.Appointment, error) {
+	return acr.updateAppointment(id, func(appointment *model.Appointment) error {
+		appointment.TransactionID = transactionID
+		return nil
+	})
+}
+
+func (acr *appointmentCommandRepository) RescheduleAppointment(id string, startAt time.Time, endAt time.Time) (entity.Appointment, error) {
+	return acr.updateAppointment(id, func(appointment *model.Appointment) error {
+		if appointment.Status == constant.APPOINTMENT_CANCELLED {
+			return errors.New(constant.ERROR_APPOINTMENT_STATUS)
+		}
+
+		appointment.StartAt = startAt
+		appointment.EndAt = endAt
+
+		return nil
+	})
+}
+
+// ConfirmAppointment marks a booking as paid. It reports whether this call
+// made the change so repeated payment notifications are harmless.
+func (acr *appointmentCommandRepository) ConfirmAppointment(id string) (entity.Appointment, bool, error) {
+	changed := false
+
+	appointment, errUpdate := acr.updateAppointment(id, func(appointment *model.Appointment) error {
+		switch appointment.Status {
+		case constant.APPOINTMENT_CONFIRMED:
+			return nil
+		case constant.APPOINTMENT_PENDING:
+			appointment.Status = constant.APPOINTMENT_CONFIRMED
+			changed = true
+			return nil
+		default:
+			return errors.New(constant.ERROR_APPOINTMENT_STATUS)
+		}
+	})
+	if errUpdate != nil {
+		return entity.Appointment{}, false, errUpdate
+	}
+
+	return appointment, changed, nil
+}
+
+func (acr *appointmentCommandRepository) CancelAppointment(id string, cancelledBy string, reason string) (entity.Appointment, error) {
+	return acr.updateAppointment(id, func(appointment *model.Appointment) error {
+		if appointment.Status == constant.APPOINTMENT_CANCELLED {
+			return errors.New(constant.ERROR_APPOINTMENT_STATUS)
+		}
+
+		now := time.Now()
+		appointment.Status = constant.APPOINTMENT_CANCELLED
+		appointment.CancelledBy = cancelledBy
+		appointment.CancelReason = reason
+		appointment.CancelledAt = &now
+
+		return nil
+	})
+}
+
+// updateAppointment runs update on a locked appointment row. A changed
+// schedule is checked against the other bookings of the doctor before it is
+// saved.
+func (acr *appointmentCommandRepository) updateAppointment(id string, update func(appointment *model.Appointment) error) (entity.Appointment, error) {
+	appointmentModel := model.Appointment{}
+
+	errTx := acr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&appointmentModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		startAt, endAt := appointmentModel.StartAt, appointmentModel.EndAt
+
+		errUpdate := update(&appointmentModel)
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		if !appointmentModel.StartAt.Equal(startAt) || !appointmentModel.EndAt.Equal(endAt) {
+			errCheck := lockAndCheckSlot(tx, appointmentModel)
+			if errCheck != nil {
+				return errCheck
+			}
+		}
+
+		return tx.Save(&appointmentModel).Error
+	})
+	if errTx != nil {
+		return entity.Appointment{}, errTx
+	}
+
+	appointmentEntity := entity.AppointmentModelToAppointmentEntity(appointmentModel)
+
+	return appointmentEntity, nil
+}
+
+// lockAndCheckSlot takes the schedule lock of the doctor for the rest of tx
+// and fails when the appointment overlaps another active booking of the
+// doctor or the user.
+func lockAndCheckSlot(tx *gorm.DB, appointment model.Appointment) error {
+	result := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "appointment:"+appointment.DoctorID)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	activeStatus := []string{constant.APPOINTMENT_PENDING, constant.APPOINTMENT_CONFIRMED}
+
+	var overlapping int64
+	result = tx.Model(&model.Appointment{}).
+		Where("(doctor_id = ? OR user_id = ?) AND status IN ?", appointment.DoctorID, appointment.UserID, activeStatus).
+		Where("start_at < ? AND end_at > ?", appointment.EndAt, appointment.StartAt).
+		Where("id <> ?", appointment.ID).
+		Count(&overlapping)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if overlapping > 0 {
+		return errors.New(constant.ERROR_SLOT_BOOKED)
+	}
+
+	return nil
+}
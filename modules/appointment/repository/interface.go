@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"talkspace-api/modules/appointment/entity"
+	"time"
+)
+
+type AppointmentCommandRepositoryInterface interface {
+	ReplaceAvailabilities(doctorID string, availabilities []entity.Availability) ([]entity.Availability, error)
+	CreateAvailabilityException(exception entity.AvailabilityException) (entity.AvailabilityException, error)
+	DeleteAvailabilityException(id string, doctorID string) error
+	CreateAppointment(appointment entity.Appointment) (entity.Appointment, error)
+	UpdateAppointmentTransaction(id string, transactionID string) (entity.Appointment, error)
+	RescheduleAppointment(id string, startAt time.Time, endAt time.Time) (entity.Appointment, error)
+	ConfirmAppointment(id string) (entity.Appointment, bool, error)
+	CancelAppointment(id string, cancelledBy string, reason string) (entity.Appointment, error)
+}
+
+type AppointmentQueryRepositoryInterface interface {
+	GetAvailabilitiesByDoctorID(doctorID string) ([]entity.Availability, error)
+	GetAvailabilityExceptionsByDoctorID(doctorID string, fromDate string, toDate string) ([]entity.AvailabilityException, error)
+	GetAppointmentByID(id string) (entity.Appointment, error)
+	GetAppointmentsByUserID(userID string, page, limit int) ([]entity.Appointment, int, error)
+	GetAppointmentsByDoctorID(doctorID string, page, limit int) ([]entity.Appointment, int, error)
+	GetActiveAppointmentsByDoctorID(doctorID string, from time.Time, to time.Time) ([]entity.Appointment, error)
+	GetUnpaidAppointments(createdBefore time.Time) ([]entity.Appointment, error)
+}
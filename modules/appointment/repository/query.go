@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/appointment/entity"
+	"talkspace-api/modules/appointment/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type appointmentQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewAppointmentQueryRepository(db *gorm.DB) AppointmentQueryRepositoryInterface {
+	return &appointmentQueryRepository{
+		db: db,
+	}
+}
+
+func (aqr *appointmentQueryRepository) GetAvailabilitiesByDoctorID(doctorID string) ([]entity.Availability, error) {
+	var availabilityModels []model.Availability
+	result := aqr.db.Where("doctor_id = ?", doctorID).Order("weekday ASC, start_time ASC").Find(&availabilityModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	availabilities := entity.ListAvailabilityModelToAvailabilityEntity(availabilityModels)
+
+	return availabilities, nil
+}
+
+// GetAvailabilityExceptionsByDoctorID returns the exceptions between two
+// "2006-01-02" dates, both inclusive. Dates are stored in that layout so they
+// compare correctly as strings.
+func (aqr *appointmentQueryRepository) GetAvailabilityExceptionsByDoctorID(doctorID string, fromDate string, toDate string) ([]entity.AvailabilityException, error) {
+	var exceptionModels []model.AvailabilityException
+	result := aqr.db.Where("doctor_id = ? AND date >= ? AND date <= ?", doctorID, fromDate, toDate).
+		Order("date ASC, start_time ASC").
+		Find(&exceptionModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	exceptions := entity.ListAvailabilityExceptionModelToAvailabilityExceptionEntity(exceptionModels)
+
+	return exceptions, nil
+}
+
+func (aqr *appointmentQueryRepository) GetAppointmentByID(id string) (entity.Appointment, error) {
+	appointmentModel := model.Appointment{}
+	result := aqr.db.Where("id = ?", id).First(&appointmentModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Appointment{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Appointment{}, result.Error
+	}
+
+	appointmentEntity := entity.AppointmentModelToAppointmentEntity(appointmentModel)
+
+	return appointmentEntity, nil
+}
+
+func (aqr *appointmentQueryRepository) GetAppointmentsByUserID(userID string, page, limit int) ([]entity.Appointment, int, error) {
+	return aqr.getAppointments("user_id = ?", userID, page, limit)
+}
+
+func (aqr *appointmentQueryRepository) GetAppointmentsByDoctorID(doctorID string, page, limit int) ([]entity.Appointment, int, error) {
+	return aqr.getAppointments("doctor_id = ?", doctorID, page, limit)
+}
+
+func (aqr *appointmentQueryRepository) getAppointments(condition string, id string, page, limit int) ([]entity.Appointment, int, error) {
+	offset := (page - 1) * limit
+
+	var totalItems int64
+	result := aqr.db.Model(&model.Appointment{}).Where(condition, id).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var appointmentModels []model.Appointment
+	result = aqr.db.Where(condition, id).Order("start_at DESC").Offset(offset).Limit(limit).Find(&appointmentModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	appointments := entity.ListAppointmentModelToAppointmentEntity(appointmentModels)
+
+	return appointments, int(totalItems), nil
+}
+
+func (aqr *appointmentQueryRepository) GetActiveAppointmentsByDoctorID(doctorID string, from time.Time, to time.Time) ([]entity.Appointment, error) {
+	var appointmentModels []model.Appointment
+	result := aqr.db.Where("doctor_id = ? AND status IN ?", doctorID, []string{constant.APPOINTMENT_PENDING, constant.APPOINTMENT_CONFIRMED}).
+		Where("start_at < ? AND end_at > ?", to, from).
+		Order("start_at ASC").
+		Find(&appointmentModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	appointments := entity.ListAppointmentModelToAppointmentEntity(appointmentModels)
+
+	return appointments, nil
+}
+
+// GetUnpaidAppointments returns pending bookings created before the given
+// moment, whose payment hold has run out.
+func (aqr *appointmentQueryRepository) GetUnpaidAppointments(createdBefore time.Time) ([]entity.Appointment, error) {
+	var appointmentModels []model.Appointment
+	result := aqr.db.Where("status = ? AND created_at < ?", constant.APPOINTMENT_PENDING, createdBefore).Find(&appointmentModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	appointments := entity.ListAppointmentModelToAppointmentEntity(appointmentModels)
+
+	return appointments, nil
+}
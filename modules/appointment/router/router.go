@@ -0,0 +1,61 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/appointment/handler"
+	"talkspace-api/modules/appointment/repository"
+	"talkspace-api/modules/appointment/usecase"
+	cr "talkspace-api/modules/consultation/repository"
+	dr "talkspace-api/modules/doctor/repository"
+	ir "talkspace-api/modules/intake/repository"
+	sr "talkspace-api/modules/subscription/repository"
+	tr "talkspace-api/modules/transaction/repository"
+	tu "talkspace-api/modules/transaction/usecase"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/helper/encryption"
+	"talkspace-api/utils/helper/midtrans"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func AppointmentRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	appointmentQueryRepository := repository.NewAppointmentQueryRepository(db)
+	appointmentCommandRepository := repository.NewAppointmentCommandRepository(db)
+	transactionQueryRepository := tr.NewTransactionQueryRepository(db, rdb)
+	transactionCommandRepository := tr.NewTransactionCommandRepository(db, rdb)
+	doctorQueryRepository := dr.NewDoctorQueryRepository(db, rdb)
+	userQueryRepository := ur.NewUserQueryRepository(db, rdb)
+	consultationCommandRepository := cr.NewConsultationCommandRepository(db, rdb)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+	subscriptionCommandRepository := sr.NewSubscriptionCommandRepository(db, rdb)
+	subscriptionQueryRepository := sr.NewSubscriptionQueryRepository(db)
+	intakeQueryRepository := ir.NewIntakeQueryRepository(db, encryption.NewCipher())
+	paymentGateway := midtrans.NewPaymentGateway()
+
+	transactionCommandUsecase := tu.NewTransactionCommandUsecase(transactionCommandRepository, transactionQueryRepository, doctorQueryRepository, userQueryRepository, consultationCommandRepository, consultationQueryRepository, appointmentCommandRepository, subscriptionCommandRepository, subscriptionQueryRepository, paymentGateway)
+
+	appointmentQueryUsecase := usecase.NewAppointmentQueryUsecase(appointmentCommandRepository, appointmentQueryRepository)
+	appointmentCommandUsecase := usecase.NewAppointmentCommandUsecase(appointmentCommandRepository, appointmentQueryRepository, doctorQueryRepository, consultationCommandRepository, consultationQueryRepository, transactionCommandUsecase, intakeQueryRepository)
+
+	appointmentHandler := handler.NewAppointmentHandler(appointmentCommandUsecase, appointmentQueryUsecase)
+
+	availability := e.Group("/availability", middlewares.JWTMiddleware(false))
+	availability.PUT("", appointmentHandler.SetAvailabilities)
+	availability.POST("/exceptions", appointmentHandler.CreateAvailabilityException)
+	availability.DELETE("/exceptions/:exception_id", appointmentHandler.DeleteAvailabilityException)
+
+	doctors := e.Group("/doctors", middlewares.JWTMiddleware(false))
+	doctors.GET("/:doctor_id", appointmentHandler.GetAppointmentsByDoctorID)
+	doctors.GET("/:doctor_id/availability", appointmentHandler.GetAvailability)
+	doctors.GET("/:doctor_id/slots", appointmentHandler.GetAvailableSlots)
+
+	users := e.Group("/users", middlewares.JWTMiddleware(false))
+	users.GET("/:user_id", appointmentHandler.GetAppointmentsByUserID)
+
+	e.POST("", appointmentHandler.CreateAppointment, middlewares.JWTMiddleware(false))
+	e.GET("/:appointment_id", appointmentHandler.GetAppointmentByID, middlewares.JWTMiddleware(false))
+	e.PATCH("/:appointment_id/reschedule", appointmentHandler.RescheduleAppointment, middlewares.JWTMiddleware(false))
+	e.PATCH("/:appointment_id/cancel", appointmentHandler.CancelAppointment, middlewares.JWTMiddleware(false))
+}
@@ -0,0 +1,393 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"talkspace-api/modules/appointment/entity"
+	"talkspace-api/modules/appointment/repository"
+	ce "talkspace-api/modules/consultation/entity"
+	cr "talkspace-api/modules/consultation/repository"
+	dr "talkspace-api/modules/doctor/repository"
+	ie "talkspace-api/modules/intake/entity"
+	ir "talkspace-api/modules/intake/repository"
+	te "talkspace-api/modules/transaction/entity"
+	tu "talkspace-api/modules/transaction/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/validator"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type appointmentCommandUsecase struct {
+	appointmentCommandRepository  repository.AppointmentCommandRepositoryInterface
+	appointmentQueryRepository    repository.AppointmentQueryRepositoryInterface
+	doctorQueryRepository         dr.DoctorQueryRepositoryInterface
+	consultationCommandRepository cr.ConsultationCommandRepositoryInterface
+	consultationQueryRepository   cr.ConsultationQueryRepositoryInterface
+	transactionCommandUsecase     tu.TransactionCommandUsecaseInterface
+	intakeQueryRepository         ir.IntakeQueryRepositoryInterface
+}
+
+func NewAppointmentCommandUsecase(acr repository.AppointmentCommandRepositoryInterface, aqr repository.AppointmentQueryRepositoryInterface, dqr dr.DoctorQueryRepositoryInterface, ccr cr.ConsultationCommandRepositoryInterface, cqr cr.ConsultationQueryRepositoryInterface, tcu tu.TransactionCommandUsecaseInterface, iqr ir.IntakeQueryRepositoryInterface) AppointmentCommandUsecaseInterface {
+	return &appointmentCommandUsecase{
+		appointmentCommandRepository:  acr,
+		appointmentQueryRepository:    aqr,
+		doctorQueryRepository:         dqr,
+		consultationCommandRepository: ccr,
+		consultationQueryRepository:   cqr,
+		transactionCommandUsecase:     tcu,
+		intakeQueryRepository:         iqr,
+	}
+}
+
+func (acu *appointmentCommandUsecase) SetAvailabilities(doctorID string, availabilities []entity.Availability) ([]entity.Availability, error) {
+	if doctorID == "" {
+		return nil, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	for i := range availabilities {
+		if availabilities[i].Weekday < 0 || availabilities[i].Weekday > 6 {
+			return nil, errors.New(constant.ERROR_WEEKDAY_INVALID)
+		}
+
+		errWindow := normalizeWindow(&availabilities[i].StartTime, &availabilities[i].EndTime, &availabilities[i].SlotMinutes)
+		if errWindow != nil {
+			return nil, errWindow
+		}
+
+		availabilities[i].DoctorID = doctorID
+	}
+
+	for i, availability := range availabilities {
+		for _, other := range availabilities[i+1:] {
+			if availability.Weekday == other.Weekday && availability.StartTime < other.EndTime && other.StartTime < availability.EndTime {
+				return nil, errors.New(constant.ERROR_AVAILABILITY_OVERLAP)
+			}
+		}
+	}
+
+	availabilityEntities, errReplace := acu.appointmentCommandRepository.ReplaceAvailabilities(doctorID, availabilities)
+	if errReplace != nil {
+		return nil, errReplace
+	}
+
+	return availabilityEntities, nil
+}
+
+func (acu *appointmentCommandUsecase) CreateAvailabilityException(exception entity.AvailabilityException) (entity.AvailabilityException, error) {
+	errEmpty := validator.IsDataEmpty([]string{"doctor_id", "date"}, exception.DoctorID, exception.Date)
+	if errEmpty != nil {
+		return entity.AvailabilityException{}, errEmpty
+	}
+
+	_, errDate := entity.ParseDate(exception.Date)
+	if errDate != nil {
+		return entity.AvailabilityException{}, errDate
+	}
+
+	// a day off needs no hours, extra hours and partial blocks do
+	if exception.Available || exception.StartTime != "" || exception.EndTime != "" {
+		errWindow := normalizeWindow(&exception.StartTime, &exception.EndTime, &exception.SlotMinutes)
+		if errWindow != nil {
+			return entity.AvailabilityException{}, errWindow
+		}
+	}
+
+	exceptionEntity, errCreate := acu.appointmentCommandRepository.CreateAvailabilityException(exception)
+	if errCreate != nil {
+		return entity.AvailabilityException{}, errCreate
+	}
+
+	return exceptionEntity, nil
+}
+
+func (acu *appointmentCommandUsecase) DeleteAvailabilityException(id string, doctorID string) error {
+	if id == "" {
+		return errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	return acu.appointmentCommandRepository.DeleteAvailabilityException(id, doctorID)
+}
+
+// CreateAppointment holds a free slot for the user and creates the payment
+// for it. The slot is confirmed once the payment settles and released if it
+// is not paid within entity.PaymentHold. The intake of the user goes with
+// the booking to the doctor.
+func (acu *appointmentCommandUsecase) CreateAppointment(appointment entity.Appointment) (entity.Appointment, te.Transaction, error) {
+	errEmpty := validator.IsDataEmpty([]string{"user_id", "doctor_id"}, appointment.UserID, appointment.DoctorID)
+	if errEmpty != nil {
+		return entity.Appointment{}, te.Transaction{}, errEmpty
+	}
+
+	doctor, errGetDoctor := acu.doctorQueryRepository.GetDoctorByID(appointment.DoctorID)
+	if errGetDoctor != nil {
+		return entity.Appointment{}, te.Transaction{}, errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	if !doctor.Status {
+		return entity.Appointment{}, te.Transaction{}, errors.New(constant.ERROR_DOCTOR_INACTIVE)
+	}
+
+	intakeID, errIntake := acu.attachableIntake(appointment.UserID, appointment.IntakeID)
+	if errIntake != nil {
+		return entity.Appointment{}, te.Transaction{}, errIntake
+	}
+
+	slot, errSlot := findSlot(acu.appointmentQueryRepository, appointment.DoctorID, appointment.StartAt, "")
+	if errSlot != nil {
+		return entity.Appointment{}, te.Transaction{}, errSlot
+	}
+
+	appointmentEntity, errCreate := acu.appointmentCommandRepository.CreateAppointment(entity.Appointment{
+		UserID:   appointment.UserID,
+		DoctorID: appointment.DoctorID,
+		IntakeID: intakeID,
+		StartAt:  slot.StartAt,
+		EndAt:    slot.EndAt,
+		Status:   constant.APPOINTMENT_PENDING,
+	})
+	if errCreate != nil {
+		return entity.Appointment{}, te.Transaction{}, errCreate
+	}
+
+	transaction, errCharge := acu.transactionCommandUsecase.CreateTransaction(te.Transaction{
+		UserID:        appointmentEntity.UserID,
+		DoctorID:      appointmentEntity.DoctorID,
+		AppointmentID: appointmentEntity.ID,
+	})
+	if errCharge != nil {
+		_, errCancel := acu.appointmentCommandRepository.CancelAppointment(appointmentEntity.ID, constant.SYSTEM, "payment could not be created")
+		if errCancel != nil {
+			logrus.Errorf("failed to release appointment %s: %v", appointmentEntity.ID, errCancel)
+		}
+		return entity.Appointment{}, te.Transaction{}, errCharge
+	}
+
+	appointmentEntity, errUpdate := acu.appointmentCommandRepository.UpdateAppointmentTransaction(appointmentEntity.ID, transaction.ID)
+	if errUpdate != nil {
+		return entity.Appointment{}, te.Transaction{}, errUpdate
+	}
+
+	return appointmentEntity, transaction, nil
+}
+
+// attachableIntake picks the intake to send with a booking, the one the
+// user chose or else their latest. It has to be theirs and answered within
+// ie.IntakeValidity.
+func (acu *appointmentCommandUsecase) attachableIntake(userID string, intakeID string) (string, error) {
+	var intake ie.Intake
+	var errGet error
+	if intakeID != "" {
+		intake, errGet = acu.intakeQueryRepository.GetIntakeByID(intakeID)
+	} else {
+		intake, errGet = acu.intakeQueryRepository.GetLatestIntakeByUserID(userID)
+	}
+	if errGet != nil {
+		if errGet.Error() == constant.ERROR_ID_NOTFOUND {
+			return "", errors.New(constant.ERROR_INTAKE_REQUIRED)
+		}
+		return "", errGet
+	}
+
+	if intake.UserID != userID || time.Since(intake.CreatedAt) > ie.IntakeValidity {
+		return "", errors.New(constant.ERROR_INTAKE_REQUIRED)
+	}
+
+	return intake.ID, nil
+}
+
+func (acu *appointmentCommandUsecase) RescheduleAppointment(id string, actorID string, role string, startAt time.Time) (entity.Appointment, error) {
+	appointment, errGet := acu.getParticipatingAppointment(id, actorID, role)
+	if errGet != nil {
+		return entity.Appointment{}, errGet
+	}
+
+	if !appointment.IsActive() {
+		return entity.Appointment{}, errors.New(constant.ERROR_APPOINTMENT_STATUS)
+	}
+
+	now := time.Now()
+	if (role == constant.USER && !appointment.CanChange(now)) || !appointment.StartAt.After(now) {
+		return entity.Appointment{}, errors.New(constant.ERROR_APPOINTMENT_CUTOFF)
+	}
+
+	slot, errSlot := findSlot(acu.appointmentQueryRepository, appointment.DoctorID, startAt, appointment.ID)
+	if errSlot != nil {
+		return entity.Appointment{}, errSlot
+	}
+
+	appointmentEntity, errReschedule := acu.appointmentCommandRepository.RescheduleAppointment(appointment.ID, slot.StartAt, slot.EndAt)
+	if errReschedule != nil {
+		return entity.Appointment{}, errReschedule
+	}
+
+	consultation, errGetRoom := acu.getConsultation(appointmentEntity)
+	if errGetRoom != nil {
+		return entity.Appointment{}, errGetRoom
+	}
+
+	if consultation.ID != "" {
+		errSchedule := acu.consultationCommandRepository.UpdateConsultationSchedule(consultation.ID, slot.StartAt, slot.EndAt)
+		if errSchedule != nil {
+			return entity.Appointment{}, errSchedule
+		}
+	}
+
+	return appointmentEntity, nil
+}
+
+// CancelAppointment releases the slot of an appointment. Users may cancel a
+// paid appointment up to entity.ChangeCutoff before it starts, doctors and
+// admins until it ends; a paid appointment is refunded in full.
+func (acu *appointmentCommandUsecase) CancelAppointment(id string, actorID string, role string, reason string) (entity.Appointment, error) {
+	appointment, errGet := acu.getParticipatingAppointment(id, actorID, role)
+	if errGet != nil {
+		return entity.Appointment{}, errGet
+	}
+
+	if !appointment.IsActive() {
+		return entity.Appointment{}, errors.New(constant.ERROR_APPOINTMENT_STATUS)
+	}
+
+	now := time.Now()
+	if (role == constant.USER && !appointment.CanChange(now)) || !now.Before(appointment.EndAt) {
+		return entity.Appointment{}, errors.New(constant.ERROR_APPOINTMENT_CUTOFF)
+	}
+
+	consultation, errGetRoom := acu.getConsultation(appointment)
+	if errGetRoom != nil {
+		return entity.Appointment{}, errGetRoom
+	}
+
+	// a session that already started can only be ended, not cancelled
+	if consultation.ID != "" && consultation.Status != constant.CONSULTATION_BOOKED && consultation.Status != constant.CONSULTATION_WAITING {
+		return entity.Appointment{}, errors.New(constant.ERROR_APPOINTMENT_STATUS)
+	}
+
+	if reason == "" {
+		reason = "cancelled by " + role
+	}
+
+	appointmentEntity, errCancel := acu.appointmentCommandRepository.CancelAppointment(appointment.ID, role, reason)
+	if errCancel != nil {
+		return entity.Appointment{}, errCancel
+	}
+
+	if appointment.Status != constant.APPOINTMENT_CONFIRMED || appointment.TransactionID == "" {
+		return appointmentEntity, nil
+	}
+
+	if consultation.ID != "" {
+		_, _, errClose := acu.consultationCommandRepository.UpdateConsultationStatus(consultation.ID, constant.CONSULTATION_CANCELLED, role)
+		if errClose != nil {
+			return entity.Appointment{}, errClose
+		}
+	}
+
+	_, errRefund := acu.transactionCommandUsecase.RefundTransaction(appointment.TransactionID, 0, fmt.Sprintf("appointment %s: %s", appointment.ID, reason))
+	if errRefund != nil {
+		logrus.Errorf("failed to refund cancelled appointment %s: %v", appointment.ID, errRefund)
+	}
+
+	return appointmentEntity, nil
+}
+
+// ReleaseUnpaidAppointments cancels bookings whose payment hold has run out
+// so their slots can be booked again, and expires their payments so a late
+// payment is turned away. It returns how many were released.
+func (acu *appointmentCommandUsecase) ReleaseUnpaidAppointments() (int, error) {
+	appointments, errGet := acu.appointmentQueryRepository.GetUnpaidAppointments(time.Now().Add(-entity.PaymentHold))
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	released := 0
+	for _, appointment := range appointments {
+		_, errCancel := acu.appointmentCommandRepository.CancelAppointment(appointment.ID, constant.SYSTEM, "payment not completed in time")
+		if errCancel != nil {
+			if errCancel.Error() != constant.ERROR_APPOINTMENT_STATUS {
+				logrus.Errorf("failed to release appointment %s: %v", appointment.ID, errCancel)
+			}
+			continue
+		}
+		released++
+
+		if appointment.TransactionID == "" {
+			continue
+		}
+
+		_, errExpire := acu.transactionCommandUsecase.ExpireTransaction(appointment.TransactionID)
+		if errExpire != nil {
+			logrus.Errorf("failed to expire the payment of released appointment %s: %v", appointment.ID, errExpire)
+		}
+	}
+
+	return released, nil
+}
+
+func (acu *appointmentCommandUsecase) getParticipatingAppointment(id string, actorID string, role string) (entity.Appointment, error) {
+	if id == "" {
+		return entity.Appointment{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	appointment, errGetID := acu.appointmentQueryRepository.GetAppointmentByID(id)
+	if errGetID != nil {
+		return entity.Appointment{}, errGetID
+	}
+
+	if role != constant.ADMIN && !appointment.IsParticipant(actorID) {
+		return entity.Appointment{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	return appointment, nil
+}
+
+// getConsultation returns the room opened by the payment of an appointment,
+// or an empty consultation while it is unpaid.
+func (acu *appointmentCommandUsecase) getConsultation(appointment entity.Appointment) (ce.Consultation, error) {
+	if appointment.TransactionID == "" {
+		return ce.Consultation{}, nil
+	}
+
+	consultation, errGetRoom := acu.consultationQueryRepository.GetConsultationByTransactionID(appointment.TransactionID)
+	if errGetRoom != nil {
+		if errGetRoom.Error() == constant.ERROR_DATA_NOTFOUND {
+			return ce.Consultation{}, nil
+		}
+		return ce.Consultation{}, errGetRoom
+	}
+
+	return consultation, nil
+}
+
+// normalizeWindow validates a start and end wall clock time, rewrites them as
+// "15:04" so they compare as strings and defaults the slot length to an hour.
+func normalizeWindow(startTime *string, endTime *string, slotMinutes *int) error {
+	start, errStart := entity.ParseClock(*startTime)
+	if errStart != nil {
+		return errStart
+	}
+
+	end, errEnd := entity.ParseClock(*endTime)
+	if errEnd != nil {
+		return errEnd
+	}
+
+	if end <= start {
+		return errors.New(constant.ERROR_TIME_RANGE)
+	}
+
+	if *slotMinutes == 0 {
+		*slotMinutes = 60
+	}
+
+	if *slotMinutes < 15 || *slotMinutes > 240 {
+		return errors.New(constant.ERROR_SLOT_DURATION)
+	}
+
+	*startTime = fmt.Sprintf("%02d:%02d", start/60, start%60)
+	*endTime = fmt.Sprintf("%02d:%02d", end/60, end%60)
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"talkspace-api/modules/appointment/entity"
+	te "talkspace-api/modules/transaction/entity"
+	"time"
+)
+
+type AppointmentCommandUsecaseInterface interface {
+	SetAvailabilities(doctorID string, availabilities []entity.Availability) ([]entity.Availability, error)
+	CreateAvailabilityException(exception entity.AvailabilityException) (entity.AvailabilityException, error)
+	DeleteAvailabilityException(id string, doctorID string) error
+	CreateAppointment(appointment entity.Appointment) (entity.Appointment, te.Transaction, error)
+	RescheduleAppointment(id string, actorID string, role string, startAt time.Time) (entity.Appointment, error)
+	CancelAppointment(id string, actorID string, role string, reason string) (entity.Appointment, error)
+	ReleaseUnpaidAppointments() (int, error)
+}
+
+type AppointmentQueryUsecaseInterface interface {
+	GetAvailability(doctorID string) ([]entity.Availability, []entity.AvailabilityException, error)
+	GetAvailableSlots(doctorID string, date string) ([]entity.Slot, error)
+	GetAppointmentByID(id string) (entity.Appointment, error)
+	GetAppointmentsByUserID(userID string, page, limit int) ([]entity.Appointment, int, error)
+	GetAppointmentsByDoctorID(doctorID string, page, limit int) ([]entity.Appointment, int, error)
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/appointment/entity"
+	"talkspace-api/modules/appointment/repository"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type appointmentQueryUsecase struct {
+	appointmentCommandRepository repository.AppointmentCommandRepositoryInterface
+	appointmentQueryRepository   repository.AppointmentQueryRepositoryInterface
+}
+
+func NewAppointmentQueryUsecase(acr repository.AppointmentCommandRepositoryInterface, aqr repository.AppointmentQueryRepositoryInterface) AppointmentQueryUsecaseInterface {
+	return &appointmentQueryUsecase{
+		appointmentCommandRepository: acr,
+		appointmentQueryRepository:   aqr,
+	}
+}
+
+func (aqu *appointmentQueryUsecase) GetAvailability(doctorID string) ([]entity.Availability, []entity.AvailabilityException, error) {
+	if doctorID == "" {
+		return nil, nil, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	availabilities, errGet := aqu.appointmentQueryRepository.GetAvailabilitiesByDoctorID(doctorID)
+	if errGet != nil {
+		return nil, nil, errGet
+	}
+
+	exceptions, errGetExceptions := aqu.appointmentQueryRepository.GetAvailabilityExceptionsByDoctorID(doctorID, entity.FormatDate(time.Now()), "9999-12-31")
+	if errGetExceptions != nil {
+		return nil, nil, errGetExceptions
+	}
+
+	return availabilities, exceptions, nil
+}
+
+func (aqu *appointmentQueryUsecase) GetAvailableSlots(doctorID string, date string) ([]entity.Slot, error) {
+	if doctorID == "" {
+		return nil, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	day, errParse := entity.ParseDate(date)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	return availableSlots(aqu.appointmentQueryRepository, doctorID, day, "")
+}
+
+func (aqu *appointmentQueryUsecase) GetAppointmentByID(id string) (entity.Appointment, error) {
+	if id == "" {
+		return entity.Appointment{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	appointment, errGetID := aqu.appointmentQueryRepository.GetAppointmentByID(id)
+	if errGetID != nil {
+		return entity.Appointment{}, errGetID
+	}
+
+	return appointment, nil
+}
+
+func (aqu *appointmentQueryUsecase) GetAppointmentsByUserID(userID string, page, limit int) ([]entity.Appointment, int, error) {
+	appointments, totalItems, errGet := aqu.appointmentQueryRepository.GetAppointmentsByUserID(userID, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return appointments, totalItems, nil
+}
+
+func (aqu *appointmentQueryUsecase) GetAppointmentsByDoctorID(doctorID string, page, limit int) ([]entity.Appointment, int, error) {
+	appointments, totalItems, errGet := aqu.appointmentQueryRepository.GetAppointmentsByDoctorID(doctorID, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return appointments, totalItems, nil
+}
+
+// availableSlots lists the slots of a doctor on day that can still be booked.
+// The appointment excludeID is ignored so it can be moved within its own
+// time.
+func availableSlots(aqr repository.AppointmentQueryRepositoryInterface, doctorID string, day time.Time, excludeID string) ([]entity.Slot, error) {
+	availabilities, errGet := aqr.GetAvailabilitiesByDoctorID(doctorID)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	date := entity.FormatDate(day)
+	exceptions, errGetExceptions := aqr.GetAvailabilityExceptionsByDoctorID(doctorID, date, date)
+	if errGetExceptions != nil {
+		return nil, errGetExceptions
+	}
+
+	booked, errGetBooked := aqr.GetActiveAppointmentsByDoctorID(doctorID, day, day.AddDate(0, 0, 1))
+	if errGetBooked != nil {
+		return nil, errGetBooked
+	}
+
+	appointments := []entity.Appointment{}
+	for _, appointment := range booked {
+		if appointment.ID != excludeID {
+			appointments = append(appointments, appointment)
+		}
+	}
+
+	slots := entity.DaySlots(day, availabilities, exceptions)
+
+	return entity.FreeSlots(slots, appointments, time.Now().Add(entity.MinimumNotice)), nil
+}
+
+// findSlot returns the free slot of a doctor that starts exactly at startAt.
+func findSlot(aqr repository.AppointmentQueryRepositoryInterface, doctorID string, startAt time.Time, excludeID string) (entity.Slot, error) {
+	day, errParse := entity.ParseDate(entity.FormatDate(startAt))
+	if errParse != nil {
+		return entity.Slot{}, errParse
+	}
+
+	slots, errGet := availableSlots(aqr, doctorID, day, excludeID)
+	if errGet != nil {
+		return entity.Slot{}, errGet
+	}
+
+	for _, slot := range slots {
+		if slot.StartAt.Equal(startAt) {
+			return slot, nil
+		}
+	}
+
+	return entity.Slot{}, errors.New(constant.ERROR_SLOT_UNAVAILABLE)
+}
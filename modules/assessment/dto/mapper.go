@@ -0,0 +1,88 @@
+package dto
+
+import "talkspace-api/modules/assessment/entity"
+
+func InstrumentEntityToInstrumentResponse(response entity.Instrument) InstrumentResponse {
+	optionResponses := []OptionResponse{}
+	for _, option := range response.Options {
+		optionResponses = append(optionResponses, OptionResponse{
+			Value: option.Value,
+			Label: option.Label,
+		})
+	}
+
+	return InstrumentResponse{
+		Code:         response.Code,
+		Version:      response.Version,
+		Title:        response.Title,
+		Instructions: response.Instructions,
+		Questions:    response.Questions,
+		Options:      optionResponses,
+		MaxScore:     response.MaxScore(),
+		Bands:        ListBandEntityToBandResponse(response.Bands),
+	}
+}
+
+func ListInstrumentEntityToInstrumentResponse(response []entity.Instrument) []InstrumentResponse {
+	instrumentResponses := []InstrumentResponse{}
+	for _, instrument := range response {
+		instrumentResponse := InstrumentEntityToInstrumentResponse(instrument)
+		instrumentResponses = append(instrumentResponses, instrumentResponse)
+	}
+	return instrumentResponses
+}
+
+func ListBandEntityToBandResponse(response []entity.Band) []BandResponse {
+	bandResponses := []BandResponse{}
+	for _, band := range response {
+		bandResponses = append(bandResponses, BandResponse{
+			Min:      band.Min,
+			Max:      band.Max,
+			Severity: band.Severity,
+		})
+	}
+	return bandResponses
+}
+
+func AssessmentEntityToAssessmentResponse(response entity.Assessment) AssessmentResponse {
+	return AssessmentResponse{
+		ID:                response.ID,
+		UserID:            response.UserID,
+		InstrumentCode:    response.InstrumentCode,
+		InstrumentVersion: response.InstrumentVersion,
+		Answers:           response.Answers,
+		Score:             response.Score,
+		Severity:          response.Severity,
+		Flagged:           response.Flagged,
+		CreatedAt:         response.CreatedAt,
+	}
+}
+
+func ListAssessmentEntityToAssessmentResponse(response []entity.Assessment) []AssessmentResponse {
+	assessmentResponses := []AssessmentResponse{}
+	for _, assessment := range response {
+		assessmentResponse := AssessmentEntityToAssessmentResponse(assessment)
+		assessmentResponses = append(assessmentResponses, assessmentResponse)
+	}
+	return assessmentResponses
+}
+
+func ChartEntityToAssessmentChartResponse(instrument entity.Instrument, points []entity.ChartPoint) AssessmentChartResponse {
+	pointResponses := []ChartPointResponse{}
+	for _, point := range points {
+		pointResponses = append(pointResponses, ChartPointResponse{
+			AssessmentID:      point.AssessmentID,
+			InstrumentVersion: point.InstrumentVersion,
+			Score:             point.Score,
+			Severity:          point.Severity,
+			CreatedAt:         point.CreatedAt,
+		})
+	}
+
+	return AssessmentChartResponse{
+		InstrumentCode: instrument.Code,
+		MaxScore:       instrument.MaxScore(),
+		Bands:          ListBandEntityToBandResponse(instrument.Bands),
+		Points:         pointResponses,
+	}
+}
@@ -0,0 +1,8 @@
+package dto
+
+type (
+	AssessmentRequest struct {
+		Version int   `json:"version" form:"version"`
+		Answers []int `json:"answers" form:"answers"`
+	}
+)
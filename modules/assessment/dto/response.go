@@ -0,0 +1,54 @@
+package dto
+
+import "time"
+
+type (
+	InstrumentResponse struct {
+		Code         string           `json:"code"`
+		Version      int              `json:"version"`
+		Title        string           `json:"title"`
+		Instructions string           `json:"instructions"`
+		Questions    []string         `json:"questions"`
+		Options      []OptionResponse `json:"options"`
+		MaxScore     int              `json:"max_score"`
+		Bands        []BandResponse   `json:"bands"`
+	}
+
+	OptionResponse struct {
+		Value int    `json:"value"`
+		Label string `json:"label"`
+	}
+
+	BandResponse struct {
+		Min      int    `json:"min"`
+		Max      int    `json:"max"`
+		Severity string `json:"severity"`
+	}
+
+	AssessmentResponse struct {
+		ID                string    `json:"id"`
+		UserID            string    `json:"user_id"`
+		InstrumentCode    string    `json:"instrument_code"`
+		InstrumentVersion int       `json:"instrument_version"`
+		Answers           []int     `json:"answers"`
+		Score             int       `json:"score"`
+		Severity          string    `json:"severity"`
+		Flagged           bool      `json:"flagged"`
+		CreatedAt         time.Time `json:"created_at"`
+	}
+
+	AssessmentChartResponse struct {
+		InstrumentCode string               `json:"instrument_code"`
+		MaxScore       int                  `json:"max_score"`
+		Bands          []BandResponse       `json:"bands"`
+		Points         []ChartPointResponse `json:"points"`
+	}
+
+	ChartPointResponse struct {
+		AssessmentID      string    `json:"assessment_id"`
+		InstrumentVersion int       `json:"instrument_version"`
+		Score             int       `json:"score"`
+		Severity          string    `json:"severity"`
+		CreatedAt         time.Time `json:"created_at"`
+	}
+)
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// ChartDefaultRange is how far back a history chart goes when no start
+// date is given.
+const ChartDefaultRange = 180 * 24 * time.Hour
+
+type Assessment struct {
+	ID                string
+	UserID            string
+	InstrumentCode    string
+	InstrumentVersion int
+	Answers           []int
+	Score             int
+	Severity          string
+	Flagged           bool
+	CreatedAt         time.Time
+}
+
+// ChartPoint is one score in the history of a user, for drawing charts.
+type ChartPoint struct {
+	AssessmentID      string
+	InstrumentVersion int
+	Score             int
+	Severity          string
+	CreatedAt         time.Time
+}
@@ -0,0 +1,186 @@
+package entity
+
+import (
+	"errors"
+	"talkspace-api/utils/constant"
+)
+
+const (
+	InstrumentPHQ9 = "phq9"
+	InstrumentGAD7 = "gad7"
+)
+
+type (
+	// Instrument is one version of a standardized questionnaire. A version is
+	// never changed once published: a new one is added instead, so older
+	// results keep the questions and bands they were scored with.
+	Instrument struct {
+		Code         string
+		Version      int
+		Title        string
+		Instructions string
+		Questions    []string
+		Options      []Option
+		Bands        []Band
+		// CriticalItems are the questions, by index, whose answer is flagged
+		// for follow-up whenever it is above the lowest option.
+		CriticalItems []int
+	}
+
+	Option struct {
+		Value int
+		Label string
+	}
+
+	// Band maps a range of total scores, both ends inclusive, to a severity.
+	Band struct {
+		Min      int
+		Max      int
+		Severity string
+	}
+)
+
+var frequencyOptions = []Option{
+	{Value: 0, Label: "Not at all"},
+	{Value: 1, Label: "Several days"},
+	{Value: 2, Label: "More than half the days"},
+	{Value: 3, Label: "Nearly every day"},
+}
+
+// Instruments lists every published version of every instrument, oldest
+// version first.
+var Instruments = map[string][]Instrument{
+	InstrumentPHQ9: {
+		{
+			Code:         InstrumentPHQ9,
+			Version:      1,
+			Title:        "Patient Health Questionnaire (PHQ-9)",
+			Instructions: "Over the last 2 weeks, how often have you been bothered by any of the following problems?",
+			Questions: []string{
+				"Little interest or pleasure in doing things",
+				"Feeling down, depressed, or hopeless",
+				"Trouble falling or staying asleep, or sleeping too much",
+				"Feeling tired or having little energy",
+				"Poor appetite or overeating",
+				"Feeling bad about yourself, or that you are a failure or have let yourself or your family down",
+				"Trouble concentrating on things, such as reading the newspaper or watching television",
+				"Moving or speaking so slowly that other people could have noticed, or the opposite, being so fidgety or restless that you have been moving around a lot more than usual",
+				"Thoughts that you would be better off dead, or of hurting yourself in some way",
+			},
+			Options: frequencyOptions,
+			Bands: []Band{
+				{Min: 0, Max: 4, Severity: "minimal"},
+				{Min: 5, Max: 9, Severity: "mild"},
+				{Min: 10, Max: 14, Severity: "moderate"},
+				{Min: 15, Max: 19, Severity: "moderately_severe"},
+				{Min: 20, Max: 27, Severity: "severe"},
+			},
+			CriticalItems: []int{8},
+		},
+	},
+	InstrumentGAD7: {
+		{
+			Code:         InstrumentGAD7,
+			Version:      1,
+			Title:        "Generalized Anxiety Disorder (GAD-7)",
+			Instructions: "Over the last 2 weeks, how often have you been bothered by the following problems?",
+			Questions: []string{
+				"Feeling nervous, anxious, or on edge",
+				"Not being able to stop or control worrying",
+				"Worrying too much about different things",
+				"Trouble relaxing",
+				"Being so restless that it is hard to sit still",
+				"Becoming easily annoyed or irritable",
+				"Feeling afraid, as if something awful might happen",
+			},
+			Options: frequencyOptions,
+			Bands: []Band{
+				{Min: 0, Max: 4, Severity: "minimal"},
+				{Min: 5, Max: 9, Severity: "mild"},
+				{Min: 10, Max: 14, Severity: "moderate"},
+				{Min: 15, Max: 21, Severity: "severe"},
+			},
+		},
+	},
+}
+
+// GetInstrument returns a version of an instrument, the latest one when
+// version is 0.
+func GetInstrument(code string, version int) (Instrument, error) {
+	versions := Instruments[code]
+	if len(versions) == 0 {
+		return Instrument{}, errors.New(constant.ERROR_INSTRUMENT_NOTFOUND)
+	}
+
+	if version == 0 {
+		return versions[len(versions)-1], nil
+	}
+
+	for _, instrument := range versions {
+		if instrument.Version == version {
+			return instrument, nil
+		}
+	}
+
+	return Instrument{}, errors.New(constant.ERROR_INSTRUMENT_NOTFOUND)
+}
+
+// LatestInstruments returns the current version of every instrument.
+func LatestInstruments() []Instrument {
+	instruments := []Instrument{}
+	for _, code := range []string{InstrumentPHQ9, InstrumentGAD7} {
+		versions := Instruments[code]
+		instruments = append(instruments, versions[len(versions)-1])
+	}
+	return instruments
+}
+
+func (i Instrument) MaxScore() int {
+	highest := 0
+	for _, option := range i.Options {
+		if option.Value > highest {
+			highest = option.Value
+		}
+	}
+	return highest * len(i.Questions)
+}
+
+// Score checks there is one valid answer per question and returns the
+// total, its severity and whether a critical item needs follow-up.
+func (i Instrument) Score(answers []int) (int, string, bool, error) {
+	if len(answers) != len(i.Questions) {
+		return 0, "", false, errors.New(constant.ERROR_ANSWERS_INVALID)
+	}
+
+	lowest := i.Options[0].Value
+	valid := map[int]bool{}
+	for _, option := range i.Options {
+		valid[option.Value] = true
+		if option.Value < lowest {
+			lowest = option.Value
+		}
+	}
+
+	score := 0
+	for _, answer := range answers {
+		if !valid[answer] {
+			return 0, "", false, errors.New(constant.ERROR_ANSWERS_INVALID)
+		}
+		score += answer
+	}
+
+	flagged := false
+	for _, item := range i.CriticalItems {
+		if answers[item] > lowest {
+			flagged = true
+		}
+	}
+
+	for _, band := range i.Bands {
+		if score >= band.Min && score <= band.Max {
+			return score, band.Severity, flagged, nil
+		}
+	}
+
+	return 0, "", false, errors.New(constant.ERROR_ANSWERS_INVALID)
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"encoding/json"
+	"talkspace-api/modules/assessment/model"
+)
+
+func AssessmentEntityToAssessmentModel(assessmentEntity Assessment) model.Assessment {
+	answers, _ := json.Marshal(assessmentEntity.Answers)
+
+	assessmentModel := model.Assessment{
+		ID:                assessmentEntity.ID,
+		UserID:            assessmentEntity.UserID,
+		InstrumentCode:    assessmentEntity.InstrumentCode,
+		InstrumentVersion: assessmentEntity.InstrumentVersion,
+		Answers:           string(answers),
+		Score:             assessmentEntity.Score,
+		Severity:          assessmentEntity.Severity,
+		Flagged:           assessmentEntity.Flagged,
+		CreatedAt:         assessmentEntity.CreatedAt,
+	}
+	return assessmentModel
+}
+
+func AssessmentModelToAssessmentEntity(assessmentModel model.Assessment) Assessment {
+	answers := []int{}
+	json.Unmarshal([]byte(assessmentModel.Answers), &answers)
+
+	assessmentEntity := Assessment{
+		ID:                assessmentModel.ID,
+		UserID:            assessmentModel.UserID,
+		InstrumentCode:    assessmentModel.InstrumentCode,
+		InstrumentVersion: assessmentModel.InstrumentVersion,
+		Answers:           answers,
+		Score:             assessmentModel.Score,
+		Severity:          assessmentModel.Severity,
+		Flagged:           assessmentModel.Flagged,
+		CreatedAt:         assessmentModel.CreatedAt,
+	}
+	return assessmentEntity
+}
+
+func ListAssessmentModelToAssessmentEntity(assessmentModels []model.Assessment) []Assessment {
+	listAssessmentEntity := []Assessment{}
+	for _, assessment := range assessmentModels {
+		assessmentEntity := AssessmentModelToAssessmentEntity(assessment)
+		listAssessmentEntity = append(listAssessmentEntity, assessmentEntity)
+	}
+	return listAssessmentEntity
+}
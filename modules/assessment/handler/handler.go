@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/assessment/dto"
+	"talkspace-api/modules/assessment/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type assessmentHandler struct {
+	assessmentCommandUsecase usecase.AssessmentCommandUsecaseInterface
+	assessmentQueryUsecase   usecase.AssessmentQueryUsecaseInterface
+}
+
+func NewAssessmentHandler(acu usecase.AssessmentCommandUsecaseInterface, aqu usecase.AssessmentQueryUsecaseInterface) *assessmentHandler {
+	return &assessmentHandler{
+		assessmentCommandUsecase: acu,
+		assessmentQueryUsecase:   aqu,
+	}
+}
+
+// Query
+func (ah *assessmentHandler) GetInstruments(c echo.Context) error {
+	instruments := ah.assessmentQueryUsecase.GetInstruments()
+
+	instrumentResponses := dto.ListInstrumentEntityToInstrumentResponse(instruments)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, instrumentResponses))
+}
+
+func (ah *assessmentHandler) GetInstrument(c echo.Context) error {
+	version := 0
+	if versionParam := c.QueryParam("version"); versionParam != "" {
+		version, _ = strconv.Atoi(versionParam)
+	}
+
+	instrument, errGet := ah.assessmentQueryUsecase.GetInstrument(c.Param("code"), version)
+	if errGet != nil {
+		return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGet.Error()))
+	}
+
+	instrumentResponse := dto.InstrumentEntityToInstrumentResponse(instrument)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, instrumentResponse))
+}
+
+func (ah *assessmentHandler) GetAssessmentByID(c echo.Context) error {
+	assessmentIDParam := c.Param("assessment_id")
+	if assessmentIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	assessment, errGetID := ah.assessmentQueryUsecase.GetAssessmentByID(assessmentIDParam, tokenID, role)
+	if errGetID != nil {
+		return assessmentError(c, errGetID)
+	}
+
+	assessmentResponse := dto.AssessmentEntityToAssessmentResponse(assessment)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, assessmentResponse))
+}
+
+func (ah *assessmentHandler) GetAssessmentsByUserID(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	assessments, totalItems, errGet := ah.assessmentQueryUsecase.GetAssessmentsByUserID(userIDParam, c.QueryParam("instrument"), tokenID, role, page, limit)
+	if errGet != nil {
+		return assessmentError(c, errGet)
+	}
+
+	if len(assessments) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	assessmentResponses := dto.ListAssessmentEntityToAssessmentResponse(assessments)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		assessmentResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (ah *assessmentHandler) GetAssessmentChart(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	instrument, points, errGet := ah.assessmentQueryUsecase.GetAssessmentChart(userIDParam, c.Param("code"), c.QueryParam("from"), c.QueryParam("to"), tokenID, role)
+	if errGet != nil {
+		return assessmentError(c, errGet)
+	}
+
+	chartResponse := dto.ChartEntityToAssessmentChartResponse(instrument, points)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, chartResponse))
+}
+
+// Command
+func (ah *assessmentHandler) SubmitAssessment(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	assessmentRequest := dto.AssessmentRequest{}
+
+	errBind := c.Bind(&assessmentRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	assessment, errCreate := ah.assessmentCommandUsecase.SubmitAssessment(userID, c.Param("code"), assessmentRequest.Version, assessmentRequest.Answers)
+	if errCreate != nil {
+		return assessmentError(c, errCreate)
+	}
+
+	assessmentResponse := dto.AssessmentEntityToAssessmentResponse(assessment)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, assessmentResponse))
+}
+
+func assessmentError(c echo.Context, err error) error {
+	switch err.Error() {
+	case constant.ERROR_ID_NOTFOUND, constant.ERROR_INSTRUMENT_NOTFOUND:
+		return c.JSON(http.StatusNotFound, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_ROLE_ACCESS:
+		return c.JSON(http.StatusForbidden, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_ANSWERS_INVALID, constant.ERROR_DATE_FORMAT, constant.ERROR_TIME_RANGE:
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(err.Error()))
+	}
+}
@@ -0,0 +1,15 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type AssessmentHandlerInterface interface {
+	// Query
+	GetInstruments(c echo.Context) error
+	GetInstrument(c echo.Context) error
+	GetAssessmentByID(c echo.Context) error
+	GetAssessmentsByUserID(c echo.Context) error
+	GetAssessmentChart(c echo.Context) error
+
+	// Command
+	SubmitAssessment(c echo.Context) error
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (a *Assessment) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	a.ID = UUID.String()
+	return nil
+}
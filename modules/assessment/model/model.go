@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// Assessment is one completed questionnaire. Answers is the chosen option
+// value of every question, in order, as a json array.
+type Assessment struct {
+	ID                string    `gorm:"primarykey"`
+	UserID            string    `gorm:"index:idx_assessments_user_instrument;not null"`
+	InstrumentCode    string    `gorm:"index:idx_assessments_user_instrument;not null"`
+	InstrumentVersion int       `gorm:"not null"`
+	Answers           string    `gorm:"type:jsonb;not null"`
+	Score             int       `gorm:"not null"`
+	Severity          string    `gorm:"not null"`
+	Flagged           bool      `gorm:"not null;default:false"`
+	CreatedAt         time.Time `gorm:"index:idx_assessments_user_instrument"`
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"talkspace-api/modules/assessment/entity"
+
+	"gorm.io/gorm"
+)
+
+type assessmentCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewAssessmentCommandRepository(db *gorm.DB) AssessmentCommandRepositoryInterface {
+	return &assessmentCommandRepository{
+		db: db,
+	}
+}
+
+func (acr *assessmentCommandRepository) CreateAssessment(assessment entity.Assessment) (entity.Assessment, error) {
+	assessmentModel := entity.AssessmentEntityToAssessmentModel(assessment)
+
+	result := acr.db.Create(&assessmentModel)
+	if result.Error != nil {
+		return entity.Assessment{}, result.Error
+	}
+
+	assessmentEntity := entity.AssessmentModelToAssessmentEntity(assessmentModel)
+
+	return assessmentEntity, nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"talkspace-api/modules/assessment/entity"
+	"time"
+)
+
+type AssessmentCommandRepositoryInterface interface {
+	CreateAssessment(assessment entity.Assessment) (entity.Assessment, error)
+}
+
+type AssessmentQueryRepositoryInterface interface {
+	GetAssessmentByID(id string) (entity.Assessment, error)
+	GetAssessmentsByUserID(userID string, instrumentCode string, page, limit int) ([]entity.Assessment, int, error)
+	GetAssessmentChart(userID string, instrumentCode string, from time.Time, to time.Time) ([]entity.ChartPoint, error)
+}
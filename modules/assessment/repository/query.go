@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/assessment/entity"
+	"talkspace-api/modules/assessment/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type assessmentQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewAssessmentQueryRepository(db *gorm.DB) AssessmentQueryRepositoryInterface {
+	return &assessmentQueryRepository{
+		db: db,
+	}
+}
+
+func (aqr *assessmentQueryRepository) GetAssessmentByID(id string) (entity.Assessment, error) {
+	assessmentModel := model.Assessment{}
+	result := aqr.db.Where("id = ?", id).First(&assessmentModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Assessment{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Assessment{}, result.Error
+	}
+
+	assessmentEntity := entity.AssessmentModelToAssessmentEntity(assessmentModel)
+
+	return assessmentEntity, nil
+}
+
+// GetAssessmentsByUserID returns the results of a user, newest first, of a
+// single instrument unless instrumentCode is empty.
+func (aqr *assessmentQueryRepository) GetAssessmentsByUserID(userID string, instrumentCode string, page, limit int) ([]entity.Assessment, int, error) {
+	offset := (page - 1) * limit
+
+	byUser := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("user_id = ?", userID)
+		if instrumentCode != "" {
+			db = db.Where("instrument_code = ?", instrumentCode)
+		}
+		return db
+	}
+
+	var totalItems int64
+	result := aqr.db.Model(&model.Assessment{}).Scopes(byUser).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var assessmentModels []model.Assessment
+	result = aqr.db.Scopes(byUser).Order("created_at DESC").Offset(offset).Limit(limit).Find(&assessmentModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	assessments := entity.ListAssessmentModelToAssessmentEntity(assessmentModels)
+
+	return assessments, int(totalItems), nil
+}
+
+// GetAssessmentChart returns the scores of one instrument between from and
+// to, oldest first.
+func (aqr *assessmentQueryRepository) GetAssessmentChart(userID string, instrumentCode string, from time.Time, to time.Time) ([]entity.ChartPoint, error) {
+	var assessmentModels []model.Assessment
+	result := aqr.db.Select("id", "instrument_version", "score", "severity", "created_at").
+		Where("user_id = ? AND instrument_code = ?", userID, instrumentCode).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at ASC").
+		Find(&assessmentModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	points := []entity.ChartPoint{}
+	for _, assessmentModel := range assessmentModels {
+		points = append(points, entity.ChartPoint{
+			AssessmentID:      assessmentModel.ID,
+			InstrumentVersion: assessmentModel.InstrumentVersion,
+			Score:             assessmentModel.Score,
+			Severity:          assessmentModel.Severity,
+			CreatedAt:         assessmentModel.CreatedAt,
+		})
+	}
+
+	return points, nil
+}
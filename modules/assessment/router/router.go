@@ -0,0 +1,34 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/assessment/handler"
+	"talkspace-api/modules/assessment/repository"
+	"talkspace-api/modules/assessment/usecase"
+	cr "talkspace-api/modules/consultation/repository"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func AssessmentRoutes(e *echo.Group, db *gorm.DB) {
+	assessmentQueryRepository := repository.NewAssessmentQueryRepository(db)
+	assessmentCommandRepository := repository.NewAssessmentCommandRepository(db)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+
+	assessmentQueryUsecase := usecase.NewAssessmentQueryUsecase(assessmentQueryRepository, consultationQueryRepository)
+	assessmentCommandUsecase := usecase.NewAssessmentCommandUsecase(assessmentCommandRepository)
+
+	assessmentHandler := handler.NewAssessmentHandler(assessmentCommandUsecase, assessmentQueryUsecase)
+
+	instruments := e.Group("/instruments", middlewares.JWTMiddleware(false))
+	instruments.GET("", assessmentHandler.GetInstruments)
+	instruments.GET("/:code", assessmentHandler.GetInstrument)
+	instruments.POST("/:code", assessmentHandler.SubmitAssessment)
+
+	users := e.Group("/users", middlewares.JWTMiddleware(false))
+	users.GET("/:user_id", assessmentHandler.GetAssessmentsByUserID)
+	users.GET("/:user_id/charts/:code", assessmentHandler.GetAssessmentChart)
+
+	e.GET("/:assessment_id", assessmentHandler.GetAssessmentByID, middlewares.JWTMiddleware(false))
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"talkspace-api/modules/assessment/entity"
+	"talkspace-api/modules/assessment/repository"
+)
+
+type assessmentCommandUsecase struct {
+	assessmentCommandRepository repository.AssessmentCommandRepositoryInterface
+}
+
+func NewAssessmentCommandUsecase(acr repository.AssessmentCommandRepositoryInterface) AssessmentCommandUsecaseInterface {
+	return &assessmentCommandUsecase{
+		assessmentCommandRepository: acr,
+	}
+}
+
+// SubmitAssessment scores the answers against the given version of an
+// instrument, the latest one when version is 0, and stores the result.
+func (acu *assessmentCommandUsecase) SubmitAssessment(userID string, instrumentCode string, version int, answers []int) (entity.Assessment, error) {
+	instrument, errGet := entity.GetInstrument(instrumentCode, version)
+	if errGet != nil {
+		return entity.Assessment{}, errGet
+	}
+
+	score, severity, flagged, errScore := instrument.Score(answers)
+	if errScore != nil {
+		return entity.Assessment{}, errScore
+	}
+
+	assessment := entity.Assessment{
+		UserID:            userID,
+		InstrumentCode:    instrument.Code,
+		InstrumentVersion: instrument.Version,
+		Answers:           answers,
+		Score:             score,
+		Severity:          severity,
+		Flagged:           flagged,
+	}
+
+	assessmentEntity, errCreate := acu.assessmentCommandRepository.CreateAssessment(assessment)
+	if errCreate != nil {
+		return entity.Assessment{}, errCreate
+	}
+
+	return assessmentEntity, nil
+}
@@ -0,0 +1,15 @@
+package usecase
+
+import "talkspace-api/modules/assessment/entity"
+
+type AssessmentCommandUsecaseInterface interface {
+	SubmitAssessment(userID string, instrumentCode string, version int, answers []int) (entity.Assessment, error)
+}
+
+type AssessmentQueryUsecaseInterface interface {
+	GetInstruments() []entity.Instrument
+	GetInstrument(code string, version int) (entity.Instrument, error)
+	GetAssessmentByID(id string, requesterID string, role string) (entity.Assessment, error)
+	GetAssessmentsByUserID(userID string, instrumentCode string, requesterID string, role string, page, limit int) ([]entity.Assessment, int, error)
+	GetAssessmentChart(userID string, instrumentCode string, from string, to string, requesterID string, role string) (entity.Instrument, []entity.ChartPoint, error)
+}
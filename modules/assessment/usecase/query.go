@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"errors"
+	ae "talkspace-api/modules/appointment/entity"
+	"talkspace-api/modules/assessment/entity"
+	"talkspace-api/modules/assessment/repository"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type assessmentQueryUsecase struct {
+	assessmentQueryRepository   repository.AssessmentQueryRepositoryInterface
+	consultationQueryRepository cr.ConsultationQueryRepositoryInterface
+}
+
+func NewAssessmentQueryUsecase(aqr repository.AssessmentQueryRepositoryInterface, cqr cr.ConsultationQueryRepositoryInterface) AssessmentQueryUsecaseInterface {
+	return &assessmentQueryUsecase{
+		assessmentQueryRepository:   aqr,
+		consultationQueryRepository: cqr,
+	}
+}
+
+func (aqu *assessmentQueryUsecase) GetInstruments() []entity.Instrument {
+	return entity.LatestInstruments()
+}
+
+func (aqu *assessmentQueryUsecase) GetInstrument(code string, version int) (entity.Instrument, error) {
+	return entity.GetInstrument(code, version)
+}
+
+func (aqu *assessmentQueryUsecase) GetAssessmentByID(id string, requesterID string, role string) (entity.Assessment, error) {
+	assessment, errGetID := aqu.assessmentQueryRepository.GetAssessmentByID(id)
+	if errGetID != nil {
+		return entity.Assessment{}, errGetID
+	}
+
+	errAccess := aqu.canView(assessment.UserID, requesterID, role)
+	if errAccess != nil {
+		return entity.Assessment{}, errAccess
+	}
+
+	return assessment, nil
+}
+
+func (aqu *assessmentQueryUsecase) GetAssessmentsByUserID(userID string, instrumentCode string, requesterID string, role string, page, limit int) ([]entity.Assessment, int, error) {
+	if instrumentCode != "" {
+		if _, errGet := entity.GetInstrument(instrumentCode, 0); errGet != nil {
+			return nil, 0, errGet
+		}
+	}
+
+	errAccess := aqu.canView(userID, requesterID, role)
+	if errAccess != nil {
+		return nil, 0, errAccess
+	}
+
+	assessments, totalItems, errGet := aqu.assessmentQueryRepository.GetAssessmentsByUserID(userID, instrumentCode, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return assessments, totalItems, nil
+}
+
+// GetAssessmentChart returns the scores of one instrument between two
+// "2006-01-02" dates, both inclusive, with the latest version of the
+// instrument for its bands. Without dates it covers the last
+// entity.ChartDefaultRange up to today.
+func (aqu *assessmentQueryUsecase) GetAssessmentChart(userID string, instrumentCode string, from string, to string, requesterID string, role string) (entity.Instrument, []entity.ChartPoint, error) {
+	instrument, errGet := entity.GetInstrument(instrumentCode, 0)
+	if errGet != nil {
+		return entity.Instrument{}, nil, errGet
+	}
+
+	end := time.Now().In(ae.Location())
+	if to != "" {
+		day, errParse := ae.ParseDate(to)
+		if errParse != nil {
+			return entity.Instrument{}, nil, errParse
+		}
+		end = day
+	}
+	end = time.Date(end.Year(), end.Month(), end.Day()+1, 0, 0, 0, 0, end.Location())
+
+	start := end.Add(-entity.ChartDefaultRange)
+	if from != "" {
+		day, errParse := ae.ParseDate(from)
+		if errParse != nil {
+			return entity.Instrument{}, nil, errParse
+		}
+		start = day
+	}
+
+	if !start.Before(end) {
+		return entity.Instrument{}, nil, errors.New(constant.ERROR_TIME_RANGE)
+	}
+
+	errAccess := aqu.canView(userID, requesterID, role)
+	if errAccess != nil {
+		return entity.Instrument{}, nil, errAccess
+	}
+
+	points, errGetChart := aqu.assessmentQueryRepository.GetAssessmentChart(userID, instrumentCode, start, end)
+	if errGetChart != nil {
+		return entity.Instrument{}, nil, errGetChart
+	}
+
+	return instrument, points, nil
+}
+
+// canView lets users see their own results, and doctors while they are in
+// an active consultation with the user.
+func (aqu *assessmentQueryUsecase) canView(userID string, requesterID string, role string) error {
+	switch role {
+	case constant.USER:
+		if userID == requesterID {
+			return nil
+		}
+	case constant.DOCTOR:
+		active, errCheck := aqu.consultationQueryRepository.HasActiveConsultation(userID, requesterID)
+		if errCheck != nil {
+			return errCheck
+		}
+		if active {
+			return nil
+		}
+	}
+
+	return errors.New(constant.ERROR_ROLE_ACCESS)
+}
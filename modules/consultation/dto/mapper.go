@@ -1 +1,63 @@
-package dto
\ No newline at end of file
+package dto
+
+import "talkspace-api/modules/consultation/entity"
+
+// Response
+func ConsultationEntityToConsultationResponse(response entity.Consultation) ConsultationResponse {
+	return ConsultationResponse{
+		ID:        response.ID,
+		UserID:    response.UserID,
+		DoctorID:  response.DoctorID,
+		Status:    response.Status,
+		StartAt:   response.StartAt,
+		EndAt:     response.EndAt,
+		StartedAt: response.StartedAt,
+		EndedAt:   response.EndedAt,
+		EndedBy:   response.EndedBy,
+	}
+}
+
+func MessageEntityToMessageResponse(response entity.Message) MessageResponse {
+	var attachment *AttachmentResponse
+	if response.Attachment != nil {
+		attachment = &AttachmentResponse{
+			ID:          response.Attachment.ID,
+			FileName:    response.Attachment.FileName,
+			ContentType: response.Attachment.ContentType,
+			Size:        response.Attachment.Size,
+		}
+	}
+
+	return MessageResponse{
+		ID:                   response.ID,
+		ConsultationID:       response.ConsultationID,
+		Seq:                  response.Seq,
+		SenderID:             response.ClientID,
+		SenderName:           response.SenderName,
+		SenderProfilePicture: response.SenderProfilePicture,
+		Role:                 response.Role,
+		Message:              response.Message,
+		DeliveredAt:          response.DeliveredAt,
+		ReadAt:               response.ReadAt,
+		Attachment:           attachment,
+		Flagged:              response.Flagged,
+		CreatedAt:            response.CreatedAt,
+	}
+}
+
+func ListMessageEntityToMessageResponse(response []entity.Message) []MessageResponse {
+	messageResponses := []MessageResponse{}
+	for _, message := range response {
+		messageResponse := MessageEntityToMessageResponse(message)
+		messageResponses = append(messageResponses, messageResponse)
+	}
+	return messageResponses
+}
+
+func JoinTicketEntityToJoinTicketResponse(response entity.JoinTicket) JoinTicketResponse {
+	return JoinTicketResponse{
+		Ticket:         response.Ticket,
+		ConsultationID: response.ConsultationID,
+		ExpiresAt:      response.ExpiresAt,
+	}
+}
@@ -1,6 +1 @@
-package dto
-
-type ConsultationRequest struct {
-	DoctorID	  string `json:"doctor_id" validate:"required"`
-	Status        bool   `json:"status" validate:"required"`
-}
\ No newline at end of file
+package dto
\ No newline at end of file
@@ -1,11 +1,21 @@
 package dto
 
+import (
+	"talkspace-api/utils/helper/risk"
+	"time"
+)
+
 type RoomRes struct {
 	ID   string `json:"id"`
 	DoctorProfilePicture     string `json:"doctor_profile_picture"`
 	UserProfilePicture	  string `json:"user_profile_picture"`
 	DoctorName	  string `json:"doctor_name"`
 	UserName        string `json:"user_name"`
+	Status          string `json:"status"`
+	StartAt         *time.Time `json:"start_at"`
+	EndAt           *time.Time `json:"end_at"`
+	Online          []string   `json:"online"`
+	IntakeID        string     `json:"intake_id"`
 }	
 
 type DoctorRes struct {
@@ -19,4 +29,51 @@ type DoctorRes struct {
 	Gender 	  string `json:"gender"`
 	Alumnus		string `json:"alumnus"`
 	AboutMe		string `json:"about_me"`
-}
\ No newline at end of file
+}
+
+type ConsultationResponse struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"user_id"`
+	DoctorID  string     `json:"doctor_id"`
+	Status    string     `json:"status"`
+	StartAt   *time.Time `json:"start_at"`
+	EndAt     *time.Time `json:"end_at"`
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	EndedBy   string     `json:"ended_by"`
+}
+
+type MessageResponse struct {
+	ID                   string              `json:"id"`
+	ConsultationID       string              `json:"consultation_id"`
+	Seq                  int64               `json:"seq"`
+	SenderID             string              `json:"sender_id"`
+	SenderName           string              `json:"sender_name"`
+	SenderProfilePicture string              `json:"sender_profile_picture"`
+	Role                 string              `json:"role"`
+	Message              string              `json:"message"`
+	DeliveredAt          *time.Time          `json:"delivered_at"`
+	ReadAt               *time.Time          `json:"read_at"`
+	Attachment           *AttachmentResponse `json:"attachment"`
+	Flagged              bool                `json:"flagged"`
+	Resources            []risk.Resource     `json:"resources,omitempty"`
+	CreatedAt            time.Time           `json:"created_at"`
+}
+
+type AttachmentResponse struct {
+	ID          string `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+type AttachmentURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type JoinTicketResponse struct {
+	Ticket         string    `json:"ticket"`
+	ConsultationID string    `json:"consultation_id"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
@@ -1 +1,172 @@
-package entity
\ No newline at end of file
+package entity
+
+import (
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+const (
+	// EarlyJoin is how long before the booked start a room can be joined.
+	EarlyJoin = 10 * time.Minute
+	// MessageReplayLimit caps how many missed messages are replayed over the
+	// websocket, older history is read through the REST endpoint.
+	MessageReplayLimit = 200
+	MessagePageLimit   = 100
+	// MessageMaxLength is the longest chat message accepted, in bytes.
+	MessageMaxLength = 4000
+	// AttachmentMaxSize is the largest file accepted, in bytes.
+	AttachmentMaxSize = 10 * 1024 * 1024
+	// AttachmentURLExpiry is how long a download link keeps working.
+	AttachmentURLExpiry = 15 * time.Minute
+	// JoinTicketTTL is how long a client has to open the websocket after
+	// asking for a ticket.
+	JoinTicketTTL = 30 * time.Second
+	// DefaultDurationMinutes is how long a room without a slot runs when no
+	// duration was stored with it.
+	DefaultDurationMinutes = 60
+)
+
+// RoomChannelPrefix names the Redis channels the websocket rooms of every
+// replica are driven through, one per consultation.
+const RoomChannelPrefix = "consultation:room:"
+
+// RoomSignal tells every replica that the session behind a room changed:
+// Close ends the room, Deadline moves the time it closes by itself.
+type RoomSignal struct {
+	RoomID   string     `json:"room_id"`
+	Close    bool       `json:"close,omitempty"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+// AttachmentTypes maps the accepted content types, as sniffed from the file
+// itself, to the extension the file is stored with.
+var AttachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+type Consultation struct {
+	ID              string
+	TransactionID   string
+	AppointmentID   string
+	IntakeID        string
+	SessionID       string
+	UserID          string
+	DoctorID        string
+	Status          string
+	StartAt         *time.Time
+	EndAt           *time.Time
+	DurationMinutes int
+	UserJoinedAt    *time.Time
+	DoctorJoinedAt  *time.Time
+	StartedAt       *time.Time
+	EndedAt         *time.Time
+	EndedBy         string
+	LastSeq         int64
+	CreatedAt       time.Time
+}
+
+type Message struct {
+	ID                   string
+	ConsultationID       string
+	Seq                  int64
+	ClientID             string
+	Message              string
+	Role                 string
+	SenderName           string
+	SenderProfilePicture string
+	DeliveredAt          *time.Time
+	ReadAt               *time.Time
+	Attachment           *Attachment
+	Flagged              bool
+	CreatedAt            time.Time
+}
+
+type Attachment struct {
+	ID             string
+	ConsultationID string
+	UploaderID     string
+	FileName       string
+	ContentType    string
+	Size           int64
+	StorageKey     string
+	CreatedAt      time.Time
+}
+
+// JoinTicket admits one websocket connection to a consultation room. It
+// stands in for the bearer token, which must not end up in the URL.
+type JoinTicket struct {
+	Ticket         string    `json:"-"`
+	ConsultationID string    `json:"consultation_id"`
+	ClientID       string    `json:"client_id"`
+	Role           string    `json:"role"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// MessageCursor selects the messages around a known message. At most one of
+// Before and After is set; with neither the latest messages are returned.
+// AfterSeq selects the messages following a sequence number instead.
+type MessageCursor struct {
+	Before   string
+	After    string
+	AfterSeq int64
+	Limit    int
+}
+
+var consultationTransitions = map[string][]string{
+	constant.CONSULTATION_BOOKED:  {constant.CONSULTATION_WAITING, constant.CONSULTATION_ACTIVE, constant.CONSULTATION_CANCELLED, constant.CONSULTATION_NO_SHOW},
+	constant.CONSULTATION_WAITING: {constant.CONSULTATION_ACTIVE, constant.CONSULTATION_CANCELLED, constant.CONSULTATION_NO_SHOW},
+	constant.CONSULTATION_ACTIVE:  {constant.CONSULTATION_COMPLETED},
+}
+
+func IsValidConsultationTransition(from, to string) bool {
+	for _, status := range consultationTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinished reports whether the session reached a final state.
+func (c Consultation) IsFinished() bool {
+	return len(consultationTransitions[c.Status]) == 0
+}
+
+// Deadline is when the session has to end. Booked rooms end with their slot;
+// rooms without a slot run for the paid duration once both sides joined, or
+// DefaultDurationMinutes when none was stored. It is zero while there is no
+// limit yet.
+func (c Consultation) Deadline() time.Time {
+	if c.EndAt != nil {
+		return *c.EndAt
+	}
+	if c.StartedAt != nil {
+		durationMinutes := c.DurationMinutes
+		if durationMinutes <= 0 {
+			durationMinutes = DefaultDurationMinutes
+		}
+		return c.StartedAt.Add(time.Duration(durationMinutes) * time.Minute)
+	}
+	return time.Time{}
+}
+
+// IsOpen reports whether the room can be joined at now. Rooms booked through
+// an appointment only open within the booked window.
+func (c Consultation) IsOpen(now time.Time) bool {
+	if c.IsFinished() {
+		return false
+	}
+	if c.StartAt != nil && now.Before(c.StartAt.Add(-EarlyJoin)) {
+		return false
+	}
+	deadline := c.Deadline()
+	return deadline.IsZero() || now.Before(deadline)
+}
+
+// IsParticipant reports whether the user or doctor behind id takes part in
+// the consultation.
+func (c Consultation) IsParticipant(id string) bool {
+	return c.UserID == id || c.DoctorID == id
+}
@@ -1 +1,122 @@
-package entity
\ No newline at end of file
+package entity
+
+import "talkspace-api/modules/consultation/model"
+
+func ConsultationEntityToConsultationModel(consultationEntity Consultation) model.Consultation {
+	return model.Consultation{
+		ID:              consultationEntity.ID,
+		TransactionID:   consultationEntity.TransactionID,
+		AppointmentID:   consultationEntity.AppointmentID,
+		IntakeID:        consultationEntity.IntakeID,
+		SessionID:       consultationEntity.SessionID,
+		UserID:          consultationEntity.UserID,
+		DoctorID:        consultationEntity.DoctorID,
+		Status:          consultationEntity.Status,
+		StartAt:         consultationEntity.StartAt,
+		EndAt:           consultationEntity.EndAt,
+		DurationMinutes: consultationEntity.DurationMinutes,
+		UserJoinedAt:    consultationEntity.UserJoinedAt,
+		DoctorJoinedAt:  consultationEntity.DoctorJoinedAt,
+		StartedAt:       consultationEntity.StartedAt,
+		EndedAt:         consultationEntity.EndedAt,
+		EndedBy:         consultationEntity.EndedBy,
+		LastSeq:         consultationEntity.LastSeq,
+		CreatedAt:       consultationEntity.CreatedAt,
+	}
+}
+
+func ConsultationModelToConsultationEntity(consultationModel model.Consultation) Consultation {
+	return Consultation{
+		ID:              consultationModel.ID,
+		TransactionID:   consultationModel.TransactionID,
+		AppointmentID:   consultationModel.AppointmentID,
+		IntakeID:        consultationModel.IntakeID,
+		SessionID:       consultationModel.SessionID,
+		UserID:          consultationModel.UserID,
+		DoctorID:        consultationModel.DoctorID,
+		Status:          consultationModel.Status,
+		StartAt:         consultationModel.StartAt,
+		EndAt:           consultationModel.EndAt,
+		DurationMinutes: consultationModel.DurationMinutes,
+		UserJoinedAt:    consultationModel.UserJoinedAt,
+		DoctorJoinedAt:  consultationModel.DoctorJoinedAt,
+		StartedAt:       consultationModel.StartedAt,
+		EndedAt:         consultationModel.EndedAt,
+		EndedBy:         consultationModel.EndedBy,
+		LastSeq:         consultationModel.LastSeq,
+		CreatedAt:       consultationModel.CreatedAt,
+	}
+}
+
+func ListConsultationModelToConsultationEntity(consultationModels []model.Consultation) []Consultation {
+	listConsultationEntity := []Consultation{}
+	for _, consultation := range consultationModels {
+		consultationEntity := ConsultationModelToConsultationEntity(consultation)
+		listConsultationEntity = append(listConsultationEntity, consultationEntity)
+	}
+	return listConsultationEntity
+}
+
+func MessageModelToMessageEntity(messageModel model.Message) Message {
+	var attachment *Attachment
+	if messageModel.AttachmentID != nil {
+		attachment = &Attachment{ID: *messageModel.AttachmentID}
+	}
+
+	return Message{
+		ID:             messageModel.ID,
+		ConsultationID: messageModel.ConsultationID,
+		Seq:            messageModel.Seq,
+		ClientID:       messageModel.ClientID,
+		Message:        messageModel.Message,
+		Role:           messageModel.Role,
+		Attachment:     attachment,
+		Flagged:        messageModel.Flagged,
+		CreatedAt:      messageModel.CreatedAt,
+	}
+}
+
+func MessageEntityToMessageModel(messageEntity Message) model.Message {
+	var attachmentID *string
+	if messageEntity.Attachment != nil {
+		attachmentID = &messageEntity.Attachment.ID
+	}
+
+	return model.Message{
+		ID:             messageEntity.ID,
+		ConsultationID: messageEntity.ConsultationID,
+		Seq:            messageEntity.Seq,
+		ClientID:       messageEntity.ClientID,
+		Message:        messageEntity.Message,
+		Role:           messageEntity.Role,
+		AttachmentID:   attachmentID,
+		Flagged:        messageEntity.Flagged,
+		CreatedAt:      messageEntity.CreatedAt,
+	}
+}
+
+func AttachmentEntityToAttachmentModel(attachmentEntity Attachment) model.Attachment {
+	return model.Attachment{
+		ID:             attachmentEntity.ID,
+		ConsultationID: attachmentEntity.ConsultationID,
+		UploaderID:     attachmentEntity.UploaderID,
+		FileName:       attachmentEntity.FileName,
+		ContentType:    attachmentEntity.ContentType,
+		Size:           attachmentEntity.Size,
+		StorageKey:     attachmentEntity.StorageKey,
+		CreatedAt:      attachmentEntity.CreatedAt,
+	}
+}
+
+func AttachmentModelToAttachmentEntity(attachmentModel model.Attachment) Attachment {
+	return Attachment{
+		ID:             attachmentModel.ID,
+		ConsultationID: attachmentModel.ConsultationID,
+		UploaderID:     attachmentModel.UploaderID,
+		FileName:       attachmentModel.FileName,
+		ContentType:    attachmentModel.ContentType,
+		Size:           attachmentModel.Size,
+		StorageKey:     attachmentModel.StorageKey,
+		CreatedAt:      attachmentModel.CreatedAt,
+	}
+}
@@ -1,180 +1,352 @@
 package handler
 
 import (
-	"fmt"
 	"net/http"
-	"os"
+	"strconv"
 	"talkspace-api/middlewares"
 	"talkspace-api/modules/consultation/dto"
+	"talkspace-api/modules/consultation/entity"
 	"talkspace-api/modules/consultation/model"
 	"talkspace-api/modules/consultation/usecase"
 	doctor "talkspace-api/modules/doctor/model"
 	user "talkspace-api/modules/user/model"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/risk"
 	"talkspace-api/utils/responses"
+	"time"
 
-	"github.com/golang-jwt/jwt/v4"
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type Handler struct {
 	hub *usecase.Hub
 	db *gorm.DB
+	consultationCommandUsecase usecase.ConsultationCommandUsecaseInterface
+	consultationQueryUsecase usecase.ConsultationQueryUsecaseInterface
 }
 
-func NewHandler(h *usecase.Hub, db *gorm.DB) *Handler {
+func NewHandler(h *usecase.Hub, db *gorm.DB, ccu usecase.ConsultationCommandUsecaseInterface, cqu usecase.ConsultationQueryUsecaseInterface) *Handler {
 	return &Handler{
 		hub: h,
 		db: db,
+		consultationCommandUsecase: ccu,
+		consultationQueryUsecase: cqu,
 	}
 }
 
-func (h *Handler) CreateRoom(c echo.Context) error {
-	var req dto.ConsultationRequest
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error": "error binding request",
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+func (h *Handler) JoinRoom(c echo.Context) error {
+	roomID := c.Param("roomId")
+
+	// the ticket is redeemed and the session checked before upgrading so a
+	// rejected client still gets a plain HTTP error
+	consultation, ticket, err := h.consultationCommandUsecase.JoinConsultation(roomID, c.QueryParam("ticket"))
+	if err != nil {
+		switch err.Error() {
+		case constant.ERROR_TICKET_INVALID:
+			return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(err.Error()))
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(err.Error()))
+		case constant.ERROR_ROLE_ACCESS, constant.ERROR_ROOM_CLOSED:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(err.Error()))
+		default:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(err.Error()))
+		}
+	}
+	clientID := ticket.ClientID
+	role := ticket.Role
+
+	name, err := h.consultationQueryUsecase.GetSenderName(clientID, role)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(err.Error()))
+	}
+
+	conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		return nil
+	}
+
+	cl := &usecase.Client{
+		Conn:     conn,
+		// live events queue up while the backlog is written, so the
+		// queue has room for as many as the longest replay
+		Send:     make(chan *usecase.Event, entity.MessageReplayLimit),
+		ID:       clientID,
+		RoomID:   roomID,
+		Role: role,
+		Name:     name,
+		Deadline: consultation.Deadline(),
+	}
+
+	if err := h.hub.Join(cl); err != nil {
+		conn.Close()
+		return nil
+	}
+
+	// the client joins the hub first so nothing sent while the backlog is
+	// read gets lost, the writer skips what shows up twice
+	lastSeq := int64(-1)
+	if value, errParse := strconv.ParseInt(c.QueryParam("last_seq"), 10, 64); errParse == nil && value >= 0 {
+		lastSeq = value
+	}
+
+	missed, hasMore, err := h.consultationQueryUsecase.GetMissedMessages(roomID, c.QueryParam("last_seen"), lastSeq)
+	if err != nil {
+		logrus.Errorf("failed to read missed messages of room %s: %v", roomID, err)
+	}
+
+	backlog := make([]*usecase.Event, 0, len(missed)+1)
+	for _, m := range missed {
+		backlog = append(backlog, usecase.NewMessageEvent(m, m.SenderName))
+	}
+
+	// what does not fit in the replay is paged over REST from the last
+	// replayed message; a client at zero only gets the latest messages and
+	// reads older history through the same endpoint anyway
+	if hasMore && lastSeq != 0 && len(missed) > 0 {
+		backlog = append(backlog, &usecase.Event{
+			Type:      constant.EVENT_SYSTEM,
+			RoomID:    roomID,
+			Role:      constant.SYSTEM,
+			MessageID: missed[len(missed)-1].ID,
+			Content:   "more messages were missed than can be replayed, load the rest from the messages endpoint after message_id",
+			At:        time.Now(),
 		})
 	}
 
-	ID, _, _ := middlewares.ExtractToken(c)
+	go cl.WriteMessage(backlog)
+	cl.ReadMessage(h.hub, h.consultationCommandUsecase)
 
-	consultation := model.Consultation{
-		DoctorID:      req.DoctorID,
-		UserID:     ID,
-		Status: 	 req.Status,
-	}
-
-	if len(h.hub.Rooms) == 0 {
-		var rooms []model.Consultation
-		h.db.Find(&rooms)
-		h.hub.Rooms = make(map[string]*usecase.Room)
-		for _, r := range rooms {
-			h.hub.Rooms[r.ID] = &usecase.Room{
-				ID:      r.ID,
-				UserID: r.UserID,
-				DoctorID: r.DoctorID,
-				Client: make(map[string]*usecase.Client),
-			}
-		}
+	return nil
+}
+
+func (h *Handler) CreateJoinTicket(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	if consultationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
 	}
-	// h.db.Debug().Config.Logger
-	check_db := h.db.Find(&model.Consultation{}).Where("user_id = ? AND doctor_id", consultation.UserID, consultation.DoctorID)
-	if check_db.Error != nil {
-		return c.JSON(http.StatusBadRequest, responses.ErrorResponse("room already exists in database"))
+
+	clientID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
 	}
-	if h.hub.Rooms != nil {
-		for _, r := range h.hub.Rooms {
-			if r.UserID == consultation.UserID && r.DoctorID == consultation.DoctorID {
-				return c.JSON(http.StatusBadRequest, responses.ErrorResponse("room already exists in hub"))
-			}	
+
+	ticket, errCreate := h.consultationCommandUsecase.CreateJoinTicket(consultationIDParam, clientID, role)
+	if errCreate != nil {
+		switch errCreate.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errCreate.Error()))
+		case constant.ERROR_ROLE_ACCESS, constant.ERROR_ROOM_CLOSED:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(errCreate.Error()))
+		default:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCreate.Error()))
 		}
-	}	
+	}
 
-	result := h.db.Create(&consultation)
-	if result.Error != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error": "error creating room",
-		})
+	ticketResponse := dto.JoinTicketEntityToJoinTicketResponse(ticket)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, ticketResponse))
+}
+
+func (h *Handler) EndSession(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	if consultationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
 	}
 
-	h.hub.Rooms[consultation.ID] = &usecase.Room{
-		ID:      consultation.ID,
-		UserID: consultation.UserID,
-		DoctorID: consultation.DoctorID,
-		Client: make(map[string]*usecase.Client),
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
 	}
 
-	return c.JSON(http.StatusOK, responses.SuccessResponse("create room success", interface{}(nil)))
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	consultation, errEnd := h.consultationCommandUsecase.EndConsultation(consultationIDParam, doctorID)
+	if errEnd != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errEnd.Error()))
+	}
+
+	h.hub.CloseRoom(consultation.ID)
+
+	consultationResponse := dto.ConsultationEntityToConsultationResponse(consultation)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_SESSION_ENDED, consultationResponse))
 }
 
+func (h *Handler) GetMessages(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	if consultationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+	requesterID, _, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	_, limit := responses.Pagination("", c.QueryParam("limit"))
+	cursor := entity.MessageCursor{
+		Before: c.QueryParam("before"),
+		After:  c.QueryParam("after"),
+		Limit:  limit,
+	}
+
+	messages, hasMore, errGet := h.consultationQueryUsecase.GetMessages(consultationIDParam, requesterID, cursor)
+	if errGet != nil {
+		switch errGet.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGet.Error()))
+		case constant.ERROR_ROLE_ACCESS:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(errGet.Error()))
+		default:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGet.Error()))
+		}
+	}
+
+	if len(messages) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	messageResponses := dto.ListMessageEntityToMessageResponse(messages)
+	before := messageResponses[0].ID
+	after := messageResponses[len(messageResponses)-1].ID
+
+	return c.JSON(http.StatusOK, responses.SuccessResponseCursor(constant.SUCCESS_RETRIEVED, cursor.Limit, hasMore, before, after, messageResponses))
 }
 
-func (h *Handler) JoinRoom(c echo.Context) error {
-	conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"error": "error binding request",
-		})
-		
+func (h *Handler) UploadAttachment(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	if consultationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
 	}
 
-	
-	tokenParam := c.Param("token")
-	
-	token, err := jwt.Parse(tokenParam, func(token *jwt.Token) (interface{}, error) {
-		jwtSecret := []byte(os.Getenv("JWT_SECRET"))
-        return jwtSecret, nil
-    })
-	
-	if err != nil || !token.Valid {
-		return c.JSON(http.StatusBadRequest, responses.ErrorResponse("invalid token"))
+	senderID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
 	}
 
-	clientID := token.Claims.(jwt.MapClaims)["id"].(string)
-	roomID := c.Param("roomId")
-	role := token.Claims.(jwt.MapClaims)["role"].(string)
-	
-	if role == "admin" {
-		return c.JSON(http.StatusBadRequest, responses.ErrorResponse("invalid role"))
+	file, errFile := c.FormFile("file")
+	if errFile != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_FILE_EMPTY))
 	}
 
-	cl := &usecase.Client{
-		Conn:     conn,
-		Message:  make(chan *usecase.Message, 10),
-		ID:       clientID,
-		RoomID:   roomID,
-		Role: role,
+	message, errSend := h.consultationCommandUsecase.SendAttachment(consultationIDParam, senderID, role, c.FormValue("caption"), file)
+	if errSend != nil {
+		switch errSend.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errSend.Error()))
+		case constant.ERROR_ROLE_ACCESS, constant.ERROR_ROOM_CLOSED:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(errSend.Error()))
+		default:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errSend.Error()))
+		}
 	}
 
-	h.hub.Register <- cl
+	name, _ := h.consultationQueryUsecase.GetSenderName(senderID, role)
+	message.SenderName = name
+	h.hub.Broadcast(usecase.NewMessageEvent(message, name))
 
-	go cl.WriteMessage(roomID, h.db)
-	cl.ReadMessage(h.hub, h.db)
+	messageResponse := dto.MessageEntityToMessageResponse(message)
+	if message.Flagged {
+		messageResponse.Resources = risk.Hotlines
+	}
 
-	return nil
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, messageResponse))
+}
+
+func (h *Handler) GetAttachment(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	attachmentIDParam := c.Param("attachment_id")
+
+	requesterID, _, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	url, expiresAt, errGet := h.consultationQueryUsecase.GetAttachmentURL(consultationIDParam, attachmentIDParam, requesterID)
+	if errGet != nil {
+		switch errGet.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGet.Error()))
+		case constant.ERROR_ROLE_ACCESS:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(errGet.Error()))
+		default:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGet.Error()))
+		}
+	}
+
+	attachmentResponse := dto.AttachmentURLResponse{
+		URL:       url,
+		ExpiresAt: expiresAt,
+	}
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, attachmentResponse))
 }
 
 func (h *Handler) GetRooms(c echo.Context) error {
 	roomsRes := make([]dto.RoomRes, 0)
 	ID, _, _ := middlewares.ExtractToken(c)
 
-	if len(h.hub.Rooms) == 0 {
-		var rooms []model.Consultation
-		h.db.Find(&rooms)
-		h.hub.Rooms = make(map[string]*usecase.Room)
-		for _, r := range rooms {
-			h.hub.Rooms[r.ID] = &usecase.Room{
-				ID:      r.ID,
-				UserID: r.UserID,
-				DoctorID: r.DoctorID,
-				Client: make(map[string]*usecase.Client),
-			}
+	// rooms opened by a settled payment are only written to the database,
+	// the hub learns about them once they can be joined
+	var rooms []model.Consultation
+	h.db.Where("user_id = ? OR doctor_id = ?", ID, ID).Find(&rooms)
+	schedules := make(map[string]model.Consultation)
+	now := time.Now()
+	for _, r := range rooms {
+		consultation := entity.ConsultationModelToConsultationEntity(r)
+		if !consultation.IsOpen(now) {
+			continue
+		}
+		schedules[r.ID] = r
+		if err := h.hub.CreateRoom(r.ID, r.UserID, r.DoctorID, consultation.Deadline()); err != nil {
+			return c.JSON(http.StatusServiceUnavailable, responses.ErrorResponse(err.Error()))
 		}
 	}
 
-	fmt.Println(h.hub.Rooms)
+	hubRooms, err := h.hub.ListRooms(ID)
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, responses.ErrorResponse(err.Error()))
+	}
 
-	for _, r := range h.hub.Rooms {
+	for _, r := range hubRooms {
+		// the hub may still hold a room whose session ended on another
+		// replica or ran out of time, only open sessions are listed
+		if _, ok := schedules[r.ID]; !ok {
+			continue
+		}
 		if r.UserID == ID || r.DoctorID == ID {
 			var user user.User
 			var doctor doctor.Doctor
 			h.db.Where("id = ?", r.UserID).Find(&user)
 			h.db.Where("id = ?", r.DoctorID).Find(&doctor)
+			online := make([]string, 0)
+			for _, role := range h.hub.Online(r.ID) {
+				online = append(online, role)
+			}
 			roomsRes = append(roomsRes, dto.RoomRes{
 				ID:   r.ID,
 				DoctorProfilePicture: doctor.ProfilePicture,
 				UserProfilePicture: user.ProfilePicture,
 				DoctorName: doctor.Fullname,
 				UserName: user.Fullname,
+				Status: schedules[r.ID].Status,
+				StartAt: schedules[r.ID].StartAt,
+				EndAt: schedules[r.ID].EndAt,
+				Online: online,
+				IntakeID: schedules[r.ID].IntakeID,
 			})
 		}
 	}
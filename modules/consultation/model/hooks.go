@@ -9,6 +9,14 @@ func (c *Consultation) BeforeCreate(tx *gorm.DB) (err error) {
 	UUID := uuid.New()
 	c.ID = UUID.String()
 
+	if c.Status == "" {
+		c.Status = "booked"
+	}
+
+	if c.DurationMinutes == 0 {
+		c.DurationMinutes = 60
+	}
+
 	// if c.Role == "" {
 	// 	return errors.New("role is required")
 	// }
@@ -35,4 +43,11 @@ func (m *Message) BeforeCreate(tx *gorm.DB) (err error) {
 	// }
 
 	return nil
-}
\ No newline at end of file
+}
+
+func (a *Attachment) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	a.ID = UUID.String()
+
+	return nil
+}
@@ -5,18 +5,53 @@ import "time"
 type Consultation struct {
 	ID            string `gorm:"primarykey"`
 	TransactionID string `gorm:"not null"`
+	AppointmentID string `gorm:"index"`
+	IntakeID      string `gorm:"index"`
 	SessionID     string `gorm:"not null"`
 	UserID        string `gorm:"not null"`
 	DoctorID      string `gorm:"not null"`
-	Status 	  	  bool `gorm:"not null"`
+	Status        string `gorm:"type:varchar(20);not null;default:'booked'"`
+	StartAt       *time.Time
+	EndAt         *time.Time
+	DurationMinutes int `gorm:"not null;default:60"`
+	UserJoinedAt   *time.Time
+	DoctorJoinedAt *time.Time
+	StartedAt     *time.Time
+	EndedAt       *time.Time
+	EndedBy       string
+	LastSeq       int64 `gorm:"not null;default:0"`
 	CreatedAt     time.Time
 }
 
 type Message struct {
 	ID            string `gorm:"primarykey"`
 	ConsultationID string `gorm:"not null"`
+	Seq            int64  `gorm:"not null;default:0"`
 	ClientID	   string `gorm:"not null"`
 	Message        string `gorm:"not null"`
 	Role           string `gorm:"type:role;default:'user'"`
+	AttachmentID   *string `gorm:"index;default:NULL"`
+	Flagged        bool   `gorm:"not null;default:false"`
 	CreatedAt      time.Time
-}
\ No newline at end of file
+}
+
+// MessageReceipt records when a recipient received and read a message.
+type MessageReceipt struct {
+	MessageID   string `gorm:"primaryKey"`
+	RecipientID string `gorm:"primaryKey"`
+	DeliveredAt *time.Time
+	ReadAt      *time.Time
+}
+
+// Attachment is a file shared in a consultation. The file itself is kept
+// private in storage under StorageKey.
+type Attachment struct {
+	ID             string `gorm:"primarykey"`
+	ConsultationID string `gorm:"index;not null"`
+	UploaderID     string `gorm:"not null"`
+	FileName       string `gorm:"not null"`
+	ContentType    string `gorm:"not null"`
+	Size           int64  `gorm:"not null"`
+	StorageKey     string `gorm:"not null"`
+	CreatedAt      time.Time
+}
@@ -1 +1,329 @@
 package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"talkspace-api/modules/consultation/entity"
+	"talkspace-api/modules/consultation/model"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/cloud"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type consultationCommandRepository struct {
+	db  *gorm.DB
+	rdb *redis.Client
+}
+
+func NewConsultationCommandRepository(db *gorm.DB, rdb *redis.Client) ConsultationCommandRepositoryInterface {
+	return &consultationCommandRepository{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+func (ccr *consultationCommandRepository) CreateConsultation(consultation entity.Consultation) (entity.Consultation, error) {
+	consultationModel := entity.ConsultationEntityToConsultationModel(consultation)
+
+	result := ccr.db.Create(&consultationModel)
+	if result.Error != nil {
+		return entity.Consultation{}, result.Error
+	}
+
+	consultationEntity := entity.ConsultationModelToConsultationEntity(consultationModel)
+
+	return consultationEntity, nil
+}
+
+// UpdateConsultationSchedule moves a booked session to a new slot and has
+// its room close at the new end.
+func (ccr *consultationCommandRepository) UpdateConsultationSchedule(id string, startAt time.Time, endAt time.Time) error {
+	result := ccr.db.Model(&model.Consultation{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"start_at": startAt, "end_at": endAt})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	ccr.signalRoom(entity.RoomSignal{RoomID: id, Deadline: &endAt})
+
+	return nil
+}
+
+// UpdateConsultationStatus moves a session along its lifecycle. It reports
+// whether this call made the change so repeated requests are harmless. A
+// session that reached a final state has its room closed on every replica,
+// whatever ended it.
+func (ccr *consultationCommandRepository) UpdateConsultationStatus(id string, status string, endedBy string) (entity.Consultation, bool, error) {
+	changed := false
+
+	consultation, errUpdate := ccr.updateConsultation(id, func(consultation *entity.Consultation, now time.Time) error {
+		if consultation.Status == status {
+			return nil
+		}
+
+		if !entity.IsValidConsultationTransition(consultation.Status, status) {
+			return errors.New(constant.ERROR_CONSULTATION_STATUS)
+		}
+
+		consultation.Status = status
+		if status == constant.CONSULTATION_ACTIVE && consultation.StartedAt == nil {
+			consultation.StartedAt = &now
+		}
+		if consultation.IsFinished() {
+			consultation.EndedAt = &now
+			consultation.EndedBy = endedBy
+		}
+
+		changed = true
+		return nil
+	})
+	if errUpdate != nil {
+		return entity.Consultation{}, false, errUpdate
+	}
+
+	if changed && consultation.IsFinished() {
+		ccr.signalRoom(entity.RoomSignal{RoomID: id, Close: true})
+	}
+
+	return consultation, changed, nil
+}
+
+// signalRoom tells the hub of every replica about a change to the session
+// behind a room. The change is stored already, so a failure is only logged;
+// the room then stays open until its old deadline or until everyone left.
+func (ccr *consultationCommandRepository) signalRoom(signal entity.RoomSignal) {
+	payload, err := json.Marshal(signal)
+	if err == nil {
+		err = ccr.rdb.Publish(context.Background(), entity.RoomChannelPrefix+signal.RoomID, payload).Err()
+	}
+	if err != nil {
+		logrus.Errorf("failed to signal room %s: %v", signal.RoomID, err)
+	}
+}
+
+// MarkConsultationJoined records that one side entered the room. The first
+// side to join puts the session in the waiting room and the session starts
+// once both sides joined.
+func (ccr *consultationCommandRepository) MarkConsultationJoined(id string, role string) (entity.Consultation, error) {
+	return ccr.updateConsultation(id, func(consultation *entity.Consultation, now time.Time) error {
+		if !consultation.IsOpen(now) {
+			return errors.New(constant.ERROR_ROOM_CLOSED)
+		}
+
+		switch role {
+		case constant.USER:
+			if consultation.UserJoinedAt == nil {
+				consultation.UserJoinedAt = &now
+			}
+		case constant.DOCTOR:
+			if consultation.DoctorJoinedAt == nil {
+				consultation.DoctorJoinedAt = &now
+			}
+		default:
+			return errors.New(constant.ERROR_ROLE_ACCESS)
+		}
+
+		if consultation.Status == constant.CONSULTATION_ACTIVE {
+			return nil
+		}
+
+		if consultation.UserJoinedAt != nil && consultation.DoctorJoinedAt != nil {
+			consultation.Status = constant.CONSULTATION_ACTIVE
+			consultation.StartedAt = &now
+		} else {
+			consultation.Status = constant.CONSULTATION_WAITING
+		}
+
+		return nil
+	})
+}
+
+// updateConsultation runs update on a locked consultation row.
+func (ccr *consultationCommandRepository) updateConsultation(id string, update func(consultation *entity.Consultation, now time.Time) error) (entity.Consultation, error) {
+	consultationModel := model.Consultation{}
+
+	errTx := ccr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&consultationModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		consultation := entity.ConsultationModelToConsultationEntity(consultationModel)
+
+		errUpdate := update(&consultation, time.Now())
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		consultationModel = entity.ConsultationEntityToConsultationModel(consultation)
+
+		return tx.Save(&consultationModel).Error
+	})
+	if errTx != nil {
+		return entity.Consultation{}, errTx
+	}
+
+	consultationEntity := entity.ConsultationModelToConsultationEntity(consultationModel)
+
+	return consultationEntity, nil
+}
+
+// CreateMessage stores a message under the next sequence number of its
+// consultation.
+func (ccr *consultationCommandRepository) CreateMessage(message entity.Message) (entity.Message, error) {
+	messageModel := entity.MessageEntityToMessageModel(message)
+
+	errTx := ccr.db.Transaction(func(tx *gorm.DB) error {
+		return createMessage(tx, &messageModel)
+	})
+	if errTx != nil {
+		return entity.Message{}, errTx
+	}
+
+	messageEntity := entity.MessageModelToMessageEntity(messageModel)
+
+	return messageEntity, nil
+}
+
+// CreateAttachmentMessage uploads a file to private storage and posts it to
+// the consultation as a message. The file is removed again when the message
+// cannot be stored, so no private file is left without a message.
+func (ccr *consultationCommandRepository) CreateAttachmentMessage(message entity.Message, attachment entity.Attachment, file io.ReadSeeker) (entity.Message, error) {
+	attachment.StorageKey = "consultations/" + attachment.ConsultationID + "/" + uuid.NewString() + entity.AttachmentTypes[attachment.ContentType]
+
+	errUpload := cloud.UploadPrivateFileToS3(file, attachment.StorageKey, attachment.ContentType)
+	if errUpload != nil {
+		return entity.Message{}, errUpload
+	}
+
+	attachmentModel := entity.AttachmentEntityToAttachmentModel(attachment)
+	messageModel := entity.MessageEntityToMessageModel(message)
+
+	errTx := ccr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&attachmentModel).Error; err != nil {
+			return err
+		}
+
+		messageModel.AttachmentID = &attachmentModel.ID
+
+		return createMessage(tx, &messageModel)
+	})
+	if errTx != nil {
+		if errDelete := cloud.DeleteFileFromS3(attachment.StorageKey); errDelete != nil {
+			logrus.Errorf("failed to remove attachment %s of a message that was not stored: %v", attachment.StorageKey, errDelete)
+		}
+		return entity.Message{}, errTx
+	}
+
+	messageEntity := entity.MessageModelToMessageEntity(messageModel)
+	attachmentEntity := entity.AttachmentModelToAttachmentEntity(attachmentModel)
+	messageEntity.Attachment = &attachmentEntity
+
+	return messageEntity, nil
+}
+
+// createMessage inserts a message under the next sequence number of its
+// consultation. The counter lives on the consultation row, so the numbers
+// stay gapless and ordered whichever replica writes the message.
+func createMessage(tx *gorm.DB, messageModel *model.Message) error {
+	var seq int64
+	result := tx.Raw("UPDATE consultations SET last_seq = last_seq + 1 WHERE id = ? RETURNING last_seq", messageModel.ConsultationID).Scan(&seq)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	messageModel.Seq = seq
+
+	return tx.Create(messageModel).Error
+}
+
+// UpdateMessageReceipts marks every message of the consultation up to and
+// including upToMessageID that was sent to recipientID as delivered or read.
+// Reading implies delivery. Timestamps that are already set are kept, and the
+// number of messages that changed is returned.
+func (ccr *consultationCommandRepository) UpdateMessageReceipts(consultationID string, recipientID string, upToMessageID string, receipt string, at time.Time) (int64, error) {
+	var readAt *time.Time
+	pending := "r.delivered_at IS NOT NULL"
+	if receipt == constant.EVENT_READ {
+		readAt = &at
+		pending = "r.read_at IS NOT NULL"
+	}
+
+	result := ccr.db.Exec(`
+		INSERT INTO message_receipts (message_id, recipient_id, delivered_at, read_at)
+		SELECT m.id, @recipient, @at, @read_at
+		FROM messages m
+		JOIN messages pivot ON pivot.id = @pivot AND pivot.consultation_id = m.consultation_id
+		WHERE m.consultation_id = @consultation
+			AND m.client_id <> @recipient
+			AND m.seq <= pivot.seq
+			AND NOT EXISTS (
+				SELECT 1 FROM message_receipts r
+				WHERE r.message_id = m.id AND r.recipient_id = @recipient AND `+pending+`
+			)
+		ON CONFLICT (message_id, recipient_id) DO UPDATE SET
+			delivered_at = COALESCE(message_receipts.delivered_at, EXCLUDED.delivered_at),
+			read_at = COALESCE(message_receipts.read_at, EXCLUDED.read_at)`,
+		map[string]interface{}{
+			"recipient":    recipientID,
+			"at":           at,
+			"read_at":      readAt,
+			"pivot":        upToMessageID,
+			"consultation": consultationID,
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// CreateJoinTicket stores a ticket in Redis until it is used or expires.
+func (ccr *consultationCommandRepository) CreateJoinTicket(ticket entity.JoinTicket) error {
+	data, err := json.Marshal(ticket)
+	if err != nil {
+		return err
+	}
+
+	cacheKey := "consultation:ticket:" + ticket.Ticket
+	return ccr.rdb.Set(context.Background(), cacheKey, data, time.Until(ticket.ExpiresAt)).Err()
+}
+
+// ConsumeJoinTicket reads a ticket and deletes it in the same step, so it
+// can only be used once even when two replicas receive it at the same time.
+func (ccr *consultationCommandRepository) ConsumeJoinTicket(ticket string) (entity.JoinTicket, error) {
+	cacheKey := "consultation:ticket:" + ticket
+	data, err := ccr.rdb.GetDel(context.Background(), cacheKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return entity.JoinTicket{}, errors.New(constant.ERROR_TICKET_INVALID)
+		}
+		return entity.JoinTicket{}, err
+	}
+
+	joinTicket := entity.JoinTicket{}
+	if err := json.Unmarshal([]byte(data), &joinTicket); err != nil {
+		return entity.JoinTicket{}, err
+	}
+
+	return joinTicket, nil
+}
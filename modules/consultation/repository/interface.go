@@ -1 +1,30 @@
 package repository
+
+import (
+	"io"
+	"talkspace-api/modules/consultation/entity"
+	"time"
+)
+
+type ConsultationCommandRepositoryInterface interface {
+	CreateConsultation(consultation entity.Consultation) (entity.Consultation, error)
+	UpdateConsultationSchedule(id string, startAt time.Time, endAt time.Time) error
+	UpdateConsultationStatus(id string, status string, endedBy string) (entity.Consultation, bool, error)
+	MarkConsultationJoined(id string, role string) (entity.Consultation, error)
+	CreateMessage(message entity.Message) (entity.Message, error)
+	CreateAttachmentMessage(message entity.Message, attachment entity.Attachment, file io.ReadSeeker) (entity.Message, error)
+	CreateJoinTicket(ticket entity.JoinTicket) error
+	ConsumeJoinTicket(ticket string) (entity.JoinTicket, error)
+	UpdateMessageReceipts(consultationID string, recipientID string, upToMessageID string, receipt string, at time.Time) (int64, error)
+}
+
+type ConsultationQueryRepositoryInterface interface {
+	GetConsultationByID(id string) (entity.Consultation, error)
+	GetConsultationByTransactionID(transactionID string) (entity.Consultation, error)
+	GetLapsedConsultations(now time.Time) ([]entity.Consultation, error)
+	GetMessages(consultationID string, cursor entity.MessageCursor) ([]entity.Message, bool, error)
+	GetSenderName(id string, role string) (string, error)
+	GetAttachmentByID(id string) (entity.Attachment, error)
+	HasActiveConsultation(userID string, doctorID string) (bool, error)
+	IsPatientOf(userID string, doctorID string) (bool, error)
+}
@@ -1 +1,220 @@
-package repository
\ No newline at end of file
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/consultation/entity"
+	"talkspace-api/modules/consultation/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type consultationQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewConsultationQueryRepository(db *gorm.DB) ConsultationQueryRepositoryInterface {
+	return &consultationQueryRepository{
+		db: db,
+	}
+}
+
+func (cqr *consultationQueryRepository) GetConsultationByID(id string) (entity.Consultation, error) {
+	consultationModel := model.Consultation{}
+
+	result := cqr.db.Where("id = ?", id).First(&consultationModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Consultation{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Consultation{}, result.Error
+	}
+
+	consultationEntity := entity.ConsultationModelToConsultationEntity(consultationModel)
+
+	return consultationEntity, nil
+}
+
+func (cqr *consultationQueryRepository) GetConsultationByTransactionID(transactionID string) (entity.Consultation, error) {
+	consultationModel := model.Consultation{}
+
+	result := cqr.db.Where("transaction_id = ?", transactionID).First(&consultationModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Consultation{}, errors.New(constant.ERROR_DATA_NOTFOUND)
+		}
+		return entity.Consultation{}, result.Error
+	}
+
+	consultationEntity := entity.ConsultationModelToConsultationEntity(consultationModel)
+
+	return consultationEntity, nil
+}
+
+// GetLapsedConsultations returns unfinished sessions whose deadline has
+// passed, see entity.Consultation.Deadline.
+func (cqr *consultationQueryRepository) GetLapsedConsultations(now time.Time) ([]entity.Consultation, error) {
+	var consultationModels []model.Consultation
+	result := cqr.db.
+		Where("status IN ?", []string{constant.CONSULTATION_BOOKED, constant.CONSULTATION_WAITING, constant.CONSULTATION_ACTIVE}).
+		Where("(end_at IS NOT NULL AND end_at <= ?) OR (end_at IS NULL AND started_at + make_interval(mins => COALESCE(NULLIF(duration_minutes, 0), ?)) <= ?)", now, entity.DefaultDurationMinutes, now).
+		Find(&consultationModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	consultations := entity.ListConsultationModelToConsultationEntity(consultationModels)
+
+	return consultations, nil
+}
+
+// messageRow is a message together with its sender, receipt and
+// attachment, see GetMessages.
+type messageRow struct {
+	model.Message
+	SenderName            string
+	SenderProfilePicture  string
+	DeliveredAt           *time.Time
+	ReadAt                *time.Time
+	AttachmentFileName    string
+	AttachmentContentType string
+	AttachmentSize        int64
+}
+
+// GetMessages returns up to cursor.Limit messages of a consultation in
+// sequence order with the receipt of the other participant, and whether
+// there are more in the direction that was paged.
+func (cqr *consultationQueryRepository) GetMessages(consultationID string, cursor entity.MessageCursor) ([]entity.Message, bool, error) {
+	query := cqr.db.Table("messages").
+		Select("messages.*, COALESCE(users.fullname, doctors.fullname, '') AS sender_name, COALESCE(users.profile_picture, doctors.profile_picture, '') AS sender_profile_picture, receipts.delivered_at, receipts.read_at, attachments.file_name AS attachment_file_name, attachments.content_type AS attachment_content_type, attachments.size AS attachment_size").
+		Joins("LEFT JOIN users ON messages.role = 'user' AND users.id = messages.client_id").
+		Joins("LEFT JOIN doctors ON messages.role = 'doctor' AND doctors.id = messages.client_id").
+		Joins("LEFT JOIN message_receipts receipts ON receipts.message_id = messages.id AND receipts.recipient_id <> messages.client_id").
+		Joins("LEFT JOIN attachments ON attachments.id = messages.attachment_id").
+		Where("messages.consultation_id = ?", consultationID)
+
+	order := "DESC"
+	pivotID := cursor.Before
+	if cursor.After != "" {
+		order = "ASC"
+		pivotID = cursor.After
+	} else if cursor.AfterSeq > 0 {
+		order = "ASC"
+		query = query.Where("messages.seq > ?", cursor.AfterSeq)
+	}
+
+	if pivotID != "" {
+		pivot := model.Message{}
+		result := cqr.db.Where("id = ? AND consultation_id = ?", pivotID, consultationID).First(&pivot)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return nil, false, errors.New(constant.ERROR_CURSOR_INVALID)
+			}
+			return nil, false, result.Error
+		}
+
+		if order == "ASC" {
+			query = query.Where("messages.seq > ?", pivot.Seq)
+		} else {
+			query = query.Where("messages.seq < ?", pivot.Seq)
+		}
+	}
+
+	var rows []messageRow
+	result := query.Order("messages.seq " + order).Limit(cursor.Limit + 1).Scan(&rows)
+	if result.Error != nil {
+		return nil, false, result.Error
+	}
+
+	hasMore := len(rows) > cursor.Limit
+	if hasMore {
+		rows = rows[:cursor.Limit]
+	}
+
+	messages := make([]entity.Message, len(rows))
+	for i, row := range rows {
+		message := entity.MessageModelToMessageEntity(row.Message)
+		message.SenderName = row.SenderName
+		message.SenderProfilePicture = row.SenderProfilePicture
+		message.DeliveredAt = row.DeliveredAt
+		message.ReadAt = row.ReadAt
+		if message.Attachment != nil {
+			message.Attachment.ConsultationID = row.ConsultationID
+			message.Attachment.UploaderID = row.ClientID
+			message.Attachment.FileName = row.AttachmentFileName
+			message.Attachment.ContentType = row.AttachmentContentType
+			message.Attachment.Size = row.AttachmentSize
+		}
+
+		// pages read backwards are still returned oldest first
+		if order == "DESC" {
+			messages[len(rows)-1-i] = message
+		} else {
+			messages[i] = message
+		}
+	}
+
+	return messages, hasMore, nil
+}
+
+// GetSenderName returns the full name shown next to the messages of a user
+// or doctor.
+func (cqr *consultationQueryRepository) GetSenderName(id string, role string) (string, error) {
+	table := "users"
+	if role == constant.DOCTOR {
+		table = "doctors"
+	}
+
+	var fullname string
+	result := cqr.db.Table(table).Select("fullname").Where("id = ?", id).Limit(1).Scan(&fullname)
+	if result.Error != nil {
+		return "", result.Error
+	}
+
+	return fullname, nil
+}
+
+func (cqr *consultationQueryRepository) GetAttachmentByID(id string) (entity.Attachment, error) {
+	attachmentModel := model.Attachment{}
+
+	result := cqr.db.Where("id = ?", id).First(&attachmentModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Attachment{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Attachment{}, result.Error
+	}
+
+	attachmentEntity := entity.AttachmentModelToAttachmentEntity(attachmentModel)
+
+	return attachmentEntity, nil
+}
+
+// HasActiveConsultation reports whether the doctor is in a session with the
+// user right now.
+func (cqr *consultationQueryRepository) HasActiveConsultation(userID string, doctorID string) (bool, error) {
+	var active int64
+	result := cqr.db.Model(&model.Consultation{}).
+		Where("user_id = ? AND doctor_id = ? AND status = ?", userID, doctorID, constant.CONSULTATION_ACTIVE).
+		Count(&active)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return active > 0, nil
+}
+
+// IsPatientOf reports whether the user ever booked the doctor, counting
+// every consultation that was not cancelled.
+func (cqr *consultationQueryRepository) IsPatientOf(userID string, doctorID string) (bool, error) {
+	var consultations int64
+	result := cqr.db.Model(&model.Consultation{}).
+		Where("user_id = ? AND doctor_id = ? AND status <> ?", userID, doctorID, constant.CONSULTATION_CANCELLED).
+		Count(&consultations)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return consultations > 0, nil
+}
@@ -3,21 +3,43 @@ package router
 import (
 	"talkspace-api/middlewares"
 	"talkspace-api/modules/consultation/handler"
+	"talkspace-api/modules/consultation/repository"
 	"talkspace-api/modules/consultation/usecase"
+	er "talkspace-api/modules/escalation/repository"
+	eu "talkspace-api/modules/escalation/usecase"
+	"talkspace-api/utils/helper/risk"
 
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func ConsultationRoutes(e *echo.Group, db *gorm.DB) {
-	hub := usecase.NewHub()
+// ConsultationRoutes returns the hub so the server can drain its connections
+// on shutdown.
+func ConsultationRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) *usecase.Hub {
+	hub := usecase.NewHub(rdb)
 
-	consultationWebsocket := handler.NewHandler(hub,db)
+	consultationCommandRepository := repository.NewConsultationCommandRepository(db, rdb)
+	consultationQueryRepository := repository.NewConsultationQueryRepository(db)
+	escalationCommandRepository := er.NewEscalationCommandRepository(db)
+	escalationQueryRepository := er.NewEscalationQueryRepository(db)
+	escalationCommandUsecase := eu.NewEscalationCommandUsecase(escalationCommandRepository, escalationQueryRepository, risk.NewDetector())
+	consultationCommandUsecase := usecase.NewConsultationCommandUsecase(consultationCommandRepository, consultationQueryRepository, escalationCommandUsecase)
+
+	consultationQueryUsecase := usecase.NewConsultationQueryUsecase(consultationCommandRepository, consultationQueryRepository)
+
+	consultationWebsocket := handler.NewHandler(hub, db, consultationCommandUsecase, consultationQueryUsecase)
 
 	go hub.Run()
 
-	e.POST("/createRoom", consultationWebsocket.CreateRoom, middlewares.JWTMiddleware(false))
-	e.GET("/joinRoom/:roomId/:token", consultationWebsocket.JoinRoom)
+	e.GET("/joinRoom/:roomId", consultationWebsocket.JoinRoom)
 	e.GET("/getRooms", consultationWebsocket.GetRooms, middlewares.JWTMiddleware(false))
 	e.GET("/getDoctors", consultationWebsocket.GetDoctors, middlewares.JWTMiddleware(false))
+	e.POST("/:consultation_id/tickets", consultationWebsocket.CreateJoinTicket, middlewares.JWTMiddleware(false))
+	e.GET("/:consultation_id/messages", consultationWebsocket.GetMessages, middlewares.JWTMiddleware(false))
+	e.POST("/:consultation_id/attachments", consultationWebsocket.UploadAttachment, middlewares.JWTMiddleware(false))
+	e.GET("/:consultation_id/attachments/:attachment_id", consultationWebsocket.GetAttachment, middlewares.JWTMiddleware(false))
+	e.PATCH("/:consultation_id/end", consultationWebsocket.EndSession, middlewares.JWTMiddleware(false))
+
+	return hub
 }
@@ -1,118 +1,307 @@
 package usecase
 
 import (
+	"encoding/json"
+	"errors"
 	"log"
-	"talkspace-api/modules/consultation/model"
-	doctor "talkspace-api/modules/doctor/model"
-	user "talkspace-api/modules/user/model"
+	"sync/atomic"
+	"talkspace-api/modules/consultation/entity"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/risk"
 	"time"
 
 	"github.com/gorilla/websocket"
-	"gorm.io/gorm"
+)
+
+const (
+	// writeWait bounds a single write to the connection.
+	writeWait = 10 * time.Second
+	// pongWait is how long a connection may stay silent, pongs included,
+	// before it counts as stale.
+	pongWait = 60 * time.Second
+	// pingPeriod has to be shorter than pongWait so a healthy client always
+	// answers in time.
+	pingPeriod = pongWait * 9 / 10
+	// maxFrameSize bounds an incoming frame, with room for the envelope
+	// around the longest message.
+	maxFrameSize = 8192
 )
 
 type Client struct {
 	Conn     *websocket.Conn
-	Message  chan *Message
+	Send     chan *Event
 	ID       string `json:"id"`
 	RoomID   string `json:"room_id"`
 	ClientID string `json:"client_id"`
 	Role	 string `json:"role"`
+	Name     string `json:"name"`
+	Deadline time.Time `json:"-"`
+	hub      *Hub
+	closeCode int
+	// lastActive is the unix nano time the client was last heard from,
+	// written by its reader and read by the hub
+	lastActive atomic.Int64
 }
 
 type Message struct {
+	ID       string `json:"id"`
+	Seq      int64  `json:"seq"`
+	SenderID string `json:"sender_id"`
 	Content  string `json:"content"`
 	RoomID   string `json:"room_id"`
 	Username	 string `json:"username"`
 	Role	 string `json:"role"`
+	Attachment *Attachment `json:"attachment,omitempty"`
+	Flagged   bool      `json:"flagged,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
-func (c *Client) WriteMessage(roomID string, db *gorm.DB) {
+// Attachment describes a shared file. The file itself is downloaded through
+// the attachments endpoint, which checks the requester is in the room.
+type Attachment struct {
+	ID          string `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// NewMessageEvent wraps a stored message into the event that announces it,
+// an attachment event when it carries a file.
+func NewMessageEvent(message entity.Message, username string) *Event {
+	event := &Event{
+		Type:     constant.EVENT_MESSAGE,
+		RoomID:   message.ConsultationID,
+		SenderID: message.ClientID,
+		Role:     message.Role,
+		Message: &Message{
+			ID:        message.ID,
+			Seq:       message.Seq,
+			SenderID:  message.ClientID,
+			Content:   message.Message,
+			RoomID:    message.ConsultationID,
+			Username:  username,
+			Role:      message.Role,
+			Flagged:   message.Flagged,
+			CreatedAt: message.CreatedAt,
+		},
+		At: message.CreatedAt,
+	}
+
+	if message.Attachment != nil {
+		event.Type = constant.EVENT_ATTACHMENT
+		event.Message.Attachment = &Attachment{
+			ID:          message.Attachment.ID,
+			FileName:    message.Attachment.FileName,
+			ContentType: message.Attachment.ContentType,
+			Size:        message.Attachment.Size,
+		}
+	}
+
+	return event
+}
+
+// NewCrisisEvent points a client whose message was flagged to help outside
+// the session.
+func NewCrisisEvent(roomID string) *Event {
+	return &Event{
+		Type:      constant.EVENT_CRISIS,
+		RoomID:    roomID,
+		Content:   risk.SupportMessage,
+		Resources: risk.Hotlines,
+		At:        time.Now(),
+	}
+}
+
+// Event is the envelope of every websocket frame, in both directions. Type
+// is one of the constant.EVENT_* values and decides which fields are used:
+//
+//	message    client sends content, server sends message
+//	attachment message with its attachment, files are uploaded over REST
+//	typing     typing
+//	delivered  message_id of the newest message received
+//	read       message_id of the newest message read
+//	presence   content is "joined" or "left"
+//	system     content
+//	error      content, only sent to the client that caused it
+//	crisis     content and resources, only sent to a client whose message
+//	           was flagged for self-harm risk
+type Event struct {
+	Type      string   `json:"type"`
+	RoomID    string   `json:"room_id,omitempty"`
+	SenderID  string   `json:"sender_id,omitempty"`
+	Role      string   `json:"role,omitempty"`
+	Message   *Message `json:"message,omitempty"`
+	MessageID string   `json:"message_id,omitempty"`
+	Content   string   `json:"content,omitempty"`
+	Typing    *bool    `json:"typing,omitempty"`
+	Resources []risk.Resource `json:"resources,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// WriteMessage first replays the messages the client missed, then relays
+// what the hub sends until the client is dropped, pinging it in between.
+func (c *Client) WriteMessage(backlog []*Event) {
+	ticker := time.NewTicker(pingPeriod)
 	defer func() {
+		ticker.Stop()
 		c.Conn.Close()
+		c.hub.conns.Done()
 	}()
 
-	message := &[]model.Message{}
-	var username string
-	doctor := &doctor.Doctor{}
-	user := &user.User{}
-	db.Find(&message, "consultation_id = ?", roomID)
-	
-	for _, m := range *message {
-		if m.Role == "doctor" {
-			db.Find(&doctor, "id = ?", m.ClientID)
-			username = doctor.Fullname
-		} else if m.Role == "user" {
-			db.Find(&user, "id = ?", m.ClientID)
-			username = user.Fullname
+	var replayedSeq int64
+	for _, event := range backlog {
+		if event.Message != nil {
+			replayedSeq = event.Message.Seq
 		}
-
-		msg := &Message{
-			Content:  m.Message,
-			RoomID:   m.ConsultationID,
-			Username: username,
-			Role: m.Role,
-			CreatedAt: m.CreatedAt,
+		if c.write(event) != nil {
+			return
 		}
-
-		c.Conn.WriteJSON(msg)
 	}
 
 	for {
-		message, ok := <-c.Message
-		if !ok {
-			return
-		}
+		select {
+		case event, ok := <-c.Send:
+			if !ok {
+				// the hub dropped the client, the close code says why
+				deadline := time.Now().Add(time.Second)
+				c.Conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(c.closeCode, ""), deadline)
+				return
+			}
+
+			// the client joined before the backlog was read, so the first
+			// live messages may already have been replayed
+			if event.Message != nil && event.Message.Seq <= replayedSeq {
+				continue
+			}
+
+			// clients know they are typing
+			if event.Type == constant.EVENT_TYPING && event.SenderID == c.ID {
+				continue
+			}
 
-		c.Conn.WriteJSON(message)
+			if c.write(event) != nil {
+				return
+			}
+		case <-ticker.C:
+			if c.Conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)) != nil {
+				return
+			}
+		}
 	}
 }
 
-func (c *Client) ReadMessage(hub *Hub, db *gorm.DB) {
+func (c *Client) write(event *Event) error {
+	c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.Conn.WriteJSON(event)
+}
+
+// touch records that the client is alive and pushes its read deadline.
+func (c *Client) touch() {
+	now := time.Now()
+	c.lastActive.Store(now.UnixNano())
+	c.Conn.SetReadDeadline(now.Add(pongWait))
+}
+
+// isStale reports whether the client has been silent for longer than
+// pongWait at now.
+func (c *Client) isStale(now time.Time) bool {
+	return now.Sub(time.Unix(0, c.lastActive.Load())) > pongWait
+}
+
+// ReadMessage decodes the frames of the client and routes them by type
+// until the connection closes. A frame that cannot be handled is answered
+// with an error event and the connection stays open.
+func (c *Client) ReadMessage(hub *Hub, ccu ConsultationCommandUsecaseInterface) {
 	defer func() {
-		hub.Unregister <- c
+		hub.Leave(c)
 		c.Conn.Close()
 	}()
 
-	var username string
-	doctor := &doctor.Doctor{}
-	user := &user.User{}
+	c.Conn.SetReadLimit(maxFrameSize)
+	c.touch()
+	c.Conn.SetPongHandler(func(string) error {
+		c.touch()
+		return nil
+	})
 
 	for {
-		_, m, err := c.Conn.ReadMessage()
+		_, frame, err := c.Conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("error: %v", err)
 			}
 			break
 		}
+		c.touch()
 
-		if c.Role == "doctor" {
-			db.Find(&doctor, "id = ?", c.ID)
-			username = doctor.Fullname
-		} else if c.Role == "user" {
-			db.Find(&user, "id = ?", c.ID)
-			username = user.Fullname
+		event := &Event{}
+		errRoute := json.Unmarshal(frame, event)
+		if errRoute != nil {
+			errRoute = errors.New(constant.ERROR_EVENT_INVALID)
+		} else {
+			errRoute = c.route(hub, ccu, event)
 		}
-		
-		msg := &Message{
-			Content:  string(m),
-			RoomID:   c.RoomID,
-			Username: username,
-			Role: c.Role,
-			CreatedAt: time.Now(),
+
+		if errRoute != nil {
+			hub.SendTo(c, &Event{
+				Type:    constant.EVENT_ERROR,
+				RoomID:  c.RoomID,
+				Content: errRoute.Error(),
+				At:      time.Now(),
+			})
+		}
+	}
+}
+
+func (c *Client) route(hub *Hub, ccu ConsultationCommandUsecaseInterface, event *Event) error {
+	switch event.Type {
+	case constant.EVENT_MESSAGE:
+		message, err := ccu.SendMessage(c.RoomID, c.ID, c.Role, event.Content)
+		if err != nil {
+			return err
 		}
 
-		messsage := &model.Message{
-			ConsultationID: c.RoomID,
-			ClientID: c.ID,
-			Message: string(m),
-			Role: c.Role,
+		if err := hub.Broadcast(NewMessageEvent(message, c.Name)); err != nil {
+			return err
 		}
 
-		db.Create(&messsage)
+		if message.Flagged {
+			return hub.SendTo(c, NewCrisisEvent(c.RoomID))
+		}
+
+		return nil
+	case constant.EVENT_TYPING:
+		typing := event.Typing != nil && *event.Typing
 
-		hub.Broadcast <- msg
+		return hub.Broadcast(&Event{
+			Type:     constant.EVENT_TYPING,
+			RoomID:   c.RoomID,
+			SenderID: c.ID,
+			Role:     c.Role,
+			Typing:   &typing,
+			At:       time.Now(),
+		})
+	case constant.EVENT_DELIVERED, constant.EVENT_READ:
+		at := time.Now()
+		changed, err := ccu.UpdateMessageReceipts(c.RoomID, c.ID, event.MessageID, event.Type, at)
+		if err != nil {
+			return err
+		}
+
+		// nothing new to tell the sender
+		if changed == 0 {
+			return nil
+		}
+
+		return hub.Broadcast(&Event{
+			Type:      event.Type,
+			RoomID:    c.RoomID,
+			SenderID:  c.ID,
+			Role:      c.Role,
+			MessageID: event.MessageID,
+			At:        at,
+		})
+	default:
+		return errors.New(constant.ERROR_EVENT_INVALID)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"talkspace-api/modules/consultation/entity"
+	"talkspace-api/modules/consultation/repository"
+	ee "talkspace-api/modules/escalation/entity"
+	eu "talkspace-api/modules/escalation/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/generator"
+	"talkspace-api/utils/helper/risk"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type consultationCommandUsecase struct {
+	consultationCommandRepository repository.ConsultationCommandRepositoryInterface
+	consultationQueryRepository   repository.ConsultationQueryRepositoryInterface
+	escalationCommandUsecase      eu.EscalationCommandUsecaseInterface
+}
+
+func NewConsultationCommandUsecase(ccr repository.ConsultationCommandRepositoryInterface, cqr repository.ConsultationQueryRepositoryInterface, ecu eu.EscalationCommandUsecaseInterface) ConsultationCommandUsecaseInterface {
+	return &consultationCommandUsecase{
+		consultationCommandRepository: ccr,
+		consultationQueryRepository:   cqr,
+		escalationCommandUsecase:      ecu,
+	}
+}
+
+// CreateJoinTicket hands a participant a short-lived, single-use ticket to
+// open the room's websocket with.
+func (ccu *consultationCommandUsecase) CreateJoinTicket(id string, clientID string, role string) (entity.JoinTicket, error) {
+	_, errGet := ccu.getJoinableConsultation(id, clientID, role)
+	if errGet != nil {
+		return entity.JoinTicket{}, errGet
+	}
+
+	ticket, errGenerate := generator.GenerateRandomBytes()
+	if errGenerate != nil {
+		return entity.JoinTicket{}, errGenerate
+	}
+
+	joinTicket := entity.JoinTicket{
+		Ticket:         ticket,
+		ConsultationID: id,
+		ClientID:       clientID,
+		Role:           role,
+		ExpiresAt:      time.Now().Add(entity.JoinTicketTTL),
+	}
+
+	errCreate := ccu.consultationCommandRepository.CreateJoinTicket(joinTicket)
+	if errCreate != nil {
+		return entity.JoinTicket{}, errCreate
+	}
+
+	return joinTicket, nil
+}
+
+// JoinConsultation redeems a join ticket for the room and moves the session
+// to waiting or active depending on who is already there. Membership is
+// checked again since the session may have changed since the ticket was
+// issued.
+func (ccu *consultationCommandUsecase) JoinConsultation(id string, ticket string) (entity.Consultation, entity.JoinTicket, error) {
+	if id == "" || ticket == "" {
+		return entity.Consultation{}, entity.JoinTicket{}, errors.New(constant.ERROR_TICKET_INVALID)
+	}
+
+	joinTicket, errConsume := ccu.consultationCommandRepository.ConsumeJoinTicket(ticket)
+	if errConsume != nil {
+		return entity.Consultation{}, entity.JoinTicket{}, errConsume
+	}
+
+	if joinTicket.ConsultationID != id {
+		return entity.Consultation{}, entity.JoinTicket{}, errors.New(constant.ERROR_TICKET_INVALID)
+	}
+
+	_, errGet := ccu.getJoinableConsultation(id, joinTicket.ClientID, joinTicket.Role)
+	if errGet != nil {
+		return entity.Consultation{}, entity.JoinTicket{}, errGet
+	}
+
+	consultationEntity, errJoin := ccu.consultationCommandRepository.MarkConsultationJoined(id, joinTicket.Role)
+	if errJoin != nil {
+		return entity.Consultation{}, entity.JoinTicket{}, errJoin
+	}
+
+	return consultationEntity, joinTicket, nil
+}
+
+// getJoinableConsultation returns a consultation that clientID takes part
+// in as role and that is open right now.
+func (ccu *consultationCommandUsecase) getJoinableConsultation(id string, clientID string, role string) (entity.Consultation, error) {
+	if id == "" {
+		return entity.Consultation{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	consultation, errGetID := ccu.consultationQueryRepository.GetConsultationByID(id)
+	if errGetID != nil {
+		return entity.Consultation{}, errGetID
+	}
+
+	if (role != constant.USER || consultation.UserID != clientID) && (role != constant.DOCTOR || consultation.DoctorID != clientID) {
+		return entity.Consultation{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	if !consultation.IsOpen(time.Now()) {
+		return entity.Consultation{}, errors.New(constant.ERROR_ROOM_CLOSED)
+	}
+
+	return consultation, nil
+}
+
+// EndConsultation lets the doctor finish an active session before its slot
+// runs out.
+func (ccu *consultationCommandUsecase) EndConsultation(id string, doctorID string) (entity.Consultation, error) {
+	if id == "" {
+		return entity.Consultation{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	consultation, errGetID := ccu.consultationQueryRepository.GetConsultationByID(id)
+	if errGetID != nil {
+		return entity.Consultation{}, errGetID
+	}
+
+	if consultation.DoctorID != doctorID {
+		return entity.Consultation{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	consultationEntity, _, errEnd := ccu.consultationCommandRepository.UpdateConsultationStatus(id, constant.CONSULTATION_COMPLETED, constant.DOCTOR)
+	if errEnd != nil {
+		return entity.Consultation{}, errEnd
+	}
+
+	return consultationEntity, nil
+}
+
+// CloseLapsedConsultations finishes every session whose time is up. A
+// session that started is completed, one where the two sides never met is
+// recorded as a no-show. It returns the sessions it closed.
+func (ccu *consultationCommandUsecase) CloseLapsedConsultations() ([]entity.Consultation, error) {
+	consultations, errGet := ccu.consultationQueryRepository.GetLapsedConsultations(time.Now())
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	closed := []entity.Consultation{}
+	for _, consultation := range consultations {
+		status := constant.CONSULTATION_NO_SHOW
+		if consultation.Status == constant.CONSULTATION_ACTIVE {
+			status = constant.CONSULTATION_COMPLETED
+		}
+
+		consultationEntity, changed, errClose := ccu.consultationCommandRepository.UpdateConsultationStatus(consultation.ID, status, constant.SYSTEM)
+		if errClose != nil {
+			logrus.Errorf("failed to close consultation %s: %v", consultation.ID, errClose)
+			continue
+		}
+
+		if changed {
+			closed = append(closed, consultationEntity)
+		}
+	}
+
+	return closed, nil
+}
+
+// SendMessage stores a chat message of a participant who joined the room,
+// as long as the room is still open.
+func (ccu *consultationCommandUsecase) SendMessage(consultationID string, senderID string, role string, content string) (entity.Message, error) {
+	if strings.TrimSpace(content) == "" {
+		return entity.Message{}, errors.New(constant.ERROR_MESSAGE_EMPTY)
+	}
+
+	if len(content) > entity.MessageMaxLength {
+		return entity.Message{}, errors.New(constant.ERROR_MESSAGE_TOO_LONG)
+	}
+
+	_, errGet := ccu.getJoinableConsultation(consultationID, senderID, role)
+	if errGet != nil {
+		return entity.Message{}, errGet
+	}
+
+	assessment := ccu.assess(role, content)
+
+	message, errCreate := ccu.consultationCommandRepository.CreateMessage(entity.Message{
+		ConsultationID: consultationID,
+		ClientID:       senderID,
+		Message:        content,
+		Role:           role,
+		Flagged:        assessment.Flagged,
+	})
+	if errCreate != nil {
+		return entity.Message{}, errCreate
+	}
+
+	ccu.escalate(message, assessment)
+
+	return message, nil
+}
+
+// assess screens what a patient writes for self-harm risk. A detector that
+// fails must not keep the message from being sent, so it is only logged.
+func (ccu *consultationCommandUsecase) assess(role string, content string) risk.Assessment {
+	if role != constant.USER || strings.TrimSpace(content) == "" {
+		return risk.Assessment{}
+	}
+
+	assessment, err := ccu.escalationCommandUsecase.Assess(content)
+	if err != nil {
+		logrus.Errorf("failed to screen message: %v", err)
+		return risk.Assessment{}
+	}
+
+	return assessment
+}
+
+// escalate records a flagged message for follow-up once it is stored.
+func (ccu *consultationCommandUsecase) escalate(message entity.Message, assessment risk.Assessment) {
+	if !assessment.Flagged {
+		return
+	}
+
+	_, err := ccu.escalationCommandUsecase.Escalate(ee.Escalation{
+		Source:      constant.ESCALATION_CONSULTATION,
+		ReferenceID: message.ID,
+		UserID:      message.ClientID,
+	}, assessment)
+	if err != nil {
+		logrus.Errorf("failed to escalate message %s: %v", message.ID, err)
+	}
+}
+
+// UpdateMessageReceipts records that recipientID received or read the
+// messages sent to it up to messageID. It returns how many messages changed.
+func (ccu *consultationCommandUsecase) UpdateMessageReceipts(consultationID string, recipientID string, messageID string, receipt string, at time.Time) (int64, error) {
+	if messageID == "" {
+		return 0, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	if receipt != constant.EVENT_DELIVERED && receipt != constant.EVENT_READ {
+		return 0, errors.New(constant.ERROR_EVENT_INVALID)
+	}
+
+	changed, errUpdate := ccu.consultationCommandRepository.UpdateMessageReceipts(consultationID, recipientID, messageID, receipt, at)
+	if errUpdate != nil {
+		return 0, errUpdate
+	}
+
+	return changed, nil
+}
+
+// SendAttachment validates a file and posts it to the consultation with an
+// optional caption. The file type is sniffed from its content, the name it
+// was uploaded with only has to agree with it.
+func (ccu *consultationCommandUsecase) SendAttachment(consultationID string, senderID string, role string, caption string, file *multipart.FileHeader) (entity.Message, error) {
+	if file == nil || file.Size == 0 {
+		return entity.Message{}, errors.New(constant.ERROR_FILE_EMPTY)
+	}
+
+	if file.Size > entity.AttachmentMaxSize {
+		return entity.Message{}, errors.New(constant.ERROR_ATTACHMENT_SIZE)
+	}
+
+	if len(caption) > entity.MessageMaxLength {
+		return entity.Message{}, errors.New(constant.ERROR_MESSAGE_TOO_LONG)
+	}
+
+	_, errGet := ccu.getJoinableConsultation(consultationID, senderID, role)
+	if errGet != nil {
+		return entity.Message{}, errGet
+	}
+
+	content, errOpen := file.Open()
+	if errOpen != nil {
+		return entity.Message{}, errOpen
+	}
+	defer content.Close()
+
+	head := make([]byte, 512)
+	n, _ := content.Read(head)
+	contentType := http.DetectContentType(head[:n])
+
+	extension := strings.ToLower(filepath.Ext(file.Filename))
+	if extension == ".jpeg" {
+		extension = ".jpg"
+	}
+	if expected, ok := entity.AttachmentTypes[contentType]; !ok || expected != extension {
+		return entity.Message{}, errors.New(constant.ERROR_ATTACHMENT_TYPE)
+	}
+
+	if _, errSeek := content.Seek(0, 0); errSeek != nil {
+		return entity.Message{}, errSeek
+	}
+
+	assessment := ccu.assess(role, caption)
+
+	message, errCreate := ccu.consultationCommandRepository.CreateAttachmentMessage(entity.Message{
+		ConsultationID: consultationID,
+		ClientID:       senderID,
+		Message:        caption,
+		Role:           role,
+		Flagged:        assessment.Flagged,
+	}, entity.Attachment{
+		ConsultationID: consultationID,
+		UploaderID:     senderID,
+		FileName:       filepath.Base(file.Filename),
+		ContentType:    contentType,
+		Size:           file.Size,
+	}, content)
+	if errCreate != nil {
+		return entity.Message{}, errCreate
+	}
+
+	ccu.escalate(message, assessment)
+
+	return message, nil
+}
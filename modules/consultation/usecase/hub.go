@@ -1,64 +1,484 @@
 package usecase
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"talkspace-api/modules/consultation/entity"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// every replica subscribes to the room channels and delivers what it
+	// receives to the clients connected to it
+	roomChannelPrefix = entity.RoomChannelPrefix
+	presenceKeyPrefix = "consultation:presence:"
+	// presenceTTL drops presence left behind by a replica that went away
+	// without unregistering its clients
+	presenceTTL = 2 * time.Hour
+	// effectQueueSize is how many Redis writes may wait for the effects
+	// goroutine before the owner goroutine has to wait for it
+	effectQueueSize = 1024
+)
+
 type Room struct {
-	ID     string              `gorm:"primaryKey" json:"id"`
-	Name   string           `json:"name"`
-	DoctorID string           `json:"doctor_id"`
-	UserID string           `json:"user_id"`
-	Client map[string]*Client `gorm:"-" json:"clients"`
+	ID       string             `json:"id"`
+	Name     string             `json:"name"`
+	DoctorID string             `json:"doctor_id"`
+	UserID   string             `json:"user_id"`
+	Client   map[string]*Client `json:"clients"`
+	closer   *time.Timer
+}
+
+// roomEvent is what replicas exchange over Redis: an event for the clients
+// of a room, or a signal about its session, which the repository sends as
+// well when a session is ended or moved.
+type roomEvent struct {
+	entity.RoomSignal
+	Event *Event `json:"event,omitempty"`
 }
 
+// Hub owns every room and client connected to this replica. The state is
+// only touched by the goroutine in Run; everything else goes through the
+// methods below, which hand a request to that goroutine and wait for it.
+// Redis writes are queued by the owner goroutine and run in order by a
+// goroutine of their own, so a slow Redis never holds up the rooms.
 type Hub struct {
-	Rooms      map[string]*Room `gorm:"-" json:"rooms"`
-	Register   chan *Client
-	Unregister chan *Client
-	Broadcast  chan *Message
+	rooms     map[string]*Room
+	requests  chan func()
+	effects   chan func()
+	quit      chan struct{}
+	performed chan struct{}
+	stopped   chan struct{}
+	stopOnce  sync.Once
+	conns     sync.WaitGroup
+	rdb       *redis.Client
+	pubsub    *redis.PubSub
 }
 
-func NewHub() *Hub {
+func NewHub(rdb *redis.Client) *Hub {
 	return &Hub{
-		Rooms:      make(map[string]*Room),
-		Register:   make(chan *Client),
-		Unregister: make(chan *Client),
-		Broadcast:  make(chan *Message),
+		rooms:     make(map[string]*Room),
+		requests:  make(chan func()),
+		effects:   make(chan func(), effectQueueSize),
+		quit:      make(chan struct{}),
+		performed: make(chan struct{}),
+		stopped:   make(chan struct{}),
+		rdb:       rdb,
+		pubsub:    rdb.PSubscribe(context.Background(), roomChannelPrefix+"*"),
 	}
 }
 
 func (h *Hub) Run() {
+	go h.subscribe()
+	go h.perform()
+
+	sweep := time.NewTicker(pingPeriod)
+	defer sweep.Stop()
+
 	for {
 		select {
-		case client := <-h.Register:
-			room, ok := h.Rooms[client.RoomID]
-			if !ok {
-				room = &Room{
-					ID:     client.ID,
-					Name:   client.RoomID,
-					Client: make(map[string]*Client),
-				}
-				h.Rooms[client.RoomID] = room
+		case request := <-h.requests:
+			request()
+		case now := <-sweep.C:
+			h.evictStale(now)
+		case <-h.quit:
+			h.drain()
+			close(h.effects)
+			<-h.performed
+			close(h.stopped)
+			return
+		}
+	}
+}
+
+// do runs fn on the owner goroutine and waits for it to finish.
+func (h *Hub) do(fn func()) error {
+	done := make(chan struct{})
+	request := func() {
+		fn()
+		close(done)
+	}
+
+	select {
+	case h.requests <- request:
+		<-done
+		return nil
+	case <-h.quit:
+		return errors.New(constant.ERROR_HUB_CLOSED)
+	}
+}
+
+// CreateRoom makes a room known to the hub. An existing room keeps its
+// clients and only has its participants filled in. The room closes itself
+// at deadline, unless it is zero.
+func (h *Hub) CreateRoom(id string, userID string, doctorID string, deadline time.Time) error {
+	return h.do(func() {
+		room := h.room(id)
+		room.UserID = userID
+		room.DoctorID = doctorID
+		h.arm(room, deadline)
+	})
+}
+
+// ListRooms returns a snapshot of the rooms memberID takes part in.
+func (h *Hub) ListRooms(memberID string) ([]Room, error) {
+	rooms := []Room{}
+	err := h.do(func() {
+		for _, room := range h.rooms {
+			if room.UserID != memberID && room.DoctorID != memberID {
+				continue
 			}
 
-			room.Client[client.ID] = client
-		case client := <-h.Unregister:
-			room, ok := h.Rooms[client.RoomID]
-			if ok {
-				if _, ok := room.Client[client.ID]; ok {
-					delete(room.Client, client.ID)
-					close(client.Message)
-				}
+			snapshot := Room{
+				ID:       room.ID,
+				Name:     room.Name,
+				DoctorID: room.DoctorID,
+				UserID:   room.UserID,
+				Client:   make(map[string]*Client, len(room.Client)),
+			}
+			for id, client := range room.Client {
+				snapshot.Client[id] = client
 			}
-		case message := <-h.Broadcast:
-			room, ok := h.Rooms[message.RoomID]
-			if ok {
-				for _, client := range room.Client {
-					select {
-					case client.Message <- message:
-					default:
-						close(client.Message)
-						delete(room.Client, client.ID)
-					}
-				}
+			rooms = append(rooms, snapshot)
+		}
+	})
+	return rooms, err
+}
+
+// Join connects a client to its room. A second connection of the same
+// client replaces the first one.
+func (h *Hub) Join(client *Client) error {
+	return h.do(func() {
+		room := h.room(client.RoomID)
+
+		if previous, ok := room.Client[client.ID]; ok {
+			h.drop(room, previous, websocket.ClosePolicyViolation)
+		}
+		room.Client[client.ID] = client
+		h.conns.Add(1)
+		client.hub = h
+		client.lastActive.Store(time.Now().UnixNano())
+		h.arm(room, client.Deadline)
+		h.setPresence(client, true)
+	})
+}
+
+// Leave disconnects a client. It is a no-op for a client that was already
+// dropped by the hub.
+func (h *Hub) Leave(client *Client) error {
+	return h.do(func() {
+		room, ok := h.rooms[client.RoomID]
+		if !ok {
+			return
+		}
+
+		if current, ok := room.Client[client.ID]; ok && current == client {
+			h.drop(room, client, websocket.CloseNormalClosure)
+			h.prune(room)
+		}
+	})
+}
+
+// Broadcast sends an event to everyone in its room on every replica.
+func (h *Hub) Broadcast(event *Event) error {
+	return h.do(func() {
+		h.publish(&roomEvent{RoomSignal: entity.RoomSignal{RoomID: event.RoomID}, Event: event})
+	})
+}
+
+// SendTo sends an event to a single client connected to this replica. It is
+// dropped when the client already left.
+func (h *Hub) SendTo(client *Client, event *Event) error {
+	return h.do(func() {
+		room, ok := h.rooms[client.RoomID]
+		if !ok {
+			return
+		}
+
+		if current, ok := room.Client[client.ID]; ok && current == client {
+			select {
+			case client.Send <- event:
+			default:
 			}
 		}
+	})
+}
+
+// CloseRoom ends a room on every replica and disconnects its clients.
+func (h *Hub) CloseRoom(roomID string) error {
+	return h.do(func() {
+		h.publish(&roomEvent{RoomSignal: entity.RoomSignal{RoomID: roomID, Close: true}})
+	})
+}
+
+// Online returns the IDs of the clients connected to a room on any replica,
+// mapped to their role.
+func (h *Hub) Online(roomID string) map[string]string {
+	online, err := h.rdb.HGetAll(context.Background(), presenceKeyPrefix+roomID).Result()
+	if err != nil {
+		logrus.Errorf("failed to read presence of room %s: %v", roomID, err)
+		return map[string]string{}
+	}
+	return online
+}
+
+// Shutdown stops the hub, tells every connected client the server is going
+// away and waits until their connections are closed or ctx is done.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.stopOnce.Do(func() {
+		close(h.quit)
+		h.pubsub.Close()
+	})
+
+	select {
+	case <-h.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		h.conns.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// room returns the room with the given id, creating it when needed. Owner
+// goroutine only.
+func (h *Hub) room(id string) *Room {
+	room, ok := h.rooms[id]
+	if !ok {
+		room = &Room{
+			ID:     id,
+			Name:   id,
+			Client: make(map[string]*Client),
+		}
+		h.rooms[id] = room
+	}
+	return room
+}
+
+// arm makes the room close itself when the session runs out of time. A zero
+// deadline or a room that is already armed is left alone. Owner goroutine
+// only.
+func (h *Hub) arm(room *Room, deadline time.Time) {
+	if room.closer != nil || deadline.IsZero() {
+		return
 	}
-}
\ No newline at end of file
+
+	roomID := room.ID
+	room.closer = time.AfterFunc(time.Until(deadline), func() {
+		h.CloseRoom(roomID)
+	})
+}
+
+// prune forgets a room once its last client is gone, so rooms nobody is in
+// do not pile up. Listing or joining it brings it back. Owner goroutine only.
+func (h *Hub) prune(room *Room) {
+	if len(room.Client) > 0 {
+		return
+	}
+
+	if room.closer != nil {
+		room.closer.Stop()
+	}
+	delete(h.rooms, room.ID)
+}
+
+// drop removes a client from its room, clears its presence and closes its
+// queue, which makes its writer send the close frame and hang up. Owner
+// goroutine only; a client is only ever closed here, once, while it is still
+// in the room.
+func (h *Hub) drop(room *Room, client *Client, closeCode int) {
+	delete(room.Client, client.ID)
+	client.closeCode = closeCode
+	close(client.Send)
+	h.setPresence(client, false)
+}
+
+// dispatch hands an event to the clients connected to this replica. Owner
+// goroutine only.
+func (h *Hub) dispatch(event *roomEvent) {
+	room, ok := h.rooms[event.RoomID]
+	if !ok {
+		return
+	}
+
+	if event.Close {
+		if room.closer != nil {
+			room.closer.Stop()
+		}
+
+		h.notify(room, "session ended", websocket.CloseNormalClosure)
+		delete(h.rooms, event.RoomID)
+		return
+	}
+
+	if event.Deadline != nil {
+		// the session was moved, the room closes at its new end instead
+		if room.closer != nil {
+			room.closer.Stop()
+			room.closer = nil
+		}
+		h.arm(room, *event.Deadline)
+		return
+	}
+
+	for _, client := range room.Client {
+		select {
+		case client.Send <- event.Event:
+		default:
+			// the client cannot keep up, let it reconnect
+			h.drop(room, client, websocket.ClosePolicyViolation)
+			h.prune(room)
+		}
+	}
+}
+
+// evictStale drops the clients that stopped answering pings. Their reader
+// normally notices first through its read deadline; this catches the ones
+// whose connection hangs without ever failing. Owner goroutine only.
+func (h *Hub) evictStale(now time.Time) {
+	for _, room := range h.rooms {
+		for _, client := range room.Client {
+			if client.isStale(now) {
+				h.drop(room, client, websocket.CloseGoingAway)
+				h.prune(room)
+			}
+		}
+	}
+}
+
+// drain disconnects everyone when the hub stops. Owner goroutine only.
+func (h *Hub) drain() {
+	for id, room := range h.rooms {
+		if room.closer != nil {
+			room.closer.Stop()
+		}
+
+		h.notify(room, "server is restarting, please reconnect", websocket.CloseGoingAway)
+		delete(h.rooms, id)
+	}
+}
+
+// notify sends a last system notice to every client in a room and
+// disconnects them. Owner goroutine only.
+func (h *Hub) notify(room *Room, content string, closeCode int) {
+	notice := &Event{
+		Type:    constant.EVENT_SYSTEM,
+		RoomID:  room.ID,
+		Role:    constant.SYSTEM,
+		Content: content,
+		At:      time.Now(),
+	}
+	for _, client := range room.Client {
+		select {
+		case client.Send <- notice:
+		default:
+		}
+		h.drop(room, client, closeCode)
+	}
+}
+
+// setPresence records a client joining or leaving in Redis and lets the
+// other participants know. Owner goroutine only.
+func (h *Hub) setPresence(client *Client, online bool) {
+	roomID, clientID, role := client.RoomID, client.ID, client.Role
+	event := &roomEvent{
+		RoomSignal: entity.RoomSignal{RoomID: roomID},
+		Event: &Event{
+			Type:     constant.EVENT_PRESENCE,
+			RoomID:   roomID,
+			SenderID: clientID,
+			Role:     role,
+			Content:  "left",
+			At:       time.Now(),
+		},
+	}
+	if online {
+		event.Event.Content = "joined"
+	}
+
+	h.effects <- func() {
+		ctx := context.Background()
+		key := presenceKeyPrefix + roomID
+
+		var err error
+		if online {
+			pipe := h.rdb.TxPipeline()
+			pipe.HSet(ctx, key, clientID, role)
+			pipe.Expire(ctx, key, presenceTTL)
+			_, err = pipe.Exec(ctx)
+		} else {
+			err = h.rdb.HDel(ctx, key, clientID).Err()
+		}
+		if err != nil {
+			logrus.Errorf("failed to record presence in room %s: %v", roomID, err)
+		}
+
+		h.send(event)
+	}
+}
+
+// publish queues an event for every replica, this one included. Owner
+// goroutine only.
+func (h *Hub) publish(event *roomEvent) {
+	h.effects <- func() {
+		h.send(event)
+	}
+}
+
+// send publishes an event to Redis. If Redis is unreachable the event is
+// still delivered locally so a single node keeps working. Effects goroutine
+// only; the local delivery runs apart so this goroutine never waits on the
+// owner goroutine, which may be waiting on it.
+func (h *Hub) send(event *roomEvent) {
+	payload, err := json.Marshal(event)
+	if err == nil {
+		err = h.rdb.Publish(context.Background(), roomChannelPrefix+event.RoomID, payload).Err()
+	}
+	if err != nil {
+		logrus.Errorf("failed to publish event to room %s: %v", event.RoomID, err)
+		go h.do(func() { h.dispatch(event) })
+	}
+}
+
+// perform runs the queued Redis writes in order until the owner goroutine
+// stops queueing them.
+func (h *Hub) perform() {
+	defer close(h.performed)
+
+	for effect := range h.effects {
+		effect()
+	}
+}
+
+// subscribe feeds the events published by any replica into the owner
+// goroutine until the hub shuts down.
+func (h *Hub) subscribe() {
+	for msg := range h.pubsub.Channel() {
+		event := &roomEvent{}
+		if err := json.Unmarshal([]byte(msg.Payload), event); err != nil {
+			logrus.Errorf("failed to decode room event: %v", err)
+			continue
+		}
+
+		if err := h.do(func() { h.dispatch(event) }); err != nil {
+			return
+		}
+	}
+}
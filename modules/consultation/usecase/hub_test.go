@@ -0,0 +1,364 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"talkspace-api/modules/consultation/entity"
+	"talkspace-api/utils/constant"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+// newTestHub runs a hub against a Redis that cannot be reached, so every
+// event takes the local delivery path of a single replica.
+func newTestHub(t *testing.T) *Hub {
+	t.Helper()
+	logrus.SetOutput(io.Discard)
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr:        "127.0.0.1:1",
+		MaxRetries:  -1,
+		DialTimeout: 50 * time.Millisecond,
+	})
+	hub := NewHub(rdb)
+	go hub.Run()
+
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := hub.Shutdown(ctx); err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+		rdb.Close()
+	})
+
+	return hub
+}
+
+func newTestClient(roomID string, id string, buffer int) *Client {
+	return &Client{
+		Send:   make(chan *Event, buffer),
+		ID:     id,
+		RoomID: roomID,
+		Role:   constant.USER,
+	}
+}
+
+// consume stands in for the writer of a joined client: it reads the queue
+// until the hub closes it and collects what it received.
+func consume(hub *Hub, client *Client) <-chan []*Event {
+	received := make(chan []*Event, 1)
+	go func() {
+		events := []*Event{}
+		for event := range client.Send {
+			events = append(events, event)
+		}
+		hub.conns.Done()
+		received <- events
+	}()
+	return received
+}
+
+// waitDropped waits until the hub removed a client nobody reads from its
+// room, then checks its queue was closed.
+func waitDropped(t *testing.T, hub *Hub, client *Client, memberID string) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		rooms, err := hub.ListRooms(memberID)
+		if err != nil {
+			t.Fatalf("ListRooms() error = %v", err)
+		}
+		if len(rooms) == 0 || rooms[0].Client[client.ID] == nil {
+			if _, ok := <-client.Send; ok {
+				t.Fatal("dropped client still has an open queue")
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("client was not dropped")
+}
+
+func TestHubConcurrentAccess(t *testing.T) {
+	hub := newTestHub(t)
+
+	const rooms = 8
+	const clientsPerRoom = 4
+
+	var wg sync.WaitGroup
+	for r := 0; r < rooms; r++ {
+		roomID := fmt.Sprintf("room-%d", r)
+		for c := 0; c < clientsPerRoom; c++ {
+			wg.Add(1)
+			go func(clientID string) {
+				defer wg.Done()
+
+				if err := hub.CreateRoom(roomID, "user-"+roomID, "doctor-"+roomID, time.Time{}); err != nil {
+					t.Errorf("CreateRoom() error = %v", err)
+					return
+				}
+
+				client := newTestClient(roomID, clientID, 256)
+				if err := hub.Join(client); err != nil {
+					t.Errorf("Join() error = %v", err)
+					return
+				}
+				received := consume(hub, client)
+
+				for i := 0; i < 10; i++ {
+					if err := hub.Broadcast(&Event{Type: constant.EVENT_TYPING, RoomID: roomID, SenderID: clientID}); err != nil {
+						t.Errorf("Broadcast() error = %v", err)
+					}
+					if _, err := hub.ListRooms("user-" + roomID); err != nil {
+						t.Errorf("ListRooms() error = %v", err)
+					}
+					hub.SendTo(client, &Event{Type: constant.EVENT_SYSTEM, RoomID: roomID})
+				}
+
+				if err := hub.Leave(client); err != nil {
+					t.Errorf("Leave() error = %v", err)
+				}
+				<-received
+			}(fmt.Sprintf("client-%d-%d", r, c))
+		}
+	}
+	wg.Wait()
+
+	rooms2, err := hub.ListRooms("user-room-0")
+	if err != nil {
+		t.Fatalf("ListRooms() error = %v", err)
+	}
+	if len(rooms2) != 0 {
+		t.Errorf("ListRooms() = %+v, want the room forgotten once everyone left", rooms2)
+	}
+}
+
+func TestHubCreateRoomClosesAtDeadline(t *testing.T) {
+	hub := newTestHub(t)
+
+	// nobody joins the room, it still goes away when the session is over
+	if err := hub.CreateRoom("room", "user", "doctor", time.Now().Add(50*time.Millisecond)); err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		rooms, err := hub.ListRooms("user")
+		if err != nil {
+			t.Fatalf("ListRooms() error = %v", err)
+		}
+		if len(rooms) == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("room was not closed at its deadline")
+}
+
+func TestHubRoomSignals(t *testing.T) {
+	hub := newTestHub(t)
+
+	if err := hub.CreateRoom("moved", "user", "doctor", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+	if err := hub.CreateRoom("cancelled", "user", "doctor", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+
+	client := newTestClient("cancelled", "user", 8)
+	if err := hub.Join(client); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	received := consume(hub, client)
+
+	// what the repository publishes when a session is moved or cancelled
+	newEnd := time.Now().Add(50 * time.Millisecond)
+	hub.do(func() {
+		hub.dispatch(&roomEvent{RoomSignal: entity.RoomSignal{RoomID: "moved", Deadline: &newEnd}})
+		hub.dispatch(&roomEvent{RoomSignal: entity.RoomSignal{RoomID: "cancelled", Close: true}})
+	})
+
+	<-received
+	if client.closeCode == 0 {
+		t.Error("client of a cancelled session was closed without a close code")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		rooms, err := hub.ListRooms("user")
+		if err != nil {
+			t.Fatalf("ListRooms() error = %v", err)
+		}
+		if len(rooms) == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("moved room was not closed at its new deadline")
+}
+
+func TestHubLeaveAfterDrop(t *testing.T) {
+	hub := newTestHub(t)
+
+	// an unbuffered queue nobody reads cannot keep up with the first event
+	if err := hub.CreateRoom("room", "user", "doctor", time.Time{}); err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+
+	client := newTestClient("room", "client", 0)
+	if err := hub.Join(client); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	hub.conns.Done()
+
+	if err := hub.Broadcast(&Event{Type: constant.EVENT_SYSTEM, RoomID: "room"}); err != nil {
+		t.Fatalf("Broadcast() error = %v", err)
+	}
+	waitDropped(t, hub, client, "user")
+
+	// the reader of a dropped client still leaves; its queue must not be
+	// closed a second time
+	if err := hub.Leave(client); err != nil {
+		t.Fatalf("Leave() error = %v", err)
+	}
+	if err := hub.Leave(client); err != nil {
+		t.Fatalf("second Leave() error = %v", err)
+	}
+}
+
+func TestHubJoinReplacesClient(t *testing.T) {
+	hub := newTestHub(t)
+
+	first := newTestClient("room", "client", 8)
+	if err := hub.Join(first); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	firstReceived := consume(hub, first)
+
+	second := newTestClient("room", "client", 8)
+	if err := hub.Join(second); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	secondReceived := consume(hub, second)
+
+	<-firstReceived
+	if first.closeCode == 0 {
+		t.Error("replaced client was closed without a close code")
+	}
+
+	// the replaced connection leaving must not drop the new one
+	if err := hub.Leave(first); err != nil {
+		t.Fatalf("Leave() error = %v", err)
+	}
+
+	hub.CreateRoom("room", "client", "doctor", time.Time{})
+	rooms, err := hub.ListRooms("client")
+	if err != nil {
+		t.Fatalf("ListRooms() error = %v", err)
+	}
+	if len(rooms) != 1 || rooms[0].Client["client"] != second {
+		t.Fatalf("ListRooms() = %+v, want the second connection in the room", rooms)
+	}
+
+	if err := hub.Leave(second); err != nil {
+		t.Fatalf("Leave() error = %v", err)
+	}
+	<-secondReceived
+}
+
+func TestHubCloseRoom(t *testing.T) {
+	hub := newTestHub(t)
+
+	if err := hub.CreateRoom("room", "user", "doctor", time.Time{}); err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+
+	clients := []*Client{newTestClient("room", "user", 8), newTestClient("room", "doctor", 8)}
+	received := []<-chan []*Event{}
+	for _, client := range clients {
+		if err := hub.Join(client); err != nil {
+			t.Fatalf("Join() error = %v", err)
+		}
+		received = append(received, consume(hub, client))
+	}
+
+	if err := hub.CloseRoom("room"); err != nil {
+		t.Fatalf("CloseRoom() error = %v", err)
+	}
+
+	for i, client := range clients {
+		events := <-received[i]
+		if len(events) == 0 || events[len(events)-1].Type != constant.EVENT_SYSTEM {
+			t.Errorf("client %s last events = %+v, want a system notice", client.ID, events)
+		}
+
+		if err := hub.Leave(client); err != nil {
+			t.Errorf("Leave() error = %v", err)
+		}
+	}
+
+	rooms, err := hub.ListRooms("user")
+	if err != nil {
+		t.Fatalf("ListRooms() error = %v", err)
+	}
+	if len(rooms) != 0 {
+		t.Errorf("ListRooms() = %+v, want no rooms", rooms)
+	}
+}
+
+func TestHubShutdown(t *testing.T) {
+	rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 50 * time.Millisecond})
+	defer rdb.Close()
+	logrus.SetOutput(io.Discard)
+
+	hub := NewHub(rdb)
+	go hub.Run()
+
+	client := newTestClient("room", "client", 8)
+	if err := hub.Join(client); err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	received := consume(hub, client)
+
+	// callers racing the shutdown either get through or are told the hub
+	// closed, they never block
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hub.Broadcast(&Event{Type: constant.EVENT_SYSTEM, RoomID: "room"})
+			hub.ListRooms("client")
+			hub.Leave(client)
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() { errs <- hub.Shutdown(ctx) }()
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("Shutdown() error = %v", err)
+		}
+	}
+	wg.Wait()
+	<-received
+
+	if err := hub.Broadcast(&Event{RoomID: "room"}); err == nil || err.Error() != constant.ERROR_HUB_CLOSED {
+		t.Errorf("Broadcast() after Shutdown() error = %v, want %s", err, constant.ERROR_HUB_CLOSED)
+	}
+	if err := hub.Join(newTestClient("room", "late", 1)); err == nil {
+		t.Error("Join() after Shutdown() error = nil, want an error")
+	}
+}
@@ -1 +1,25 @@
-package usecase
\ No newline at end of file
+package usecase
+
+import (
+	"mime/multipart"
+	"talkspace-api/modules/consultation/entity"
+	"time"
+)
+
+type ConsultationCommandUsecaseInterface interface {
+	CreateJoinTicket(id string, clientID string, role string) (entity.JoinTicket, error)
+	JoinConsultation(id string, ticket string) (entity.Consultation, entity.JoinTicket, error)
+	EndConsultation(id string, doctorID string) (entity.Consultation, error)
+	CloseLapsedConsultations() ([]entity.Consultation, error)
+	SendMessage(consultationID string, senderID string, role string, content string) (entity.Message, error)
+	UpdateMessageReceipts(consultationID string, recipientID string, messageID string, receipt string, at time.Time) (int64, error)
+	SendAttachment(consultationID string, senderID string, role string, caption string, file *multipart.FileHeader) (entity.Message, error)
+}
+
+type ConsultationQueryUsecaseInterface interface {
+	GetConsultationByID(id string) (entity.Consultation, error)
+	GetMessages(consultationID string, requesterID string, cursor entity.MessageCursor) ([]entity.Message, bool, error)
+	GetMissedMessages(consultationID string, lastSeenID string, lastSeq int64) ([]entity.Message, bool, error)
+	GetSenderName(id string, role string) (string, error)
+	GetAttachmentURL(consultationID string, attachmentID string, requesterID string) (string, time.Time, error)
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/consultation/entity"
+	"talkspace-api/modules/consultation/repository"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/cloud"
+	"time"
+)
+
+type consultationQueryUsecase struct {
+	consultationCommandRepository repository.ConsultationCommandRepositoryInterface
+	consultationQueryRepository   repository.ConsultationQueryRepositoryInterface
+}
+
+func NewConsultationQueryUsecase(ccr repository.ConsultationCommandRepositoryInterface, cqr repository.ConsultationQueryRepositoryInterface) ConsultationQueryUsecaseInterface {
+	return &consultationQueryUsecase{
+		consultationCommandRepository: ccr,
+		consultationQueryRepository:   cqr,
+	}
+}
+
+func (cqu *consultationQueryUsecase) GetConsultationByID(id string) (entity.Consultation, error) {
+	if id == "" {
+		return entity.Consultation{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	consultation, errGetID := cqu.consultationQueryRepository.GetConsultationByID(id)
+	if errGetID != nil {
+		return entity.Consultation{}, errGetID
+	}
+
+	return consultation, nil
+}
+
+// GetMessages pages through the chat history of a consultation. Only its
+// user and doctor can read it.
+func (cqu *consultationQueryUsecase) GetMessages(consultationID string, requesterID string, cursor entity.MessageCursor) ([]entity.Message, bool, error) {
+	if consultationID == "" {
+		return nil, false, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	if cursor.Before != "" && cursor.After != "" {
+		return nil, false, errors.New(constant.ERROR_CURSOR_INVALID)
+	}
+
+	if cursor.Limit <= 0 || cursor.Limit > entity.MessagePageLimit {
+		cursor.Limit = entity.MessagePageLimit
+	}
+
+	consultation, errGetID := cqu.consultationQueryRepository.GetConsultationByID(consultationID)
+	if errGetID != nil {
+		return nil, false, errGetID
+	}
+
+	if !consultation.IsParticipant(requesterID) {
+		return nil, false, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	messages, hasMore, errGet := cqu.consultationQueryRepository.GetMessages(consultationID, cursor)
+	if errGet != nil {
+		return nil, false, errGet
+	}
+
+	return messages, hasMore, nil
+}
+
+// GetMissedMessages returns what a reconnecting client has not seen yet:
+// the messages after its last acknowledged sequence number, or after the
+// last message ID it has seen. lastSeq is negative when the client sent
+// none; a client at zero gets the latest messages and a client that sent
+// neither gets nothing and loads the history through GetMessages instead.
+// It also reports whether more was missed than entity.MessageReplayLimit.
+func (cqu *consultationQueryUsecase) GetMissedMessages(consultationID string, lastSeenID string, lastSeq int64) ([]entity.Message, bool, error) {
+	cursor := entity.MessageCursor{Limit: entity.MessageReplayLimit}
+	switch {
+	case lastSeq >= 0:
+		cursor.AfterSeq = lastSeq
+	case lastSeenID != "":
+		cursor.After = lastSeenID
+	default:
+		return []entity.Message{}, false, nil
+	}
+
+	messages, hasMore, errGet := cqu.consultationQueryRepository.GetMessages(consultationID, cursor)
+	if errGet != nil {
+		return nil, false, errGet
+	}
+
+	return messages, hasMore, nil
+}
+
+func (cqu *consultationQueryUsecase) GetSenderName(id string, role string) (string, error) {
+	name, errGet := cqu.consultationQueryRepository.GetSenderName(id, role)
+	if errGet != nil {
+		return "", errGet
+	}
+
+	return name, nil
+}
+
+// GetAttachmentURL returns a short-lived download link for a file shared in
+// a consultation. Only its user and doctor can get one.
+func (cqu *consultationQueryUsecase) GetAttachmentURL(consultationID string, attachmentID string, requesterID string) (string, time.Time, error) {
+	if consultationID == "" || attachmentID == "" {
+		return "", time.Time{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	consultation, errGetID := cqu.consultationQueryRepository.GetConsultationByID(consultationID)
+	if errGetID != nil {
+		return "", time.Time{}, errGetID
+	}
+
+	if !consultation.IsParticipant(requesterID) {
+		return "", time.Time{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	attachment, errGetAttachment := cqu.consultationQueryRepository.GetAttachmentByID(attachmentID)
+	if errGetAttachment != nil {
+		return "", time.Time{}, errGetAttachment
+	}
+
+	// the id of another room's attachment is no key to it
+	if attachment.ConsultationID != consultationID {
+		return "", time.Time{}, errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	expiresAt := time.Now().Add(entity.AttachmentURLExpiry)
+	url, errPresign := cloud.PresignS3URL(attachment.StorageKey, attachment.FileName, entity.AttachmentURLExpiry)
+	if errPresign != nil {
+		return "", time.Time{}, errPresign
+	}
+
+	return url, expiresAt, nil
+}
@@ -132,6 +132,8 @@ func DoctorEntityToDoctorProfileResponse(entity entity.Doctor) DoctorProfileResp
 		Alumnus:           entity.Alumnus,
 		About:             entity.About,
 		Location:          entity.Location,
+		RatingAverage:     entity.RatingAverage,
+		RatingCount:       entity.RatingCount,
 	}
 }
 
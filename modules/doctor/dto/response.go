@@ -38,18 +38,20 @@ type (
 	}
 
 	DoctorProfileResponse struct {
-		ID                string `json:"id"`
-		Status            bool   `json:"status"`
-		Fullname          string `json:"fullname"`
-		Email             string `json:"email"`
-		ProfilePicture    string `json:"profile_picture"`
-		Gender            string `json:"gender"`
-		Specialization    string `json:"specialization"`
-		LicenseNumber     string `json:"license_number"`
-		YearsOfExperience string `json:"years_of_experience"`
-		Alumnus           string `json:"alumnus"`
-		About             string `json:"about"`
-		Location          string `json:"location"`
+		ID                string  `json:"id"`
+		Status            bool    `json:"status"`
+		Fullname          string  `json:"fullname"`
+		Email             string  `json:"email"`
+		ProfilePicture    string  `json:"profile_picture"`
+		Gender            string  `json:"gender"`
+		Specialization    string  `json:"specialization"`
+		LicenseNumber     string  `json:"license_number"`
+		YearsOfExperience string  `json:"years_of_experience"`
+		Alumnus           string  `json:"alumnus"`
+		About             string  `json:"about"`
+		Location          string  `json:"location"`
+		RatingAverage     float64 `json:"rating_average"`
+		RatingCount       int     `json:"rating_count"`
 	}
 
 	DoctorUpdateStatusResponse struct {
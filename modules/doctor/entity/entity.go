@@ -22,6 +22,8 @@ type Doctor struct {
 	Role              string
 	OTP               string
 	OTPExpiration     int64
+	RatingAverage     float64
+	RatingCount       int
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         *time.Time
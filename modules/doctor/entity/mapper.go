@@ -56,6 +56,8 @@ func DoctorModelToDoctorEntity(doctorModel model.Doctor) Doctor {
 		Role:              doctorModel.Role,
 		OTP:               doctorModel.OTP,
 		OTPExpiration:     doctorModel.OTPExpiration,
+		RatingAverage:     doctorModel.RatingAverage,
+		RatingCount:       doctorModel.RatingCount,
 		CreatedAt:         doctorModel.CreatedAt,
 		UpdatedAt:         doctorModel.UpdatedAt,
 		DeletedAt:         doctorModel.DeletedAt,
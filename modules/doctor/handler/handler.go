@@ -59,6 +59,7 @@ func (dh *doctorHandler) GetDoctorByID(c echo.Context) error {
 func (dh *doctorHandler) GetAllDoctors(c echo.Context) error {
 	statusParam := c.QueryParam("status")
 	specializationParam := c.QueryParam("specialization")
+	sortParam := c.QueryParam("sort")
 	pageParam := c.QueryParam("page")
 	limitParam := c.QueryParam("limit")
 
@@ -78,8 +79,11 @@ func (dh *doctorHandler) GetAllDoctors(c echo.Context) error {
 		limit, _ = strconv.Atoi(limitParam)
 	}
 
-	doctors, totalItems, err := dh.doctorQueryUsecase.GetAllDoctors(status, specializationParam, page, limit)
+	doctors, totalItems, err := dh.doctorQueryUsecase.GetAllDoctors(status, specializationParam, sortParam, page, limit)
 	if err != nil {
+		if err.Error() == constant.ERROR_SORT_INVALID {
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(err.Error()))
+		}
 		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(err.Error()))
 	}
 
@@ -24,6 +24,8 @@ type Doctor struct {
 	Role              string  `gorm:"type:role;default:'doctor'"`
 	OTP               string  `gorm:"not null"`
 	OTPExpiration     int64
+	RatingAverage     float64 `gorm:"<-:false;not null;default:0"`
+	RatingCount       int     `gorm:"<-:false;not null;default:0"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 	DeletedAt         *time.Time       `gorm:"index"`
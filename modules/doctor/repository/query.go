@@ -88,10 +88,10 @@ func (dqr *doctorQueryRepository) GetDoctorByEmail(email string) (entity.Doctor,
 	return doctorEntity, nil
 }
 
-func (dqr *doctorQueryRepository) GetAllDoctors(status *bool, specialization string, page, limit int) ([]entity.Doctor, int, error) {
+func (dqr *doctorQueryRepository) GetAllDoctors(status *bool, specialization string, sort string, page, limit int) ([]entity.Doctor, int, error) {
 	offset := (page - 1) * limit
 
-	cacheKey := fmt.Sprintf("doctors:all:status:%v:specialization:%s:page:%d:limit:%d", status, specialization, page, limit)
+	cacheKey := fmt.Sprintf("doctors:all:status:%v:specialization:%s:sort:%s:page:%d:limit:%d", status, specialization, sort, page, limit)
 	cachedDoctors, err := dqr.rdb.Get(context.Background(), cacheKey).Result()
 	if err == nil && cachedDoctors != "" {
 		var cacheResult struct {
@@ -113,6 +113,13 @@ func (dqr *doctorQueryRepository) GetAllDoctors(status *bool, specialization str
 		query = query.Where("specialization = ?", specialization)
 	}
 
+	switch sort {
+	case constant.SORT_RATING:
+		query = query.Order("rating_average DESC").Order("rating_count DESC")
+	case constant.SORT_REVIEWS:
+		query = query.Order("rating_count DESC").Order("rating_average DESC")
+	}
+
 	var totalItems int64
 	dqr.db.Model(&model.Doctor{}).Count(&totalItems)
 
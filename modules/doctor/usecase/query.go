@@ -32,8 +32,12 @@ func (dqs *doctorQueryUsecase) GetDoctorByID(id string) (entity.Doctor, error) {
 	return doctorEntity, nil
 }
 
-func (dqs *doctorQueryUsecase) GetAllDoctors(status *bool, specialization string, page, limit int) ([]entity.Doctor, int, error) {
-	doctors, totalItems, err := dqs.doctorQueryRepository.GetAllDoctors(status, specialization, page, limit)
+func (dqs *doctorQueryUsecase) GetAllDoctors(status *bool, specialization string, sort string, page, limit int) ([]entity.Doctor, int, error) {
+	if sort != "" && sort != constant.SORT_RATING && sort != constant.SORT_REVIEWS {
+		return nil, 0, errors.New(constant.ERROR_SORT_INVALID)
+	}
+
+	doctors, totalItems, err := dqs.doctorQueryRepository.GetAllDoctors(status, specialization, sort, page, limit)
 	if err != nil {
 		if err.Error() == constant.ERROR_DATA_EMPTY {
 			return nil, 0, errors.New(constant.ERROR_DATA_EMPTY)
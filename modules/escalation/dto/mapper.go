@@ -0,0 +1,29 @@
+package dto
+
+import "talkspace-api/modules/escalation/entity"
+
+func EscalationEntityToEscalationResponse(response entity.Escalation) EscalationResponse {
+	return EscalationResponse{
+		ID:          response.ID,
+		Source:      response.Source,
+		ReferenceID: response.ReferenceID,
+		UserID:      response.UserID,
+		Level:       response.Level,
+		Rules:       response.Rules,
+		Notified:    response.Notified,
+		Status:      response.Status,
+		HandledBy:   response.HandledBy,
+		Note:        response.Note,
+		HandledAt:   response.HandledAt,
+		CreatedAt:   response.CreatedAt,
+	}
+}
+
+func ListEscalationEntityToEscalationResponse(response []entity.Escalation) []EscalationResponse {
+	escalationResponses := []EscalationResponse{}
+	for _, escalation := range response {
+		escalationResponse := EscalationEntityToEscalationResponse(escalation)
+		escalationResponses = append(escalationResponses, escalationResponse)
+	}
+	return escalationResponses
+}
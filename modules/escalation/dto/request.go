@@ -0,0 +1,6 @@
+package dto
+
+type EscalationStatusRequest struct {
+	Status string `json:"status" form:"status"`
+	Note   string `json:"note" form:"note"`
+}
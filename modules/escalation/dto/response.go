@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type EscalationResponse struct {
+	ID          string     `json:"id"`
+	Source      string     `json:"source"`
+	ReferenceID string     `json:"reference_id"`
+	UserID      string     `json:"user_id"`
+	Level       string     `json:"level"`
+	Rules       []string   `json:"rules"`
+	Notified    bool       `json:"notified"`
+	Status      string     `json:"status"`
+	HandledBy   string     `json:"handled_by"`
+	Note        string     `json:"note"`
+	HandledAt   *time.Time `json:"handled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
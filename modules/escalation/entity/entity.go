@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+const (
+	// NotifyCooldown is how long after an escalation further ones of the
+	// same user are recorded without paging the on-call admin again, unless
+	// they are more urgent than any the admin was paged about.
+	NotifyCooldown = 30 * time.Minute
+	// NoteMaxLength is the longest follow-up note accepted, in bytes.
+	NoteMaxLength = 2000
+)
+
+type Escalation struct {
+	ID          string
+	Source      string
+	ReferenceID string
+	UserID      string
+	Level       string
+	Rules       []string
+	Notified    bool
+	Status      string
+	HandledBy   string
+	Note        string
+	HandledAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"encoding/json"
+	"talkspace-api/modules/escalation/model"
+)
+
+func EscalationEntityToEscalationModel(escalationEntity Escalation) model.Escalation {
+	rules, _ := json.Marshal(escalationEntity.Rules)
+
+	return model.Escalation{
+		ID:          escalationEntity.ID,
+		Source:      escalationEntity.Source,
+		ReferenceID: escalationEntity.ReferenceID,
+		UserID:      escalationEntity.UserID,
+		Level:       escalationEntity.Level,
+		Rules:       string(rules),
+		Notified:    escalationEntity.Notified,
+		Status:      escalationEntity.Status,
+		HandledBy:   escalationEntity.HandledBy,
+		Note:        escalationEntity.Note,
+		HandledAt:   escalationEntity.HandledAt,
+		CreatedAt:   escalationEntity.CreatedAt,
+		UpdatedAt:   escalationEntity.UpdatedAt,
+	}
+}
+
+func EscalationModelToEscalationEntity(escalationModel model.Escalation) Escalation {
+	rules := []string{}
+	json.Unmarshal([]byte(escalationModel.Rules), &rules)
+
+	return Escalation{
+		ID:          escalationModel.ID,
+		Source:      escalationModel.Source,
+		ReferenceID: escalationModel.ReferenceID,
+		UserID:      escalationModel.UserID,
+		Level:       escalationModel.Level,
+		Rules:       rules,
+		Notified:    escalationModel.Notified,
+		Status:      escalationModel.Status,
+		HandledBy:   escalationModel.HandledBy,
+		Note:        escalationModel.Note,
+		HandledAt:   escalationModel.HandledAt,
+		CreatedAt:   escalationModel.CreatedAt,
+		UpdatedAt:   escalationModel.UpdatedAt,
+	}
+}
+
+func ListEscalationModelToEscalationEntity(escalationModels []model.Escalation) []Escalation {
+	listEscalationEntity := []Escalation{}
+	for _, escalation := range escalationModels {
+		escalationEntity := EscalationModelToEscalationEntity(escalation)
+		listEscalationEntity = append(listEscalationEntity, escalationEntity)
+	}
+	return listEscalationEntity
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/escalation/dto"
+	"talkspace-api/modules/escalation/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type escalationHandler struct {
+	escalationCommandUsecase usecase.EscalationCommandUsecaseInterface
+	escalationQueryUsecase   usecase.EscalationQueryUsecaseInterface
+}
+
+func NewEscalationHandler(ecu usecase.EscalationCommandUsecaseInterface, equ usecase.EscalationQueryUsecaseInterface) *escalationHandler {
+	return &escalationHandler{
+		escalationCommandUsecase: ecu,
+		escalationQueryUsecase:   equ,
+	}
+}
+
+// Query
+func (eh *escalationHandler) GetEscalations(c echo.Context) error {
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	escalations, totalItems, errGet := eh.escalationQueryUsecase.GetEscalations(c.QueryParam("status"), page, limit)
+	if errGet != nil {
+		if errGet.Error() == constant.ERROR_STATUS_INVALID {
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGet.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(escalations) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	escalationResponses := dto.ListEscalationEntityToEscalationResponse(escalations)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		escalationResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (eh *escalationHandler) GetEscalationByID(c echo.Context) error {
+	escalationIDParam := c.Param("escalation_id")
+	if escalationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	escalation, errGetID := eh.escalationQueryUsecase.GetEscalationByID(escalationIDParam)
+	if errGetID != nil {
+		if errGetID.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGetID.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGetID.Error()))
+	}
+
+	escalationResponse := dto.EscalationEntityToEscalationResponse(escalation)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, escalationResponse))
+}
+
+// Command
+func (eh *escalationHandler) UpdateEscalationStatus(c echo.Context) error {
+	escalationIDParam := c.Param("escalation_id")
+	if escalationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	adminID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	statusRequest := dto.EscalationStatusRequest{}
+
+	errBind := c.Bind(&statusRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	escalation, errUpdate := eh.escalationCommandUsecase.UpdateEscalationStatus(escalationIDParam, adminID, statusRequest.Status, statusRequest.Note)
+	if errUpdate != nil {
+		switch errUpdate.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errUpdate.Error()))
+		case constant.ERROR_ESCALATION_STATUS:
+			return c.JSON(http.StatusConflict, responses.ErrorResponse(errUpdate.Error()))
+		case constant.ERROR_STATUS_INVALID, constant.ERROR_ESCALATION_TOO_LONG:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errUpdate.Error()))
+		default:
+			return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errUpdate.Error()))
+		}
+	}
+
+	escalationResponse := dto.EscalationEntityToEscalationResponse(escalation)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_STATUS_UPDATED, escalationResponse))
+}
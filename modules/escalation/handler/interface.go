@@ -0,0 +1,12 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type EscalationHandlerInterface interface {
+	// Query
+	GetEscalations(c echo.Context) error
+	GetEscalationByID(c echo.Context) error
+
+	// Command
+	UpdateEscalationStatus(c echo.Context) error
+}
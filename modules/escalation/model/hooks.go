@@ -0,0 +1,12 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (e *Escalation) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	e.ID = UUID.String()
+	return nil
+}
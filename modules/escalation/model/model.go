@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Escalation records a message that was flagged for self-harm risk so an
+// admin can follow it up. It points at the message instead of copying it.
+type Escalation struct {
+	ID          string `gorm:"primarykey"`
+	Source      string `gorm:"type:varchar(20);not null"`
+	ReferenceID string `gorm:"index;not null"`
+	UserID      string `gorm:"index;not null"`
+	Level       string `gorm:"type:varchar(20);not null"`
+	Rules       string `gorm:"type:jsonb;not null;default:'[]'"`
+	Notified    bool   `gorm:"not null;default:false"`
+	Status      string `gorm:"type:varchar(20);index;not null;default:'open'"`
+	HandledBy   string
+	Note        string `gorm:"type:text"`
+	HandledAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/escalation/entity"
+	"talkspace-api/modules/escalation/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type escalationCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewEscalationCommandRepository(db *gorm.DB) EscalationCommandRepositoryInterface {
+	return &escalationCommandRepository{
+		db: db,
+	}
+}
+
+func (ecr *escalationCommandRepository) CreateEscalation(escalation entity.Escalation) (entity.Escalation, error) {
+	escalationModel := entity.EscalationEntityToEscalationModel(escalation)
+
+	result := ecr.db.Create(&escalationModel)
+	if result.Error != nil {
+		return entity.Escalation{}, result.Error
+	}
+
+	escalationEntity := entity.EscalationModelToEscalationEntity(escalationModel)
+
+	return escalationEntity, nil
+}
+
+// UpdateEscalationStatus moves an escalation forward, open to acknowledged
+// to resolved. Resolving an open escalation directly is allowed, going back
+// is not.
+func (ecr *escalationCommandRepository) UpdateEscalationStatus(id string, status string, handledBy string, note string, at time.Time) (entity.Escalation, error) {
+	escalationModel := model.Escalation{}
+
+	errTx := ecr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&escalationModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		if !canMove(escalationModel.Status, status) {
+			return errors.New(constant.ERROR_ESCALATION_STATUS)
+		}
+
+		escalationModel.Status = status
+		escalationModel.HandledBy = handledBy
+		escalationModel.HandledAt = &at
+		if note != "" {
+			escalationModel.Note = note
+		}
+
+		return tx.Save(&escalationModel).Error
+	})
+	if errTx != nil {
+		return entity.Escalation{}, errTx
+	}
+
+	escalationEntity := entity.EscalationModelToEscalationEntity(escalationModel)
+
+	return escalationEntity, nil
+}
+
+func canMove(from string, to string) bool {
+	switch from {
+	case constant.ESCALATION_OPEN:
+		return to == constant.ESCALATION_ACKNOWLEDGED || to == constant.ESCALATION_RESOLVED
+	case constant.ESCALATION_ACKNOWLEDGED:
+		return to == constant.ESCALATION_RESOLVED
+	default:
+		return false
+	}
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"talkspace-api/modules/escalation/entity"
+	"time"
+)
+
+type EscalationCommandRepositoryInterface interface {
+	CreateEscalation(escalation entity.Escalation) (entity.Escalation, error)
+	UpdateEscalationStatus(id string, status string, handledBy string, note string, at time.Time) (entity.Escalation, error)
+}
+
+type EscalationQueryRepositoryInterface interface {
+	GetEscalationByID(id string) (entity.Escalation, error)
+	GetEscalations(status string, page, limit int) ([]entity.Escalation, int, error)
+	HasNotifiedEscalation(userID string, since time.Time, minLevel string) (bool, error)
+	GetAdminEmails() ([]string, error)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/escalation/entity"
+	"talkspace-api/modules/escalation/model"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/risk"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type escalationQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewEscalationQueryRepository(db *gorm.DB) EscalationQueryRepositoryInterface {
+	return &escalationQueryRepository{
+		db: db,
+	}
+}
+
+func (eqr *escalationQueryRepository) GetEscalationByID(id string) (entity.Escalation, error) {
+	escalationModel := model.Escalation{}
+	result := eqr.db.Where("id = ?", id).First(&escalationModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Escalation{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Escalation{}, result.Error
+	}
+
+	escalationEntity := entity.EscalationModelToEscalationEntity(escalationModel)
+
+	return escalationEntity, nil
+}
+
+// GetEscalations lists escalations newest first, only those in status when
+// it is set.
+func (eqr *escalationQueryRepository) GetEscalations(status string, page, limit int) ([]entity.Escalation, int, error) {
+	offset := (page - 1) * limit
+
+	byStatus := func(db *gorm.DB) *gorm.DB {
+		if status != "" {
+			db = db.Where("status = ?", status)
+		}
+		return db
+	}
+
+	var totalItems int64
+	result := eqr.db.Model(&model.Escalation{}).Scopes(byStatus).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var escalationModels []model.Escalation
+	result = eqr.db.Scopes(byStatus).Order("created_at DESC").Offset(offset).Limit(limit).Find(&escalationModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	escalations := entity.ListEscalationModelToEscalationEntity(escalationModels)
+
+	return escalations, int(totalItems), nil
+}
+
+// HasNotifiedEscalation reports whether the on-call admin was already paged
+// about the user since the given time, for an escalation at minLevel or a
+// more urgent one.
+func (eqr *escalationQueryRepository) HasNotifiedEscalation(userID string, since time.Time, minLevel string) (bool, error) {
+	var count int64
+	result := eqr.db.Model(&model.Escalation{}).
+		Where("user_id = ? AND notified = ? AND created_at >= ? AND level IN ?", userID, true, since, risk.LevelsFrom(minLevel)).
+		Count(&count)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return count > 0, nil
+}
+
+func (eqr *escalationQueryRepository) GetAdminEmails() ([]string, error) {
+	var emails []string
+	result := eqr.db.Table("admins").Where("deleted_at IS NULL").Pluck("email", &emails)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return emails, nil
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/escalation/handler"
+	"talkspace-api/modules/escalation/repository"
+	"talkspace-api/modules/escalation/usecase"
+	"talkspace-api/utils/helper/risk"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func EscalationRoutes(e *echo.Group, db *gorm.DB) {
+	escalationQueryRepository := repository.NewEscalationQueryRepository(db)
+	escalationCommandRepository := repository.NewEscalationCommandRepository(db)
+
+	escalationQueryUsecase := usecase.NewEscalationQueryUsecase(escalationQueryRepository)
+	escalationCommandUsecase := usecase.NewEscalationCommandUsecase(escalationCommandRepository, escalationQueryRepository, risk.NewDetector())
+
+	escalationHandler := handler.NewEscalationHandler(escalationCommandUsecase, escalationQueryUsecase)
+
+	e.GET("", escalationHandler.GetEscalations, middlewares.JWTMiddleware(false))
+	e.GET("/:escalation_id", escalationHandler.GetEscalationByID, middlewares.JWTMiddleware(false))
+	e.PATCH("/:escalation_id/status", escalationHandler.UpdateEscalationStatus, middlewares.JWTMiddleware(false))
+}
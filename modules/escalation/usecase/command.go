@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"talkspace-api/app/configs"
+	"talkspace-api/modules/escalation/entity"
+	"talkspace-api/modules/escalation/repository"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/email/mailer"
+	"talkspace-api/utils/helper/risk"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type escalationCommandUsecase struct {
+	escalationCommandRepository repository.EscalationCommandRepositoryInterface
+	escalationQueryRepository   repository.EscalationQueryRepositoryInterface
+	detector                    risk.Detector
+}
+
+func NewEscalationCommandUsecase(ecr repository.EscalationCommandRepositoryInterface, eqr repository.EscalationQueryRepositoryInterface, detector risk.Detector) EscalationCommandUsecaseInterface {
+	return &escalationCommandUsecase{
+		escalationCommandRepository: ecr,
+		escalationQueryRepository:   eqr,
+		detector:                    detector,
+	}
+}
+
+// Assess screens a message for self-harm risk without recording anything.
+func (ecu *escalationCommandUsecase) Assess(text string) (risk.Assessment, error) {
+	return ecu.detector.Detect(text)
+}
+
+// Escalate records a flagged message for follow-up and pages the on-call
+// admin, unless they were already paged about the same user within
+// entity.NotifyCooldown at the same level or a more urgent one.
+func (ecu *escalationCommandUsecase) Escalate(escalation entity.Escalation, assessment risk.Assessment) (entity.Escalation, error) {
+	if escalation.ReferenceID == "" || escalation.UserID == "" {
+		return entity.Escalation{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	escalation.Level = assessment.Level
+	escalation.Status = constant.ESCALATION_OPEN
+	escalation.Rules = []string{}
+	for _, match := range assessment.Matches {
+		if match.Rule != "" {
+			escalation.Rules = append(escalation.Rules, match.Rule)
+		}
+	}
+
+	notified, errGet := ecu.escalationQueryRepository.HasNotifiedEscalation(escalation.UserID, time.Now().Add(-entity.NotifyCooldown), escalation.Level)
+	if errGet != nil {
+		return entity.Escalation{}, errGet
+	}
+
+	var recipients []string
+	if !notified {
+		recipients = ecu.onCallEmails()
+		escalation.Notified = len(recipients) > 0
+	}
+
+	escalation, errCreate := ecu.escalationCommandRepository.CreateEscalation(escalation)
+	if errCreate != nil {
+		return entity.Escalation{}, errCreate
+	}
+
+	if escalation.Notified {
+		mailer.SendEmailCrisisEscalation(recipients, map[string]string{
+			"ID":          escalation.ID,
+			"Source":      escalation.Source,
+			"ReferenceID": escalation.ReferenceID,
+			"UserID":      escalation.UserID,
+			"Level":       escalation.Level,
+			"Rules":       strings.Join(escalation.Rules, ", "),
+			"CreatedAt":   escalation.CreatedAt.Format(time.RFC1123),
+		})
+	}
+
+	return escalation, nil
+}
+
+// onCallEmails returns the addresses in CRISIS_ONCALL_EMAILS, or every admin
+// when none are configured so an escalation is never left unannounced.
+func (ecu *escalationCommandUsecase) onCallEmails() []string {
+	var emails []string
+
+	config, err := configs.LoadConfig()
+	if err != nil {
+		logrus.Errorf("failed to load on-call configuration: %v", err)
+	} else {
+		for _, email := range strings.Split(config.CRISIS.CRISIS_ONCALL_EMAILS, ",") {
+			if email = strings.TrimSpace(email); email != "" {
+				emails = append(emails, email)
+			}
+		}
+	}
+
+	if len(emails) > 0 {
+		return emails
+	}
+
+	emails, err = ecu.escalationQueryRepository.GetAdminEmails()
+	if err != nil {
+		logrus.Errorf("failed to read admin emails for escalation: %v", err)
+		return nil
+	}
+
+	if len(emails) == 0 {
+		logrus.Warn("no on-call admin to notify about a crisis escalation")
+	}
+
+	return emails
+}
+
+func (ecu *escalationCommandUsecase) UpdateEscalationStatus(id string, adminID string, status string, note string) (entity.Escalation, error) {
+	if status != constant.ESCALATION_ACKNOWLEDGED && status != constant.ESCALATION_RESOLVED {
+		return entity.Escalation{}, errors.New(constant.ERROR_STATUS_INVALID)
+	}
+
+	if len(note) > entity.NoteMaxLength {
+		return entity.Escalation{}, errors.New(constant.ERROR_ESCALATION_TOO_LONG)
+	}
+
+	escalation, errUpdate := ecu.escalationCommandRepository.UpdateEscalationStatus(id, status, adminID, note, time.Now())
+	if errUpdate != nil {
+		return entity.Escalation{}, errUpdate
+	}
+
+	return escalation, nil
+}
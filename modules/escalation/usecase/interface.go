@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"talkspace-api/modules/escalation/entity"
+	"talkspace-api/utils/helper/risk"
+)
+
+type EscalationCommandUsecaseInterface interface {
+	Assess(text string) (risk.Assessment, error)
+	Escalate(escalation entity.Escalation, assessment risk.Assessment) (entity.Escalation, error)
+	UpdateEscalationStatus(id string, adminID string, status string, note string) (entity.Escalation, error)
+}
+
+type EscalationQueryUsecaseInterface interface {
+	GetEscalationByID(id string) (entity.Escalation, error)
+	GetEscalations(status string, page, limit int) ([]entity.Escalation, int, error)
+}
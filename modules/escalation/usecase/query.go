@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/escalation/entity"
+	"talkspace-api/modules/escalation/repository"
+	"talkspace-api/utils/constant"
+)
+
+type escalationQueryUsecase struct {
+	escalationQueryRepository repository.EscalationQueryRepositoryInterface
+}
+
+func NewEscalationQueryUsecase(eqr repository.EscalationQueryRepositoryInterface) EscalationQueryUsecaseInterface {
+	return &escalationQueryUsecase{
+		escalationQueryRepository: eqr,
+	}
+}
+
+func (equ *escalationQueryUsecase) GetEscalationByID(id string) (entity.Escalation, error) {
+	escalation, errGet := equ.escalationQueryRepository.GetEscalationByID(id)
+	if errGet != nil {
+		return entity.Escalation{}, errGet
+	}
+
+	return escalation, nil
+}
+
+func (equ *escalationQueryUsecase) GetEscalations(status string, page, limit int) ([]entity.Escalation, int, error) {
+	switch status {
+	case "", constant.ESCALATION_OPEN, constant.ESCALATION_ACKNOWLEDGED, constant.ESCALATION_RESOLVED:
+	default:
+		return nil, 0, errors.New(constant.ERROR_STATUS_INVALID)
+	}
+
+	escalations, totalItems, errGet := equ.escalationQueryRepository.GetEscalations(status, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return escalations, totalItems, nil
+}
@@ -0,0 +1,77 @@
+package dto
+
+import "talkspace-api/modules/intake/entity"
+
+// Request
+func IntakeFormRequestToIntakeFormEntity(request IntakeFormRequest) entity.IntakeForm {
+	fields := []entity.Field{}
+	for _, field := range request.Fields {
+		fields = append(fields, entity.Field{
+			Key:      field.Key,
+			Section:  field.Section,
+			Label:    field.Label,
+			Type:     field.Type,
+			Required: field.Required,
+			Options:  field.Options,
+		})
+	}
+
+	return entity.IntakeForm{
+		Title:  request.Title,
+		Fields: fields,
+	}
+}
+
+func IntakeRequestToIntakeEntity(request IntakeRequest) entity.Intake {
+	return entity.Intake{
+		FormVersion: request.FormVersion,
+		Answers:     request.Answers,
+	}
+}
+
+// Response
+func IntakeFormEntityToIntakeFormResponse(response entity.IntakeForm) IntakeFormResponse {
+	fieldResponses := []IntakeFieldResponse{}
+	for _, field := range response.Fields {
+		options := field.Options
+		if options == nil {
+			options = []string{}
+		}
+
+		fieldResponses = append(fieldResponses, IntakeFieldResponse{
+			Key:      field.Key,
+			Section:  field.Section,
+			Label:    field.Label,
+			Type:     field.Type,
+			Required: field.Required,
+			Options:  options,
+		})
+	}
+
+	return IntakeFormResponse{
+		Version: response.Version,
+		Title:   response.Title,
+		Fields:  fieldResponses,
+	}
+}
+
+func IntakeEntityToIntakeResponse(response entity.Intake, form entity.IntakeForm) IntakeResponse {
+	answerResponses := []IntakeAnswerResponse{}
+	for _, answer := range form.Answered(response) {
+		answerResponses = append(answerResponses, IntakeAnswerResponse{
+			Key:     answer.Key,
+			Section: answer.Section,
+			Label:   answer.Label,
+			Value:   answer.Value,
+		})
+	}
+
+	return IntakeResponse{
+		ID:          response.ID,
+		FormVersion: response.FormVersion,
+		FormTitle:   form.Title,
+		Answers:     answerResponses,
+		ConsentedAt: response.ConsentedAt,
+		CreatedAt:   response.CreatedAt,
+	}
+}
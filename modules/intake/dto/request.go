@@ -0,0 +1,22 @@
+package dto
+
+type (
+	IntakeFieldRequest struct {
+		Key      string   `json:"key" form:"key"`
+		Section  string   `json:"section" form:"section"`
+		Label    string   `json:"label" form:"label"`
+		Type     string   `json:"type" form:"type"`
+		Required bool     `json:"required" form:"required"`
+		Options  []string `json:"options" form:"options"`
+	}
+
+	IntakeFormRequest struct {
+		Title  string               `json:"title" form:"title"`
+		Fields []IntakeFieldRequest `json:"fields" form:"fields"`
+	}
+
+	IntakeRequest struct {
+		FormVersion int                    `json:"form_version" form:"form_version"`
+		Answers     map[string]interface{} `json:"answers" form:"answers"`
+	}
+)
@@ -0,0 +1,36 @@
+package dto
+
+import "time"
+
+type (
+	IntakeFieldResponse struct {
+		Key      string   `json:"key"`
+		Section  string   `json:"section"`
+		Label    string   `json:"label"`
+		Type     string   `json:"type"`
+		Required bool     `json:"required"`
+		Options  []string `json:"options"`
+	}
+
+	IntakeFormResponse struct {
+		Version int                   `json:"version"`
+		Title   string                `json:"title"`
+		Fields  []IntakeFieldResponse `json:"fields"`
+	}
+
+	IntakeAnswerResponse struct {
+		Key     string      `json:"key"`
+		Section string      `json:"section"`
+		Label   string      `json:"label"`
+		Value   interface{} `json:"value"`
+	}
+
+	IntakeResponse struct {
+		ID          string                 `json:"id"`
+		FormVersion int                    `json:"form_version"`
+		FormTitle   string                 `json:"form_title"`
+		Answers     []IntakeAnswerResponse `json:"answers"`
+		ConsentedAt time.Time              `json:"consented_at"`
+		CreatedAt   time.Time              `json:"created_at"`
+	}
+)
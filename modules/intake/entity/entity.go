@@ -0,0 +1,67 @@
+package entity
+
+import "time"
+
+const (
+	// IntakeValidity is how long after it was answered an intake can still
+	// be attached to a new booking.
+	IntakeValidity = 30 * 24 * time.Hour
+	// AnswerMaxLength is the longest text answer accepted, in bytes.
+	AnswerMaxLength = 4000
+	FieldMaxCount   = 50
+)
+
+// Field types
+const (
+	FieldText        = "text"
+	FieldChoice      = "choice"
+	FieldMultiChoice = "multi_choice"
+	FieldBoolean     = "boolean"
+)
+
+// Sections a field can belong to. Required boolean fields of the consent
+// section have to be answered with true.
+const (
+	SectionConcerns = "concerns"
+	SectionHistory  = "history"
+	SectionGoals    = "goals"
+	SectionConsent  = "consent"
+)
+
+type Field struct {
+	Key      string   `json:"key"`
+	Section  string   `json:"section"`
+	Label    string   `json:"label"`
+	Type     string   `json:"type"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+}
+
+type IntakeForm struct {
+	ID        string
+	Version   int
+	Title     string
+	Fields    []Field
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// Intake holds the answers by field key: a string for text and choice
+// fields, a list of strings for multi choice fields and a bool for boolean
+// fields.
+type Intake struct {
+	ID          string
+	UserID      string
+	FormVersion int
+	Answers     map[string]interface{}
+	ConsentedAt time.Time
+	CreatedAt   time.Time
+}
+
+// Answer is an answer next to the question it belongs to.
+type Answer struct {
+	Key     string
+	Section string
+	Label   string
+	Value   interface{}
+}
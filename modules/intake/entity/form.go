@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"talkspace-api/utils/constant"
+)
+
+var fieldKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// DefaultIntakeForm is served until an admin saves the first version of
+// the form.
+var DefaultIntakeForm = IntakeForm{
+	Version: 0,
+	Title:   "Before your consultation",
+	Fields: []Field{
+		{Key: "main_concern", Section: SectionConcerns, Label: "What would you like to talk about with your doctor?", Type: FieldText, Required: true},
+		{Key: "concern_areas", Section: SectionConcerns, Label: "Which of these are you struggling with?", Type: FieldMultiChoice, Options: []string{"anxiety", "low mood", "stress", "sleep", "relationships", "grief", "trauma", "other"}},
+		{Key: "concern_duration", Section: SectionConcerns, Label: "How long has this been going on?", Type: FieldChoice, Required: true, Options: []string{"less than a month", "1 to 6 months", "6 to 12 months", "more than a year"}},
+		{Key: "previous_help", Section: SectionHistory, Label: "Have you seen a psychologist or psychiatrist before?", Type: FieldBoolean, Required: true},
+		{Key: "medication", Section: SectionHistory, Label: "Are you taking any medication? Please list it.", Type: FieldText},
+		{Key: "goals", Section: SectionGoals, Label: "What would you like to get out of your sessions?", Type: FieldText, Required: true},
+		{Key: "share_with_doctor", Section: SectionConsent, Label: "I agree that the doctor of my consultation may read these answers.", Type: FieldBoolean, Required: true},
+	},
+}
+
+// Validate checks a form definition an admin wants to save. Every field
+// needs a unique key, a known section and type, and choice fields need
+// options. The form has to ask for consent with a required boolean field.
+func (f IntakeForm) Validate() error {
+	if f.Title == "" || len(f.Fields) == 0 || len(f.Fields) > FieldMaxCount {
+		return errors.New(constant.ERROR_INTAKE_FORM)
+	}
+
+	keys := map[string]bool{}
+	consent := false
+	for _, field := range f.Fields {
+		if !fieldKey.MatchString(field.Key) || keys[field.Key] || field.Label == "" {
+			return errors.New(constant.ERROR_INTAKE_FORM)
+		}
+		keys[field.Key] = true
+
+		switch field.Section {
+		case SectionConcerns, SectionHistory, SectionGoals, SectionConsent:
+		default:
+			return errors.New(constant.ERROR_INTAKE_FORM)
+		}
+
+		switch field.Type {
+		case FieldText, FieldBoolean:
+			if len(field.Options) > 0 {
+				return errors.New(constant.ERROR_INTAKE_FORM)
+			}
+		case FieldChoice, FieldMultiChoice:
+			if len(field.Options) == 0 {
+				return errors.New(constant.ERROR_INTAKE_FORM)
+			}
+		default:
+			return errors.New(constant.ERROR_INTAKE_FORM)
+		}
+
+		if field.Section == SectionConsent && field.Type == FieldBoolean && field.Required {
+			consent = true
+		}
+	}
+
+	if !consent {
+		return errors.New(constant.ERROR_INTAKE_FORM)
+	}
+
+	return nil
+}
+
+// ValidateAnswers checks the answers of a user against the form and drops
+// keys the form does not ask for.
+func (f IntakeForm) ValidateAnswers(answers map[string]interface{}) (map[string]interface{}, error) {
+	valid := map[string]interface{}{}
+	for _, field := range f.Fields {
+		value, ok := answers[field.Key]
+		if !ok || value == nil || value == "" {
+			if field.Required {
+				return nil, errors.New(constant.ERROR_INTAKE_ANSWERS)
+			}
+			continue
+		}
+
+		switch field.Type {
+		case FieldText:
+			text, ok := value.(string)
+			if !ok {
+				return nil, errors.New(constant.ERROR_INTAKE_ANSWERS)
+			}
+			if len(text) > AnswerMaxLength {
+				return nil, errors.New(constant.ERROR_INTAKE_TOO_LONG)
+			}
+		case FieldChoice:
+			choice, ok := value.(string)
+			if !ok || !contains(field.Options, choice) {
+				return nil, errors.New(constant.ERROR_INTAKE_ANSWERS)
+			}
+		case FieldMultiChoice:
+			list, ok := value.([]interface{})
+			if !ok || (field.Required && len(list) == 0) {
+				return nil, errors.New(constant.ERROR_INTAKE_ANSWERS)
+			}
+
+			choices := []string{}
+			for _, item := range list {
+				choice, ok := item.(string)
+				if !ok || !contains(field.Options, choice) {
+					return nil, errors.New(constant.ERROR_INTAKE_ANSWERS)
+				}
+				if !contains(choices, choice) {
+					choices = append(choices, choice)
+				}
+			}
+			value = choices
+		case FieldBoolean:
+			answer, ok := value.(bool)
+			if !ok {
+				return nil, errors.New(constant.ERROR_INTAKE_ANSWERS)
+			}
+			if field.Section == SectionConsent && field.Required && !answer {
+				return nil, errors.New(constant.ERROR_INTAKE_CONSENT)
+			}
+		}
+
+		valid[field.Key] = value
+	}
+
+	return valid, nil
+}
+
+// Answered pairs the answers of an intake with the questions of the form in
+// the order the form asks them.
+func (f IntakeForm) Answered(intake Intake) []Answer {
+	answers := []Answer{}
+	for _, field := range f.Fields {
+		value, ok := intake.Answers[field.Key]
+		if !ok {
+			continue
+		}
+
+		answers = append(answers, Answer{
+			Key:     field.Key,
+			Section: field.Section,
+			Label:   field.Label,
+			Value:   value,
+		})
+	}
+	return answers
+}
+
+func contains(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"encoding/json"
+	"talkspace-api/modules/intake/model"
+)
+
+func IntakeFormEntityToIntakeFormModel(intakeFormEntity IntakeForm) model.IntakeForm {
+	fields, _ := json.Marshal(intakeFormEntity.Fields)
+
+	return model.IntakeForm{
+		ID:        intakeFormEntity.ID,
+		Version:   intakeFormEntity.Version,
+		Title:     intakeFormEntity.Title,
+		Fields:    string(fields),
+		CreatedBy: intakeFormEntity.CreatedBy,
+		CreatedAt: intakeFormEntity.CreatedAt,
+	}
+}
+
+func IntakeFormModelToIntakeFormEntity(intakeFormModel model.IntakeForm) IntakeForm {
+	fields := []Field{}
+	json.Unmarshal([]byte(intakeFormModel.Fields), &fields)
+
+	return IntakeForm{
+		ID:        intakeFormModel.ID,
+		Version:   intakeFormModel.Version,
+		Title:     intakeFormModel.Title,
+		Fields:    fields,
+		CreatedBy: intakeFormModel.CreatedBy,
+		CreatedAt: intakeFormModel.CreatedAt,
+	}
+}
+
+// IntakeEntityToIntakeModel leaves the answers as plain JSON, the
+// repository encrypts them before storing.
+func IntakeEntityToIntakeModel(intakeEntity Intake) model.Intake {
+	answers, _ := json.Marshal(intakeEntity.Answers)
+
+	return model.Intake{
+		ID:          intakeEntity.ID,
+		UserID:      intakeEntity.UserID,
+		FormVersion: intakeEntity.FormVersion,
+		Answers:     string(answers),
+		ConsentedAt: intakeEntity.ConsentedAt,
+		CreatedAt:   intakeEntity.CreatedAt,
+	}
+}
+
+func IntakeModelToIntakeEntity(intakeModel model.Intake) Intake {
+	answers := map[string]interface{}{}
+	json.Unmarshal([]byte(intakeModel.Answers), &answers)
+
+	return Intake{
+		ID:          intakeModel.ID,
+		UserID:      intakeModel.UserID,
+		FormVersion: intakeModel.FormVersion,
+		Answers:     answers,
+		ConsentedAt: intakeModel.ConsentedAt,
+		CreatedAt:   intakeModel.CreatedAt,
+	}
+}
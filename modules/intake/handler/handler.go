@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/intake/dto"
+	"talkspace-api/modules/intake/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type intakeHandler struct {
+	intakeCommandUsecase usecase.IntakeCommandUsecaseInterface
+	intakeQueryUsecase   usecase.IntakeQueryUsecaseInterface
+}
+
+func NewIntakeHandler(icu usecase.IntakeCommandUsecaseInterface, iqu usecase.IntakeQueryUsecaseInterface) *intakeHandler {
+	return &intakeHandler{
+		intakeCommandUsecase: icu,
+		intakeQueryUsecase:   iqu,
+	}
+}
+
+// Query
+func (ih *intakeHandler) GetIntakeForm(c echo.Context) error {
+	form, errGet := ih.intakeQueryUsecase.GetIntakeForm()
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	formResponse := dto.IntakeFormEntityToIntakeFormResponse(form)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, formResponse))
+}
+
+func (ih *intakeHandler) GetLatestIntake(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	intake, form, errGet := ih.intakeQueryUsecase.GetLatestIntake(userID)
+	if errGet != nil {
+		if errGet.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGet.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	intakeResponse := dto.IntakeEntityToIntakeResponse(intake, form)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, intakeResponse))
+}
+
+// GetIntakeByConsultationID shows the doctor of a consultation what the user
+// answered before booking it.
+func (ih *intakeHandler) GetIntakeByConsultationID(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	if consultationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	requesterID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	intake, form, errGet := ih.intakeQueryUsecase.GetIntakeByConsultationID(consultationIDParam, requesterID, role)
+	if errGet != nil {
+		switch errGet.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGet.Error()))
+		case constant.ERROR_ROLE_ACCESS:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(errGet.Error()))
+		default:
+			return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+		}
+	}
+
+	intakeResponse := dto.IntakeEntityToIntakeResponse(intake, form)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, intakeResponse))
+}
+
+// Command
+func (ih *intakeHandler) UpdateIntakeForm(c echo.Context) error {
+	adminID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	formRequest := dto.IntakeFormRequest{}
+
+	errBind := c.Bind(&formRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	formEntity := dto.IntakeFormRequestToIntakeFormEntity(formRequest)
+
+	form, errUpdate := ih.intakeCommandUsecase.UpdateIntakeForm(formEntity, adminID)
+	if errUpdate != nil {
+		if errUpdate.Error() == constant.ERROR_INTAKE_FORM {
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errUpdate.Error()))
+		}
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errUpdate.Error()))
+	}
+
+	formResponse := dto.IntakeFormEntityToIntakeFormResponse(form)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, formResponse))
+}
+
+func (ih *intakeHandler) SubmitIntake(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	intakeRequest := dto.IntakeRequest{}
+
+	errBind := c.Bind(&intakeRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	intakeEntity := dto.IntakeRequestToIntakeEntity(intakeRequest)
+	intakeEntity.UserID = userID
+
+	intake, errCreate := ih.intakeCommandUsecase.SubmitIntake(intakeEntity)
+	if errCreate != nil {
+		switch errCreate.Error() {
+		case constant.ERROR_INTAKE_OUTDATED:
+			return c.JSON(http.StatusConflict, responses.ErrorResponse(errCreate.Error()))
+		case constant.ERROR_INTAKE_ANSWERS, constant.ERROR_INTAKE_TOO_LONG, constant.ERROR_INTAKE_CONSENT:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCreate.Error()))
+		default:
+			return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errCreate.Error()))
+		}
+	}
+
+	form, errGet := ih.intakeQueryUsecase.GetIntakeForm()
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	intakeResponse := dto.IntakeEntityToIntakeResponse(intake, form)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, intakeResponse))
+}
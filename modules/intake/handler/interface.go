@@ -0,0 +1,14 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type IntakeHandlerInterface interface {
+	// Query
+	GetIntakeForm(c echo.Context) error
+	GetLatestIntake(c echo.Context) error
+	GetIntakeByConsultationID(c echo.Context) error
+
+	// Command
+	UpdateIntakeForm(c echo.Context) error
+	SubmitIntake(c echo.Context) error
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (f *IntakeForm) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	f.ID = UUID.String()
+	return nil
+}
+
+func (i *Intake) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	i.ID = UUID.String()
+	return nil
+}
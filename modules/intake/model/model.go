@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// IntakeForm is one version of the intake questionnaire. Editing the form
+// adds a version, so every intake keeps the questions it answered.
+type IntakeForm struct {
+	ID        string `gorm:"primarykey"`
+	Version   int    `gorm:"uniqueIndex;not null"`
+	Title     string `gorm:"not null"`
+	Fields    string `gorm:"type:jsonb;not null;default:'[]'"`
+	CreatedBy string `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// Intake is what a user answered before booking. The answers are stored
+// encrypted.
+type Intake struct {
+	ID          string `gorm:"primarykey"`
+	UserID      string `gorm:"index;not null"`
+	FormVersion int    `gorm:"not null"`
+	Answers     string `gorm:"type:text;not null"`
+	ConsentedAt time.Time
+	CreatedAt   time.Time
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"talkspace-api/modules/intake/model"
+	"talkspace-api/utils/helper/encryption"
+)
+
+// The answers are bound to the user who gave them, so a ciphertext copied
+// into the intake of someone else fails to decrypt.
+func answersContext(userID string) string {
+	return userID + ":intake"
+}
+
+func sealIntake(cipher encryption.Cipher, intake *model.Intake) error {
+	ciphertext, err := cipher.Encrypt(intake.Answers, answersContext(intake.UserID))
+	if err != nil {
+		return err
+	}
+	intake.Answers = ciphertext
+	return nil
+}
+
+func openIntake(cipher encryption.Cipher, intake *model.Intake) error {
+	plaintext, err := cipher.Decrypt(intake.Answers, answersContext(intake.UserID))
+	if err != nil {
+		return err
+	}
+	intake.Answers = plaintext
+	return nil
+}
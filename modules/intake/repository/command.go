@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"talkspace-api/modules/intake/entity"
+	"talkspace-api/modules/intake/model"
+	"talkspace-api/utils/helper/encryption"
+
+	"gorm.io/gorm"
+)
+
+type intakeCommandRepository struct {
+	db     *gorm.DB
+	cipher encryption.Cipher
+}
+
+func NewIntakeCommandRepository(db *gorm.DB, cipher encryption.Cipher) IntakeCommandRepositoryInterface {
+	return &intakeCommandRepository{
+		db:     db,
+		cipher: cipher,
+	}
+}
+
+// CreateIntakeForm saves the form as the version after the latest one.
+// Two admins saving at once get one version each, the table lock keeps
+// them from taking the same number.
+func (icr *intakeCommandRepository) CreateIntakeForm(form entity.IntakeForm) (entity.IntakeForm, error) {
+	formModel := entity.IntakeFormEntityToIntakeFormModel(form)
+
+	errTx := icr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Exec("LOCK TABLE intake_forms IN SHARE ROW EXCLUSIVE MODE")
+		if result.Error != nil {
+			return result.Error
+		}
+
+		var latest int
+		result = tx.Model(&model.IntakeForm{}).Select("COALESCE(MAX(version), 0)").Scan(&latest)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		formModel.Version = latest + 1
+
+		return tx.Create(&formModel).Error
+	})
+	if errTx != nil {
+		return entity.IntakeForm{}, errTx
+	}
+
+	formEntity := entity.IntakeFormModelToIntakeFormEntity(formModel)
+
+	return formEntity, nil
+}
+
+func (icr *intakeCommandRepository) CreateIntake(intake entity.Intake) (entity.Intake, error) {
+	intakeModel := entity.IntakeEntityToIntakeModel(intake)
+
+	errEncrypt := sealIntake(icr.cipher, &intakeModel)
+	if errEncrypt != nil {
+		return entity.Intake{}, errEncrypt
+	}
+
+	result := icr.db.Create(&intakeModel)
+	if result.Error != nil {
+		return entity.Intake{}, result.Error
+	}
+
+	intake.ID = intakeModel.ID
+	intake.CreatedAt = intakeModel.CreatedAt
+
+	return intake, nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "talkspace-api/modules/intake/entity"
+
+type IntakeCommandRepositoryInterface interface {
+	CreateIntakeForm(form entity.IntakeForm) (entity.IntakeForm, error)
+	CreateIntake(intake entity.Intake) (entity.Intake, error)
+}
+
+type IntakeQueryRepositoryInterface interface {
+	GetLatestIntakeForm() (entity.IntakeForm, error)
+	GetIntakeFormByVersion(version int) (entity.IntakeForm, error)
+	GetIntakeByID(id string) (entity.Intake, error)
+	GetLatestIntakeByUserID(userID string) (entity.Intake, error)
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/intake/entity"
+	"talkspace-api/modules/intake/model"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/encryption"
+
+	"gorm.io/gorm"
+)
+
+type intakeQueryRepository struct {
+	db     *gorm.DB
+	cipher encryption.Cipher
+}
+
+func NewIntakeQueryRepository(db *gorm.DB, cipher encryption.Cipher) IntakeQueryRepositoryInterface {
+	return &intakeQueryRepository{
+		db:     db,
+		cipher: cipher,
+	}
+}
+
+// GetLatestIntakeForm returns the form users answer now, ERROR_DATA_NOTFOUND
+// while no version was saved yet.
+func (iqr *intakeQueryRepository) GetLatestIntakeForm() (entity.IntakeForm, error) {
+	formModel := model.IntakeForm{}
+	result := iqr.db.Order("version DESC").First(&formModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.IntakeForm{}, errors.New(constant.ERROR_DATA_NOTFOUND)
+		}
+		return entity.IntakeForm{}, result.Error
+	}
+
+	formEntity := entity.IntakeFormModelToIntakeFormEntity(formModel)
+
+	return formEntity, nil
+}
+
+func (iqr *intakeQueryRepository) GetIntakeFormByVersion(version int) (entity.IntakeForm, error) {
+	formModel := model.IntakeForm{}
+	result := iqr.db.Where("version = ?", version).First(&formModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.IntakeForm{}, errors.New(constant.ERROR_DATA_NOTFOUND)
+		}
+		return entity.IntakeForm{}, result.Error
+	}
+
+	formEntity := entity.IntakeFormModelToIntakeFormEntity(formModel)
+
+	return formEntity, nil
+}
+
+func (iqr *intakeQueryRepository) GetIntakeByID(id string) (entity.Intake, error) {
+	return iqr.getIntake(iqr.db.Where("id = ?", id))
+}
+
+func (iqr *intakeQueryRepository) GetLatestIntakeByUserID(userID string) (entity.Intake, error) {
+	return iqr.getIntake(iqr.db.Where("user_id = ?", userID).Order("created_at DESC"))
+}
+
+func (iqr *intakeQueryRepository) getIntake(query *gorm.DB) (entity.Intake, error) {
+	intakeModel := model.Intake{}
+	result := query.First(&intakeModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Intake{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Intake{}, result.Error
+	}
+
+	errDecrypt := openIntake(iqr.cipher, &intakeModel)
+	if errDecrypt != nil {
+		return entity.Intake{}, errDecrypt
+	}
+
+	intakeEntity := entity.IntakeModelToIntakeEntity(intakeModel)
+
+	return intakeEntity, nil
+}
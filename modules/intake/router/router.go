@@ -0,0 +1,33 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/modules/intake/handler"
+	"talkspace-api/modules/intake/repository"
+	"talkspace-api/modules/intake/usecase"
+	"talkspace-api/utils/helper/encryption"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func IntakeRoutes(e *echo.Group, db *gorm.DB) {
+	cipher := encryption.NewCipher()
+
+	intakeQueryRepository := repository.NewIntakeQueryRepository(db, cipher)
+	intakeCommandRepository := repository.NewIntakeCommandRepository(db, cipher)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+
+	intakeQueryUsecase := usecase.NewIntakeQueryUsecase(intakeQueryRepository, consultationQueryRepository)
+	intakeCommandUsecase := usecase.NewIntakeCommandUsecase(intakeCommandRepository, intakeQueryRepository)
+
+	intakeHandler := handler.NewIntakeHandler(intakeCommandUsecase, intakeQueryUsecase)
+
+	e.GET("/form", intakeHandler.GetIntakeForm, middlewares.JWTMiddleware(false))
+	e.PUT("/form", intakeHandler.UpdateIntakeForm, middlewares.JWTMiddleware(false))
+
+	e.POST("", intakeHandler.SubmitIntake, middlewares.JWTMiddleware(false))
+	e.GET("/latest", intakeHandler.GetLatestIntake, middlewares.JWTMiddleware(false))
+	e.GET("/consultations/:consultation_id", intakeHandler.GetIntakeByConsultationID, middlewares.JWTMiddleware(false))
+}
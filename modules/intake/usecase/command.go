@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/intake/entity"
+	"talkspace-api/modules/intake/repository"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type intakeCommandUsecase struct {
+	intakeCommandRepository repository.IntakeCommandRepositoryInterface
+	intakeQueryRepository   repository.IntakeQueryRepositoryInterface
+}
+
+func NewIntakeCommandUsecase(icr repository.IntakeCommandRepositoryInterface, iqr repository.IntakeQueryRepositoryInterface) IntakeCommandUsecaseInterface {
+	return &intakeCommandUsecase{
+		intakeCommandRepository: icr,
+		intakeQueryRepository:   iqr,
+	}
+}
+
+// UpdateIntakeForm saves a new version of the form. Intakes answered
+// before keep pointing at the version they answered.
+func (icu *intakeCommandUsecase) UpdateIntakeForm(form entity.IntakeForm, adminID string) (entity.IntakeForm, error) {
+	errValidate := form.Validate()
+	if errValidate != nil {
+		return entity.IntakeForm{}, errValidate
+	}
+
+	form.CreatedBy = adminID
+
+	formEntity, errCreate := icu.intakeCommandRepository.CreateIntakeForm(form)
+	if errCreate != nil {
+		return entity.IntakeForm{}, errCreate
+	}
+
+	return formEntity, nil
+}
+
+// SubmitIntake stores the answers of a user to the current form. Answers
+// to a version that was replaced in the meantime are rejected so the user
+// sees the new questions.
+func (icu *intakeCommandUsecase) SubmitIntake(intake entity.Intake) (entity.Intake, error) {
+	form, errGet := currentForm(icu.intakeQueryRepository)
+	if errGet != nil {
+		return entity.Intake{}, errGet
+	}
+
+	if intake.FormVersion != form.Version {
+		return entity.Intake{}, errors.New(constant.ERROR_INTAKE_OUTDATED)
+	}
+
+	answers, errValidate := form.ValidateAnswers(intake.Answers)
+	if errValidate != nil {
+		return entity.Intake{}, errValidate
+	}
+
+	intake.Answers = answers
+	intake.ConsentedAt = time.Now()
+
+	intakeEntity, errCreate := icu.intakeCommandRepository.CreateIntake(intake)
+	if errCreate != nil {
+		return entity.Intake{}, errCreate
+	}
+
+	return intakeEntity, nil
+}
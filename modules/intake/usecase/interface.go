@@ -0,0 +1,14 @@
+package usecase
+
+import "talkspace-api/modules/intake/entity"
+
+type IntakeCommandUsecaseInterface interface {
+	UpdateIntakeForm(form entity.IntakeForm, adminID string) (entity.IntakeForm, error)
+	SubmitIntake(intake entity.Intake) (entity.Intake, error)
+}
+
+type IntakeQueryUsecaseInterface interface {
+	GetIntakeForm() (entity.IntakeForm, error)
+	GetLatestIntake(userID string) (entity.Intake, entity.IntakeForm, error)
+	GetIntakeByConsultationID(consultationID string, requesterID string, role string) (entity.Intake, entity.IntakeForm, error)
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"errors"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/modules/intake/entity"
+	"talkspace-api/modules/intake/repository"
+	"talkspace-api/utils/constant"
+)
+
+type intakeQueryUsecase struct {
+	intakeQueryRepository       repository.IntakeQueryRepositoryInterface
+	consultationQueryRepository cr.ConsultationQueryRepositoryInterface
+}
+
+func NewIntakeQueryUsecase(iqr repository.IntakeQueryRepositoryInterface, cqr cr.ConsultationQueryRepositoryInterface) IntakeQueryUsecaseInterface {
+	return &intakeQueryUsecase{
+		intakeQueryRepository:       iqr,
+		consultationQueryRepository: cqr,
+	}
+}
+
+func (iqu *intakeQueryUsecase) GetIntakeForm() (entity.IntakeForm, error) {
+	return currentForm(iqu.intakeQueryRepository)
+}
+
+func (iqu *intakeQueryUsecase) GetLatestIntake(userID string) (entity.Intake, entity.IntakeForm, error) {
+	intake, errGet := iqu.intakeQueryRepository.GetLatestIntakeByUserID(userID)
+	if errGet != nil {
+		return entity.Intake{}, entity.IntakeForm{}, errGet
+	}
+
+	form, errGetForm := formByVersion(iqu.intakeQueryRepository, intake.FormVersion)
+	if errGetForm != nil {
+		return entity.Intake{}, entity.IntakeForm{}, errGetForm
+	}
+
+	return intake, form, nil
+}
+
+// GetIntakeByConsultationID returns the intake attached to a consultation
+// to its doctor and its user, with the questions it answered.
+func (iqu *intakeQueryUsecase) GetIntakeByConsultationID(consultationID string, requesterID string, role string) (entity.Intake, entity.IntakeForm, error) {
+	consultation, errGet := iqu.consultationQueryRepository.GetConsultationByID(consultationID)
+	if errGet != nil {
+		return entity.Intake{}, entity.IntakeForm{}, errGet
+	}
+
+	switch role {
+	case constant.DOCTOR:
+		if consultation.DoctorID != requesterID {
+			return entity.Intake{}, entity.IntakeForm{}, errors.New(constant.ERROR_ROLE_ACCESS)
+		}
+	case constant.USER:
+		if consultation.UserID != requesterID {
+			return entity.Intake{}, entity.IntakeForm{}, errors.New(constant.ERROR_ROLE_ACCESS)
+		}
+	default:
+		return entity.Intake{}, entity.IntakeForm{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	// consultations booked before intakes existed have none
+	if consultation.IntakeID == "" {
+		return entity.Intake{}, entity.IntakeForm{}, errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	intake, errGetIntake := iqu.intakeQueryRepository.GetIntakeByID(consultation.IntakeID)
+	if errGetIntake != nil {
+		return entity.Intake{}, entity.IntakeForm{}, errGetIntake
+	}
+
+	form, errGetForm := formByVersion(iqu.intakeQueryRepository, intake.FormVersion)
+	if errGetForm != nil {
+		return entity.Intake{}, entity.IntakeForm{}, errGetForm
+	}
+
+	return intake, form, nil
+}
+
+// currentForm returns the latest saved form, the default one while none
+// was saved yet.
+func currentForm(iqr repository.IntakeQueryRepositoryInterface) (entity.IntakeForm, error) {
+	form, errGet := iqr.GetLatestIntakeForm()
+	if errGet != nil {
+		if errGet.Error() == constant.ERROR_DATA_NOTFOUND {
+			return entity.DefaultIntakeForm, nil
+		}
+		return entity.IntakeForm{}, errGet
+	}
+
+	return form, nil
+}
+
+func formByVersion(iqr repository.IntakeQueryRepositoryInterface, version int) (entity.IntakeForm, error) {
+	if version == entity.DefaultIntakeForm.Version {
+		return entity.DefaultIntakeForm, nil
+	}
+
+	return iqr.GetIntakeFormByVersion(version)
+}
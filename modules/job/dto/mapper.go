@@ -0,0 +1,27 @@
+package dto
+
+import "talkspace-api/modules/job/entity"
+
+// Response
+func JobRunEntityToJobRunResponse(response entity.JobRun) JobRunResponse {
+	return JobRunResponse{
+		ID:          response.ID,
+		Job:         response.Job,
+		Instance:    response.Instance,
+		Status:      response.Status,
+		Message:     response.Message,
+		ScheduledAt: response.ScheduledAt,
+		StartedAt:   response.StartedAt,
+		FinishedAt:  response.FinishedAt,
+		DurationMs:  response.FinishedAt.Sub(response.StartedAt).Milliseconds(),
+	}
+}
+
+func ListJobRunEntityToJobRunResponse(response []entity.JobRun) []JobRunResponse {
+	jobRunResponses := []JobRunResponse{}
+	for _, jobRun := range response {
+		jobRunResponse := JobRunEntityToJobRunResponse(jobRun)
+		jobRunResponses = append(jobRunResponses, jobRunResponse)
+	}
+	return jobRunResponses
+}
@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+type (
+	JobRunResponse struct {
+		ID          string    `json:"id"`
+		Job         string    `json:"job"`
+		Instance    string    `json:"instance"`
+		Status      string    `json:"status"`
+		Message     string    `json:"message"`
+		ScheduledAt time.Time `json:"scheduled_at"`
+		StartedAt   time.Time `json:"started_at"`
+		FinishedAt  time.Time `json:"finished_at"`
+		DurationMs  int64     `json:"duration_ms"`
+	}
+)
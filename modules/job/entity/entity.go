@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+type JobRun struct {
+	ID          string
+	Job         string
+	Instance    string
+	Status      string
+	Message     string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	CreatedAt   time.Time
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "talkspace-api/modules/job/model"
+
+func JobRunEntityToJobRunModel(jobRunEntity JobRun) model.JobRun {
+	jobRunModel := model.JobRun{
+		ID:          jobRunEntity.ID,
+		Job:         jobRunEntity.Job,
+		Instance:    jobRunEntity.Instance,
+		Status:      jobRunEntity.Status,
+		Message:     jobRunEntity.Message,
+		ScheduledAt: jobRunEntity.ScheduledAt,
+		StartedAt:   jobRunEntity.StartedAt,
+		FinishedAt:  jobRunEntity.FinishedAt,
+		CreatedAt:   jobRunEntity.CreatedAt,
+	}
+	return jobRunModel
+}
+
+func JobRunModelToJobRunEntity(jobRunModel model.JobRun) JobRun {
+	jobRunEntity := JobRun{
+		ID:          jobRunModel.ID,
+		Job:         jobRunModel.Job,
+		Instance:    jobRunModel.Instance,
+		Status:      jobRunModel.Status,
+		Message:     jobRunModel.Message,
+		ScheduledAt: jobRunModel.ScheduledAt,
+		StartedAt:   jobRunModel.StartedAt,
+		FinishedAt:  jobRunModel.FinishedAt,
+		CreatedAt:   jobRunModel.CreatedAt,
+	}
+	return jobRunEntity
+}
+
+func ListJobRunModelToJobRunEntity(jobRunModels []model.JobRun) []JobRun {
+	listJobRunEntity := []JobRun{}
+	for _, jobRun := range jobRunModels {
+		jobRunEntity := JobRunModelToJobRunEntity(jobRun)
+		listJobRunEntity = append(listJobRunEntity, jobRunEntity)
+	}
+	return listJobRunEntity
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/job/dto"
+	"talkspace-api/modules/job/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type jobHandler struct {
+	jobCommandUsecase usecase.JobCommandUsecaseInterface
+	jobQueryUsecase   usecase.JobQueryUsecaseInterface
+}
+
+func NewJobHandler(jcu usecase.JobCommandUsecaseInterface, jqu usecase.JobQueryUsecaseInterface) *jobHandler {
+	return &jobHandler{
+		jobCommandUsecase: jcu,
+		jobQueryUsecase:   jqu,
+	}
+}
+
+// Query
+func (jh *jobHandler) GetJobRuns(c echo.Context) error {
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	jobRuns, totalItems, errGet := jh.jobQueryUsecase.GetJobRuns(c.QueryParam("job"), c.QueryParam("status"), page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(jobRuns) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	jobRunResponses := dto.ListJobRunEntityToJobRunResponse(jobRuns)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		jobRunResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
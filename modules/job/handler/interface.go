@@ -0,0 +1,8 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type JobHandlerInterface interface {
+	// Query
+	GetJobRuns(c echo.Context) error
+}
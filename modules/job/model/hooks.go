@@ -0,0 +1,12 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (j *JobRun) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	j.ID = UUID.String()
+	return nil
+}
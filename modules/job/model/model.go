@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type JobRun struct {
+	ID          string `gorm:"primarykey"`
+	Job         string `gorm:"index;not null"`
+	Instance    string `gorm:"not null"`
+	Status      string `gorm:"type:varchar(20);not null"`
+	Message     string
+	ScheduledAt time.Time
+	StartedAt   time.Time `gorm:"index"`
+	FinishedAt  time.Time
+	CreatedAt   time.Time
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"talkspace-api/modules/job/entity"
+	"talkspace-api/modules/job/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type jobCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewJobCommandRepository(db *gorm.DB) JobCommandRepositoryInterface {
+	return &jobCommandRepository{
+		db: db,
+	}
+}
+
+func (jcr *jobCommandRepository) CreateJobRun(jobRun entity.JobRun) (entity.JobRun, error) {
+	jobRunModel := entity.JobRunEntityToJobRunModel(jobRun)
+
+	result := jcr.db.Create(&jobRunModel)
+	if result.Error != nil {
+		return entity.JobRun{}, result.Error
+	}
+
+	jobRunEntity := entity.JobRunModelToJobRunEntity(jobRunModel)
+
+	return jobRunEntity, nil
+}
+
+func (jcr *jobCommandRepository) DeleteJobRunsBefore(before time.Time) (int, error) {
+	result := jcr.db.Where("started_at < ?", before).Delete(&model.JobRun{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return int(result.RowsAffected), nil
+}
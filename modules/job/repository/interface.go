@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"talkspace-api/modules/job/entity"
+	"time"
+)
+
+type JobCommandRepositoryInterface interface {
+	CreateJobRun(jobRun entity.JobRun) (entity.JobRun, error)
+	DeleteJobRunsBefore(before time.Time) (int, error)
+}
+
+type JobQueryRepositoryInterface interface {
+	GetJobRuns(job string, status string, page, limit int) ([]entity.JobRun, int, error)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"talkspace-api/modules/job/entity"
+	"talkspace-api/modules/job/model"
+
+	"gorm.io/gorm"
+)
+
+type jobQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewJobQueryRepository(db *gorm.DB) JobQueryRepositoryInterface {
+	return &jobQueryRepository{
+		db: db,
+	}
+}
+
+func (jqr *jobQueryRepository) GetJobRuns(job string, status string, page, limit int) ([]entity.JobRun, int, error) {
+	offset := (page - 1) * limit
+
+	query := jqr.db.Model(&model.JobRun{})
+	if job != "" {
+		query = query.Where("job = ?", job)
+	}
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var totalItems int64
+	result := query.Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var jobRunModels []model.JobRun
+	result = query.Order("started_at DESC").Offset(offset).Limit(limit).Find(&jobRunModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	jobRuns := entity.ListJobRunModelToJobRunEntity(jobRunModels)
+
+	return jobRuns, int(totalItems), nil
+}
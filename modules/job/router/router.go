@@ -0,0 +1,23 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/job/handler"
+	"talkspace-api/modules/job/repository"
+	"talkspace-api/modules/job/usecase"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func JobRoutes(e *echo.Group, db *gorm.DB) {
+	jobQueryRepository := repository.NewJobQueryRepository(db)
+	jobCommandRepository := repository.NewJobCommandRepository(db)
+
+	jobQueryUsecase := usecase.NewJobQueryUsecase(jobCommandRepository, jobQueryRepository)
+	jobCommandUsecase := usecase.NewJobCommandUsecase(jobCommandRepository, jobQueryRepository)
+
+	jobHandler := handler.NewJobHandler(jobCommandUsecase, jobQueryUsecase)
+
+	e.GET("/runs", jobHandler.GetJobRuns, middlewares.JWTMiddleware(false))
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"talkspace-api/modules/job/entity"
+	"talkspace-api/modules/job/repository"
+	"talkspace-api/utils/validator"
+	"time"
+)
+
+type jobCommandUsecase struct {
+	jobCommandRepository repository.JobCommandRepositoryInterface
+	jobQueryRepository   repository.JobQueryRepositoryInterface
+}
+
+func NewJobCommandUsecase(jcr repository.JobCommandRepositoryInterface, jqr repository.JobQueryRepositoryInterface) JobCommandUsecaseInterface {
+	return &jobCommandUsecase{
+		jobCommandRepository: jcr,
+		jobQueryRepository:   jqr,
+	}
+}
+
+func (jcu *jobCommandUsecase) RecordJobRun(jobRun entity.JobRun) (entity.JobRun, error) {
+	errEmpty := validator.IsDataEmpty([]string{"job", "instance", "status"}, jobRun.Job, jobRun.Instance, jobRun.Status)
+	if errEmpty != nil {
+		return entity.JobRun{}, errEmpty
+	}
+
+	jobRunEntity, errCreate := jcu.jobCommandRepository.CreateJobRun(jobRun)
+	if errCreate != nil {
+		return entity.JobRun{}, errCreate
+	}
+
+	return jobRunEntity, nil
+}
+
+// PruneJobRuns deletes the runs that started more than retentionDays ago and
+// returns how many were deleted.
+func (jcu *jobCommandUsecase) PruneJobRuns(retentionDays int) (int, error) {
+	if retentionDays < 1 {
+		retentionDays = 1
+	}
+
+	return jcu.jobCommandRepository.DeleteJobRunsBefore(time.Now().AddDate(0, 0, -retentionDays))
+}
@@ -0,0 +1,12 @@
+package usecase
+
+import "talkspace-api/modules/job/entity"
+
+type JobCommandUsecaseInterface interface {
+	RecordJobRun(jobRun entity.JobRun) (entity.JobRun, error)
+	PruneJobRuns(retentionDays int) (int, error)
+}
+
+type JobQueryUsecaseInterface interface {
+	GetJobRuns(job string, status string, page, limit int) ([]entity.JobRun, int, error)
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"talkspace-api/modules/job/entity"
+	"talkspace-api/modules/job/repository"
+)
+
+type jobQueryUsecase struct {
+	jobCommandRepository repository.JobCommandRepositoryInterface
+	jobQueryRepository   repository.JobQueryRepositoryInterface
+}
+
+func NewJobQueryUsecase(jcr repository.JobCommandRepositoryInterface, jqr repository.JobQueryRepositoryInterface) JobQueryUsecaseInterface {
+	return &jobQueryUsecase{
+		jobCommandRepository: jcr,
+		jobQueryRepository:   jqr,
+	}
+}
+
+func (jqu *jobQueryUsecase) GetJobRuns(job string, status string, page, limit int) ([]entity.JobRun, int, error) {
+	jobRuns, totalItems, errGet := jqu.jobQueryRepository.GetJobRuns(job, status, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return jobRuns, totalItems, nil
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "talkspace-api/modules/note/entity"
+
+func SessionNoteRequestToSessionNoteEntity(request SessionNoteRequest) entity.SessionNote {
+	return entity.SessionNote{
+		Subjective: request.Subjective,
+		Objective:  request.Objective,
+		Assessment: request.Assessment,
+		Plan:       request.Plan,
+	}
+}
+
+func SessionNoteUpdateRequestToSessionNoteEntity(request SessionNoteUpdateRequest) entity.SessionNote {
+	note := SessionNoteRequestToSessionNoteEntity(request.SessionNoteRequest)
+	note.Version = request.Version
+	return note
+}
+
+func SessionNoteEntityToSessionNoteResponse(response entity.SessionNote) SessionNoteResponse {
+	return SessionNoteResponse{
+		ID:             response.ID,
+		ConsultationID: response.ConsultationID,
+		DoctorID:       response.DoctorID,
+		UserID:         response.UserID,
+		Subjective:     response.Subjective,
+		Objective:      response.Objective,
+		Assessment:     response.Assessment,
+		Plan:           response.Plan,
+		Shared:         response.Shared,
+		SharedAt:       response.SharedAt,
+		Version:        response.Version,
+		CreatedAt:      response.CreatedAt,
+		UpdatedAt:      response.UpdatedAt,
+	}
+}
+
+func ListSessionNoteVersionEntityToSessionNoteVersionResponse(response []entity.SessionNoteVersion) []SessionNoteVersionResponse {
+	versionResponses := []SessionNoteVersionResponse{}
+	for _, version := range response {
+		versionResponses = append(versionResponses, SessionNoteVersionResponse{
+			Version:    version.Version,
+			Subjective: version.Subjective,
+			Objective:  version.Objective,
+			Assessment: version.Assessment,
+			Plan:       version.Plan,
+			EditedBy:   version.EditedBy,
+			CreatedAt:  version.CreatedAt,
+		})
+	}
+	return versionResponses
+}
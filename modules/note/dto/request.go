@@ -0,0 +1,21 @@
+package dto
+
+type (
+	SessionNoteRequest struct {
+		Subjective string `json:"subjective" form:"subjective"`
+		Objective  string `json:"objective" form:"objective"`
+		Assessment string `json:"assessment" form:"assessment"`
+		Plan       string `json:"plan" form:"plan"`
+	}
+
+	// SessionNoteUpdateRequest carries the version the edit was based on so
+	// concurrent edits do not overwrite each other.
+	SessionNoteUpdateRequest struct {
+		SessionNoteRequest
+		Version int `json:"version" form:"version"`
+	}
+
+	SessionNoteShareRequest struct {
+		Shared bool `json:"shared" form:"shared"`
+	}
+)
@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type (
+	SessionNoteResponse struct {
+		ID             string     `json:"id"`
+		ConsultationID string     `json:"consultation_id"`
+		DoctorID       string     `json:"doctor_id"`
+		UserID         string     `json:"user_id"`
+		Subjective     string     `json:"subjective"`
+		Objective      string     `json:"objective"`
+		Assessment     string     `json:"assessment"`
+		Plan           string     `json:"plan"`
+		Shared         bool       `json:"shared"`
+		SharedAt       *time.Time `json:"shared_at,omitempty"`
+		Version        int        `json:"version"`
+		CreatedAt      time.Time  `json:"created_at"`
+		UpdatedAt      time.Time  `json:"updated_at"`
+	}
+
+	SessionNoteVersionResponse struct {
+		Version    int       `json:"version"`
+		Subjective string    `json:"subjective"`
+		Objective  string    `json:"objective"`
+		Assessment string    `json:"assessment"`
+		Plan       string    `json:"plan"`
+		EditedBy   string    `json:"edited_by"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+)
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// SectionMaxLength is the longest section accepted, in bytes.
+const SectionMaxLength = 10000
+
+// SessionNote is a SOAP note a doctor writes about a consultation. The
+// patient only sees it once the doctor shares it.
+type SessionNote struct {
+	ID             string
+	ConsultationID string
+	DoctorID       string
+	UserID         string
+	Subjective     string
+	Objective      string
+	Assessment     string
+	Plan           string
+	Shared         bool
+	SharedAt       *time.Time
+	Version        int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type SessionNoteVersion struct {
+	ID         string
+	NoteID     string
+	Version    int
+	Subjective string
+	Objective  string
+	Assessment string
+	Plan       string
+	EditedBy   string
+	CreatedAt  time.Time
+}
+
+// IsEmpty reports whether none of the sections were written.
+func (sn SessionNote) IsEmpty() bool {
+	return sn.Subjective == "" && sn.Objective == "" && sn.Assessment == "" && sn.Plan == ""
+}
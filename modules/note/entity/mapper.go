@@ -0,0 +1,56 @@
+package entity
+
+import "talkspace-api/modules/note/model"
+
+func SessionNoteEntityToSessionNoteModel(noteEntity SessionNote) model.SessionNote {
+	noteModel := model.SessionNote{
+		ID:             noteEntity.ID,
+		ConsultationID: noteEntity.ConsultationID,
+		DoctorID:       noteEntity.DoctorID,
+		UserID:         noteEntity.UserID,
+		Subjective:     noteEntity.Subjective,
+		Objective:      noteEntity.Objective,
+		Assessment:     noteEntity.Assessment,
+		Plan:           noteEntity.Plan,
+		Shared:         noteEntity.Shared,
+		SharedAt:       noteEntity.SharedAt,
+		Version:        noteEntity.Version,
+		CreatedAt:      noteEntity.CreatedAt,
+		UpdatedAt:      noteEntity.UpdatedAt,
+	}
+	return noteModel
+}
+
+func SessionNoteModelToSessionNoteEntity(noteModel model.SessionNote) SessionNote {
+	noteEntity := SessionNote{
+		ID:             noteModel.ID,
+		ConsultationID: noteModel.ConsultationID,
+		DoctorID:       noteModel.DoctorID,
+		UserID:         noteModel.UserID,
+		Subjective:     noteModel.Subjective,
+		Objective:      noteModel.Objective,
+		Assessment:     noteModel.Assessment,
+		Plan:           noteModel.Plan,
+		Shared:         noteModel.Shared,
+		SharedAt:       noteModel.SharedAt,
+		Version:        noteModel.Version,
+		CreatedAt:      noteModel.CreatedAt,
+		UpdatedAt:      noteModel.UpdatedAt,
+	}
+	return noteEntity
+}
+
+func SessionNoteVersionModelToSessionNoteVersionEntity(versionModel model.SessionNoteVersion) SessionNoteVersion {
+	versionEntity := SessionNoteVersion{
+		ID:         versionModel.ID,
+		NoteID:     versionModel.NoteID,
+		Version:    versionModel.Version,
+		Subjective: versionModel.Subjective,
+		Objective:  versionModel.Objective,
+		Assessment: versionModel.Assessment,
+		Plan:       versionModel.Plan,
+		EditedBy:   versionModel.EditedBy,
+		CreatedAt:  versionModel.CreatedAt,
+	}
+	return versionEntity
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/note/dto"
+	"talkspace-api/modules/note/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type sessionNoteHandler struct {
+	sessionNoteCommandUsecase usecase.SessionNoteCommandUsecaseInterface
+	sessionNoteQueryUsecase   usecase.SessionNoteQueryUsecaseInterface
+}
+
+func NewSessionNoteHandler(sncu usecase.SessionNoteCommandUsecaseInterface, snqu usecase.SessionNoteQueryUsecaseInterface) *sessionNoteHandler {
+	return &sessionNoteHandler{
+		sessionNoteCommandUsecase: sncu,
+		sessionNoteQueryUsecase:   snqu,
+	}
+}
+
+// Query
+func (snh *sessionNoteHandler) GetSessionNoteByConsultationID(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	if consultationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	note, errGet := snh.sessionNoteQueryUsecase.GetSessionNoteByConsultationID(consultationIDParam, tokenID, role)
+	if errGet != nil {
+		return noteError(c, errGet)
+	}
+
+	noteResponse := dto.SessionNoteEntityToSessionNoteResponse(note)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, noteResponse))
+}
+
+func (snh *sessionNoteHandler) GetSessionNoteVersions(c echo.Context) error {
+	noteIDParam := c.Param("note_id")
+	if noteIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	versions, totalItems, errGet := snh.sessionNoteQueryUsecase.GetSessionNoteVersions(noteIDParam, doctorID, page, limit)
+	if errGet != nil {
+		return noteError(c, errGet)
+	}
+
+	if len(versions) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	versionResponses := dto.ListSessionNoteVersionEntityToSessionNoteVersionResponse(versions)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		versionResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Command
+func (snh *sessionNoteHandler) CreateSessionNote(c echo.Context) error {
+	consultationIDParam := c.Param("consultation_id")
+	if consultationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	noteRequest := dto.SessionNoteRequest{}
+
+	errBind := c.Bind(&noteRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	noteEntity := dto.SessionNoteRequestToSessionNoteEntity(noteRequest)
+	noteEntity.ConsultationID = consultationIDParam
+
+	note, errCreate := snh.sessionNoteCommandUsecase.CreateSessionNote(noteEntity, doctorID)
+	if errCreate != nil {
+		return noteError(c, errCreate)
+	}
+
+	noteResponse := dto.SessionNoteEntityToSessionNoteResponse(note)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, noteResponse))
+}
+
+func (snh *sessionNoteHandler) UpdateSessionNote(c echo.Context) error {
+	noteIDParam := c.Param("note_id")
+	if noteIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	noteRequest := dto.SessionNoteUpdateRequest{}
+
+	errBind := c.Bind(&noteRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	noteEntity := dto.SessionNoteUpdateRequestToSessionNoteEntity(noteRequest)
+
+	note, errUpdate := snh.sessionNoteCommandUsecase.UpdateSessionNote(noteIDParam, noteEntity, doctorID)
+	if errUpdate != nil {
+		return noteError(c, errUpdate)
+	}
+
+	noteResponse := dto.SessionNoteEntityToSessionNoteResponse(note)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, noteResponse))
+}
+
+func (snh *sessionNoteHandler) ShareSessionNote(c echo.Context) error {
+	noteIDParam := c.Param("note_id")
+	if noteIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	shareRequest := dto.SessionNoteShareRequest{}
+
+	errBind := c.Bind(&shareRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	note, errUpdate := snh.sessionNoteCommandUsecase.ShareSessionNote(noteIDParam, doctorID, shareRequest.Shared)
+	if errUpdate != nil {
+		return noteError(c, errUpdate)
+	}
+
+	noteResponse := dto.SessionNoteEntityToSessionNoteResponse(note)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, noteResponse))
+}
+
+func noteError(c echo.Context, err error) error {
+	switch err.Error() {
+	case constant.ERROR_ID_NOTFOUND:
+		return c.JSON(http.StatusNotFound, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_ROLE_ACCESS:
+		return c.JSON(http.StatusForbidden, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_NOTE_EXIST, constant.ERROR_NOTE_VERSION:
+		return c.JSON(http.StatusConflict, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_NOTE_STATUS, constant.ERROR_NOTE_EMPTY, constant.ERROR_NOTE_TOO_LONG:
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(err.Error()))
+	}
+}
@@ -0,0 +1,14 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type SessionNoteHandlerInterface interface {
+	// Query
+	GetSessionNoteByConsultationID(c echo.Context) error
+	GetSessionNoteVersions(c echo.Context) error
+
+	// Command
+	CreateSessionNote(c echo.Context) error
+	UpdateSessionNote(c echo.Context) error
+	ShareSessionNote(c echo.Context) error
+}
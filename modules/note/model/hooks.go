@@ -0,0 +1,18 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (sn *SessionNote) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	sn.ID = UUID.String()
+	return nil
+}
+
+func (snv *SessionNoteVersion) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	snv.ID = UUID.String()
+	return nil
+}
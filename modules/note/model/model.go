@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// SessionNote holds the sections encrypted, see the note repository.
+type SessionNote struct {
+	ID             string `gorm:"primarykey"`
+	ConsultationID string `gorm:"uniqueIndex;not null"`
+	DoctorID       string `gorm:"index;not null"`
+	UserID         string `gorm:"index;not null"`
+	Subjective     string `gorm:"type:text"`
+	Objective      string `gorm:"type:text"`
+	Assessment     string `gorm:"type:text"`
+	Plan           string `gorm:"type:text"`
+	Shared         bool   `gorm:"not null;default:false"`
+	SharedAt       *time.Time
+	Version        int `gorm:"not null;default:1"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SessionNoteVersion is a copy of the sections as they were after an edit.
+type SessionNoteVersion struct {
+	ID         string `gorm:"primarykey"`
+	NoteID     string `gorm:"uniqueIndex:idx_session_note_versions_note_version;not null"`
+	Version    int    `gorm:"uniqueIndex:idx_session_note_versions_note_version;not null"`
+	Subjective string `gorm:"type:text"`
+	Objective  string `gorm:"type:text"`
+	Assessment string `gorm:"type:text"`
+	Plan       string `gorm:"type:text"`
+	EditedBy   string `gorm:"not null"`
+	CreatedAt  time.Time
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"talkspace-api/utils/helper/encryption"
+)
+
+// sections points at the encrypted columns of a note or one of its
+// versions. Each one is bound to its consultation and section name, so a
+// ciphertext copied anywhere else fails to decrypt.
+type sections struct {
+	consultationID string
+	fields         map[string]*string
+}
+
+func noteSections(consultationID string, subjective, objective, assessment, plan *string) sections {
+	return sections{
+		consultationID: consultationID,
+		fields: map[string]*string{
+			"subjective": subjective,
+			"objective":  objective,
+			"assessment": assessment,
+			"plan":       plan,
+		},
+	}
+}
+
+func (s sections) encrypt(cipher encryption.Cipher) error {
+	for name, field := range s.fields {
+		ciphertext, err := cipher.Encrypt(*field, s.consultationID+":"+name)
+		if err != nil {
+			return err
+		}
+		*field = ciphertext
+	}
+	return nil
+}
+
+func (s sections) decrypt(cipher encryption.Cipher) error {
+	for name, field := range s.fields {
+		plaintext, err := cipher.Decrypt(*field, s.consultationID+":"+name)
+		if err != nil {
+			return err
+		}
+		*field = plaintext
+	}
+	return nil
+}
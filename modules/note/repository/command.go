@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/note/entity"
+	"talkspace-api/modules/note/model"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/encryption"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sessionNoteCommandRepository struct {
+	db     *gorm.DB
+	cipher encryption.Cipher
+}
+
+func NewSessionNoteCommandRepository(db *gorm.DB, cipher encryption.Cipher) SessionNoteCommandRepositoryInterface {
+	return &sessionNoteCommandRepository{
+		db:     db,
+		cipher: cipher,
+	}
+}
+
+// CreateSessionNote stores the first version of the note of a consultation.
+func (sncr *sessionNoteCommandRepository) CreateSessionNote(note entity.SessionNote) (entity.SessionNote, error) {
+	note.Version = 1
+
+	noteModel := entity.SessionNoteEntityToSessionNoteModel(note)
+	errEncrypt := sealNote(sncr.cipher, &noteModel)
+	if errEncrypt != nil {
+		return entity.SessionNote{}, errEncrypt
+	}
+
+	errTx := sncr.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		result := tx.Model(&model.SessionNote{}).Where("consultation_id = ?", noteModel.ConsultationID).Count(&existing)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if existing > 0 {
+			return errors.New(constant.ERROR_NOTE_EXIST)
+		}
+
+		result = tx.Create(&noteModel)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return createVersion(tx, noteModel, note.DoctorID)
+	})
+	if errTx != nil {
+		return entity.SessionNote{}, errTx
+	}
+
+	note.ID = noteModel.ID
+	note.CreatedAt = noteModel.CreatedAt
+	note.UpdatedAt = noteModel.UpdatedAt
+
+	return note, nil
+}
+
+// UpdateSessionNote replaces the sections of a note and keeps a copy of the
+// result as a new version. note.Version is the version the edit was based
+// on; the edit is rejected when someone saved another one in between.
+func (sncr *sessionNoteCommandRepository) UpdateSessionNote(id string, note entity.SessionNote, editedBy string) (entity.SessionNote, error) {
+	noteModel := model.SessionNote{}
+
+	errTx := sncr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&noteModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		if noteModel.Version != note.Version {
+			return errors.New(constant.ERROR_NOTE_VERSION)
+		}
+
+		noteModel.Subjective = note.Subjective
+		noteModel.Objective = note.Objective
+		noteModel.Assessment = note.Assessment
+		noteModel.Plan = note.Plan
+		noteModel.Version++
+
+		errEncrypt := sealNote(sncr.cipher, &noteModel)
+		if errEncrypt != nil {
+			return errEncrypt
+		}
+
+		result = tx.Save(&noteModel)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return createVersion(tx, noteModel, editedBy)
+	})
+	if errTx != nil {
+		return entity.SessionNote{}, errTx
+	}
+
+	errDecrypt := openNote(sncr.cipher, &noteModel)
+	if errDecrypt != nil {
+		return entity.SessionNote{}, errDecrypt
+	}
+
+	noteEntity := entity.SessionNoteModelToSessionNoteEntity(noteModel)
+
+	return noteEntity, nil
+}
+
+// UpdateSessionNoteSharing shows the note to the patient or hides it again.
+// It does not change the sections, so no version is added.
+func (sncr *sessionNoteCommandRepository) UpdateSessionNoteSharing(id string, shared bool, at time.Time) (entity.SessionNote, error) {
+	noteModel := model.SessionNote{}
+
+	errTx := sncr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&noteModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		if noteModel.Shared == shared {
+			return nil
+		}
+
+		noteModel.Shared = shared
+		noteModel.SharedAt = nil
+		if shared {
+			noteModel.SharedAt = &at
+		}
+
+		return tx.Model(&noteModel).Select("shared", "shared_at").Updates(&noteModel).Error
+	})
+	if errTx != nil {
+		return entity.SessionNote{}, errTx
+	}
+
+	errDecrypt := openNote(sncr.cipher, &noteModel)
+	if errDecrypt != nil {
+		return entity.SessionNote{}, errDecrypt
+	}
+
+	noteEntity := entity.SessionNoteModelToSessionNoteEntity(noteModel)
+
+	return noteEntity, nil
+}
+
+// createVersion copies the already encrypted sections of a note.
+func createVersion(tx *gorm.DB, noteModel model.SessionNote, editedBy string) error {
+	versionModel := model.SessionNoteVersion{
+		NoteID:     noteModel.ID,
+		Version:    noteModel.Version,
+		Subjective: noteModel.Subjective,
+		Objective:  noteModel.Objective,
+		Assessment: noteModel.Assessment,
+		Plan:       noteModel.Plan,
+		EditedBy:   editedBy,
+	}
+
+	return tx.Create(&versionModel).Error
+}
+
+func sealNote(cipher encryption.Cipher, noteModel *model.SessionNote) error {
+	return noteSections(noteModel.ConsultationID, &noteModel.Subjective, &noteModel.Objective, &noteModel.Assessment, &noteModel.Plan).encrypt(cipher)
+}
+
+func openNote(cipher encryption.Cipher, noteModel *model.SessionNote) error {
+	return noteSections(noteModel.ConsultationID, &noteModel.Subjective, &noteModel.Objective, &noteModel.Assessment, &noteModel.Plan).decrypt(cipher)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"talkspace-api/modules/note/entity"
+	"time"
+)
+
+type SessionNoteCommandRepositoryInterface interface {
+	CreateSessionNote(note entity.SessionNote) (entity.SessionNote, error)
+	UpdateSessionNote(id string, note entity.SessionNote, editedBy string) (entity.SessionNote, error)
+	UpdateSessionNoteSharing(id string, shared bool, at time.Time) (entity.SessionNote, error)
+}
+
+type SessionNoteQueryRepositoryInterface interface {
+	GetSessionNoteByID(id string) (entity.SessionNote, error)
+	GetSessionNoteByConsultationID(consultationID string) (entity.SessionNote, error)
+	GetSessionNoteVersions(noteID string, page, limit int) ([]entity.SessionNoteVersion, int, error)
+}
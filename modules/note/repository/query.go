@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/note/entity"
+	"talkspace-api/modules/note/model"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/encryption"
+
+	"gorm.io/gorm"
+)
+
+type sessionNoteQueryRepository struct {
+	db     *gorm.DB
+	cipher encryption.Cipher
+}
+
+func NewSessionNoteQueryRepository(db *gorm.DB, cipher encryption.Cipher) SessionNoteQueryRepositoryInterface {
+	return &sessionNoteQueryRepository{
+		db:     db,
+		cipher: cipher,
+	}
+}
+
+func (snqr *sessionNoteQueryRepository) GetSessionNoteByID(id string) (entity.SessionNote, error) {
+	return snqr.getSessionNote("id = ?", id)
+}
+
+func (snqr *sessionNoteQueryRepository) GetSessionNoteByConsultationID(consultationID string) (entity.SessionNote, error) {
+	return snqr.getSessionNote("consultation_id = ?", consultationID)
+}
+
+func (snqr *sessionNoteQueryRepository) getSessionNote(condition string, id string) (entity.SessionNote, error) {
+	noteModel := model.SessionNote{}
+	result := snqr.db.Where(condition, id).First(&noteModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.SessionNote{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.SessionNote{}, result.Error
+	}
+
+	errDecrypt := openNote(snqr.cipher, &noteModel)
+	if errDecrypt != nil {
+		return entity.SessionNote{}, errDecrypt
+	}
+
+	noteEntity := entity.SessionNoteModelToSessionNoteEntity(noteModel)
+
+	return noteEntity, nil
+}
+
+// GetSessionNoteVersions returns the versions of a note, newest first.
+func (snqr *sessionNoteQueryRepository) GetSessionNoteVersions(noteID string, page, limit int) ([]entity.SessionNoteVersion, int, error) {
+	offset := (page - 1) * limit
+
+	noteModel := model.SessionNote{}
+	result := snqr.db.Select("id", "consultation_id").Where("id = ?", noteID).First(&noteModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, 0, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return nil, 0, result.Error
+	}
+
+	var totalItems int64
+	result = snqr.db.Model(&model.SessionNoteVersion{}).Where("note_id = ?", noteID).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var versionModels []model.SessionNoteVersion
+	result = snqr.db.Where("note_id = ?", noteID).Order("version DESC").Offset(offset).Limit(limit).Find(&versionModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	versions := []entity.SessionNoteVersion{}
+	for _, versionModel := range versionModels {
+		errDecrypt := noteSections(noteModel.ConsultationID, &versionModel.Subjective, &versionModel.Objective, &versionModel.Assessment, &versionModel.Plan).decrypt(snqr.cipher)
+		if errDecrypt != nil {
+			return nil, 0, errDecrypt
+		}
+
+		versions = append(versions, entity.SessionNoteVersionModelToSessionNoteVersionEntity(versionModel))
+	}
+
+	return versions, int(totalItems), nil
+}
@@ -0,0 +1,34 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/modules/note/handler"
+	"talkspace-api/modules/note/repository"
+	"talkspace-api/modules/note/usecase"
+	"talkspace-api/utils/helper/encryption"
+
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+func SessionNoteRoutes(e *echo.Group, db *gorm.DB) {
+	cipher := encryption.NewCipher()
+
+	sessionNoteQueryRepository := repository.NewSessionNoteQueryRepository(db, cipher)
+	sessionNoteCommandRepository := repository.NewSessionNoteCommandRepository(db, cipher)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+
+	sessionNoteQueryUsecase := usecase.NewSessionNoteQueryUsecase(sessionNoteQueryRepository)
+	sessionNoteCommandUsecase := usecase.NewSessionNoteCommandUsecase(sessionNoteCommandRepository, sessionNoteQueryRepository, consultationQueryRepository)
+
+	sessionNoteHandler := handler.NewSessionNoteHandler(sessionNoteCommandUsecase, sessionNoteQueryUsecase)
+
+	consultations := e.Group("/consultations", middlewares.JWTMiddleware(false))
+	consultations.POST("/:consultation_id", sessionNoteHandler.CreateSessionNote)
+	consultations.GET("/:consultation_id", sessionNoteHandler.GetSessionNoteByConsultationID)
+
+	e.PUT("/:note_id", sessionNoteHandler.UpdateSessionNote, middlewares.JWTMiddleware(false))
+	e.PATCH("/:note_id/share", sessionNoteHandler.ShareSessionNote, middlewares.JWTMiddleware(false))
+	e.GET("/:note_id/versions", sessionNoteHandler.GetSessionNoteVersions, middlewares.JWTMiddleware(false))
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/modules/note/entity"
+	"talkspace-api/modules/note/repository"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type sessionNoteCommandUsecase struct {
+	sessionNoteCommandRepository repository.SessionNoteCommandRepositoryInterface
+	sessionNoteQueryRepository   repository.SessionNoteQueryRepositoryInterface
+	consultationQueryRepository  cr.ConsultationQueryRepositoryInterface
+}
+
+func NewSessionNoteCommandUsecase(sncr repository.SessionNoteCommandRepositoryInterface, snqr repository.SessionNoteQueryRepositoryInterface, cqr cr.ConsultationQueryRepositoryInterface) SessionNoteCommandUsecaseInterface {
+	return &sessionNoteCommandUsecase{
+		sessionNoteCommandRepository: sncr,
+		sessionNoteQueryRepository:   snqr,
+		consultationQueryRepository:  cqr,
+	}
+}
+
+// notableStatus are the consultation states a note can be written in: the
+// session started, or the patient never showed up.
+var notableStatus = map[string]bool{
+	constant.CONSULTATION_ACTIVE:    true,
+	constant.CONSULTATION_COMPLETED: true,
+	constant.CONSULTATION_NO_SHOW:   true,
+}
+
+func (sncu *sessionNoteCommandUsecase) CreateSessionNote(note entity.SessionNote, doctorID string) (entity.SessionNote, error) {
+	note, errValidate := validateSections(note)
+	if errValidate != nil {
+		return entity.SessionNote{}, errValidate
+	}
+
+	consultation, errGetID := sncu.consultationQueryRepository.GetConsultationByID(note.ConsultationID)
+	if errGetID != nil {
+		return entity.SessionNote{}, errGetID
+	}
+
+	if consultation.DoctorID != doctorID {
+		return entity.SessionNote{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	if !notableStatus[consultation.Status] {
+		return entity.SessionNote{}, errors.New(constant.ERROR_NOTE_STATUS)
+	}
+
+	note.DoctorID = consultation.DoctorID
+	note.UserID = consultation.UserID
+	note.Shared = false
+	note.SharedAt = nil
+
+	noteEntity, errCreate := sncu.sessionNoteCommandRepository.CreateSessionNote(note)
+	if errCreate != nil {
+		return entity.SessionNote{}, errCreate
+	}
+
+	return noteEntity, nil
+}
+
+// UpdateSessionNote saves a new version of the note. note.Version must be
+// the version the doctor was editing.
+func (sncu *sessionNoteCommandUsecase) UpdateSessionNote(id string, note entity.SessionNote, doctorID string) (entity.SessionNote, error) {
+	note, errValidate := validateSections(note)
+	if errValidate != nil {
+		return entity.SessionNote{}, errValidate
+	}
+
+	current, errGetID := sncu.sessionNoteQueryRepository.GetSessionNoteByID(id)
+	if errGetID != nil {
+		return entity.SessionNote{}, errGetID
+	}
+
+	if current.DoctorID != doctorID {
+		return entity.SessionNote{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	noteEntity, errUpdate := sncu.sessionNoteCommandRepository.UpdateSessionNote(id, note, doctorID)
+	if errUpdate != nil {
+		return entity.SessionNote{}, errUpdate
+	}
+
+	return noteEntity, nil
+}
+
+func (sncu *sessionNoteCommandUsecase) ShareSessionNote(id string, doctorID string, shared bool) (entity.SessionNote, error) {
+	current, errGetID := sncu.sessionNoteQueryRepository.GetSessionNoteByID(id)
+	if errGetID != nil {
+		return entity.SessionNote{}, errGetID
+	}
+
+	if current.DoctorID != doctorID {
+		return entity.SessionNote{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	noteEntity, errUpdate := sncu.sessionNoteCommandRepository.UpdateSessionNoteSharing(id, shared, time.Now())
+	if errUpdate != nil {
+		return entity.SessionNote{}, errUpdate
+	}
+
+	return noteEntity, nil
+}
+
+func validateSections(note entity.SessionNote) (entity.SessionNote, error) {
+	note.Subjective = strings.TrimSpace(note.Subjective)
+	note.Objective = strings.TrimSpace(note.Objective)
+	note.Assessment = strings.TrimSpace(note.Assessment)
+	note.Plan = strings.TrimSpace(note.Plan)
+
+	if note.IsEmpty() {
+		return note, errors.New(constant.ERROR_NOTE_EMPTY)
+	}
+
+	for _, section := range []string{note.Subjective, note.Objective, note.Assessment, note.Plan} {
+		if len(section) > entity.SectionMaxLength {
+			return note, errors.New(constant.ERROR_NOTE_TOO_LONG)
+		}
+	}
+
+	return note, nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import "talkspace-api/modules/note/entity"
+
+type SessionNoteCommandUsecaseInterface interface {
+	CreateSessionNote(note entity.SessionNote, doctorID string) (entity.SessionNote, error)
+	UpdateSessionNote(id string, note entity.SessionNote, doctorID string) (entity.SessionNote, error)
+	ShareSessionNote(id string, doctorID string, shared bool) (entity.SessionNote, error)
+}
+
+type SessionNoteQueryUsecaseInterface interface {
+	GetSessionNoteByConsultationID(consultationID string, requesterID string, role string) (entity.SessionNote, error)
+	GetSessionNoteVersions(id string, doctorID string, page, limit int) ([]entity.SessionNoteVersion, int, error)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/note/entity"
+	"talkspace-api/modules/note/repository"
+	"talkspace-api/utils/constant"
+)
+
+type sessionNoteQueryUsecase struct {
+	sessionNoteQueryRepository repository.SessionNoteQueryRepositoryInterface
+}
+
+func NewSessionNoteQueryUsecase(snqr repository.SessionNoteQueryRepositoryInterface) SessionNoteQueryUsecaseInterface {
+	return &sessionNoteQueryUsecase{
+		sessionNoteQueryRepository: snqr,
+	}
+}
+
+// GetSessionNoteByConsultationID returns the note to the doctor who wrote it,
+// or to the patient once it was shared. A patient cannot tell an unshared
+// note from a missing one.
+func (snqu *sessionNoteQueryUsecase) GetSessionNoteByConsultationID(consultationID string, requesterID string, role string) (entity.SessionNote, error) {
+	note, errGet := snqu.sessionNoteQueryRepository.GetSessionNoteByConsultationID(consultationID)
+	if errGet != nil {
+		return entity.SessionNote{}, errGet
+	}
+
+	switch {
+	case role == constant.DOCTOR && note.DoctorID == requesterID:
+		return note, nil
+	case role == constant.USER && note.UserID == requesterID:
+		if !note.Shared {
+			return entity.SessionNote{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return note, nil
+	default:
+		return entity.SessionNote{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+}
+
+func (snqu *sessionNoteQueryUsecase) GetSessionNoteVersions(id string, doctorID string, page, limit int) ([]entity.SessionNoteVersion, int, error) {
+	note, errGetID := snqu.sessionNoteQueryRepository.GetSessionNoteByID(id)
+	if errGetID != nil {
+		return nil, 0, errGetID
+	}
+
+	if note.DoctorID != doctorID {
+		return nil, 0, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	versions, totalItems, errGet := snqu.sessionNoteQueryRepository.GetSessionNoteVersions(id, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return versions, totalItems, nil
+}
@@ -0,0 +1,53 @@
+package dto
+
+import "talkspace-api/modules/review/entity"
+
+func ReviewRequestToReviewEntity(request ReviewRequest) entity.Review {
+	return entity.Review{
+		ConsultationID: request.ConsultationID,
+		Rating:         request.Rating,
+		Comment:        request.Comment,
+	}
+}
+
+func ReviewEntityToReviewResponse(response entity.Review) ReviewResponse {
+	return ReviewResponse{
+		ID:             response.ID,
+		ConsultationID: response.ConsultationID,
+		DoctorID:       response.DoctorID,
+		Rating:         response.Rating,
+		Comment:        response.Comment,
+		Reply:          response.Reply,
+		RepliedAt:      response.RepliedAt,
+		CreatedAt:      response.CreatedAt,
+	}
+}
+
+func ListReviewEntityToReviewResponse(response []entity.Review) []ReviewResponse {
+	reviewResponses := []ReviewResponse{}
+	for _, review := range response {
+		reviewResponse := ReviewEntityToReviewResponse(review)
+		reviewResponses = append(reviewResponses, reviewResponse)
+	}
+	return reviewResponses
+}
+
+func ReviewEntityToReviewModerationResponse(response entity.Review) ReviewModerationResponse {
+	return ReviewModerationResponse{
+		ReviewResponse: ReviewEntityToReviewResponse(response),
+		UserID:         response.UserID,
+		Hidden:         response.Hidden,
+		HiddenBy:       response.HiddenBy,
+		HiddenReason:   response.HiddenReason,
+		HiddenAt:       response.HiddenAt,
+	}
+}
+
+func ListReviewEntityToReviewModerationResponse(response []entity.Review) []ReviewModerationResponse {
+	reviewResponses := []ReviewModerationResponse{}
+	for _, review := range response {
+		reviewResponse := ReviewEntityToReviewModerationResponse(review)
+		reviewResponses = append(reviewResponses, reviewResponse)
+	}
+	return reviewResponses
+}
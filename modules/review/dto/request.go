@@ -0,0 +1,18 @@
+package dto
+
+type (
+	ReviewRequest struct {
+		ConsultationID string `json:"consultation_id" form:"consultation_id"`
+		Rating         int    `json:"rating" form:"rating"`
+		Comment        string `json:"comment" form:"comment"`
+	}
+
+	ReviewReplyRequest struct {
+		Reply string `json:"reply" form:"reply"`
+	}
+
+	ReviewVisibilityRequest struct {
+		Hidden bool   `json:"hidden" form:"hidden"`
+		Reason string `json:"reason" form:"reason"`
+	}
+)
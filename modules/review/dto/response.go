@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+type (
+	ReviewResponse struct {
+		ID             string     `json:"id"`
+		ConsultationID string     `json:"consultation_id"`
+		DoctorID       string     `json:"doctor_id"`
+		Rating         int        `json:"rating"`
+		Comment        string     `json:"comment"`
+		Reply          string     `json:"reply,omitempty"`
+		RepliedAt      *time.Time `json:"replied_at,omitempty"`
+		CreatedAt      time.Time  `json:"created_at"`
+	}
+
+	// ReviewModerationResponse is what admins see, including who wrote the
+	// review and why it was hidden.
+	ReviewModerationResponse struct {
+		ReviewResponse
+		UserID       string     `json:"user_id"`
+		Hidden       bool       `json:"hidden"`
+		HiddenBy     string     `json:"hidden_by,omitempty"`
+		HiddenReason string     `json:"hidden_reason,omitempty"`
+		HiddenAt     *time.Time `json:"hidden_at,omitempty"`
+	}
+)
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+const (
+	RatingMin = 1
+	RatingMax = 5
+	// TextMaxLength is the longest comment or reply accepted, in bytes.
+	TextMaxLength = 2000
+)
+
+type Review struct {
+	ID             string
+	ConsultationID string
+	UserID         string
+	DoctorID       string
+	Rating         int
+	Comment        string
+	Reply          string
+	RepliedAt      *time.Time
+	Hidden         bool
+	HiddenBy       string
+	HiddenReason   string
+	HiddenAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
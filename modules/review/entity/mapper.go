@@ -0,0 +1,52 @@
+package entity
+
+import "talkspace-api/modules/review/model"
+
+func ReviewEntityToReviewModel(reviewEntity Review) model.Review {
+	reviewModel := model.Review{
+		ID:             reviewEntity.ID,
+		ConsultationID: reviewEntity.ConsultationID,
+		UserID:         reviewEntity.UserID,
+		DoctorID:       reviewEntity.DoctorID,
+		Rating:         reviewEntity.Rating,
+		Comment:        reviewEntity.Comment,
+		Reply:          reviewEntity.Reply,
+		RepliedAt:      reviewEntity.RepliedAt,
+		Hidden:         reviewEntity.Hidden,
+		HiddenBy:       reviewEntity.HiddenBy,
+		HiddenReason:   reviewEntity.HiddenReason,
+		HiddenAt:       reviewEntity.HiddenAt,
+		CreatedAt:      reviewEntity.CreatedAt,
+		UpdatedAt:      reviewEntity.UpdatedAt,
+	}
+	return reviewModel
+}
+
+func ReviewModelToReviewEntity(reviewModel model.Review) Review {
+	reviewEntity := Review{
+		ID:             reviewModel.ID,
+		ConsultationID: reviewModel.ConsultationID,
+		UserID:         reviewModel.UserID,
+		DoctorID:       reviewModel.DoctorID,
+		Rating:         reviewModel.Rating,
+		Comment:        reviewModel.Comment,
+		Reply:          reviewModel.Reply,
+		RepliedAt:      reviewModel.RepliedAt,
+		Hidden:         reviewModel.Hidden,
+		HiddenBy:       reviewModel.HiddenBy,
+		HiddenReason:   reviewModel.HiddenReason,
+		HiddenAt:       reviewModel.HiddenAt,
+		CreatedAt:      reviewModel.CreatedAt,
+		UpdatedAt:      reviewModel.UpdatedAt,
+	}
+	return reviewEntity
+}
+
+func ListReviewModelToReviewEntity(reviewModels []model.Review) []Review {
+	listReviewEntity := []Review{}
+	for _, review := range reviewModels {
+		reviewEntity := ReviewModelToReviewEntity(review)
+		listReviewEntity = append(listReviewEntity, reviewEntity)
+	}
+	return listReviewEntity
+}
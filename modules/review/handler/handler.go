@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/review/dto"
+	"talkspace-api/modules/review/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type reviewHandler struct {
+	reviewCommandUsecase usecase.ReviewCommandUsecaseInterface
+	reviewQueryUsecase   usecase.ReviewQueryUsecaseInterface
+}
+
+func NewReviewHandler(rcu usecase.ReviewCommandUsecaseInterface, rqu usecase.ReviewQueryUsecaseInterface) *reviewHandler {
+	return &reviewHandler{
+		reviewCommandUsecase: rcu,
+		reviewQueryUsecase:   rqu,
+	}
+}
+
+// Query
+func (rh *reviewHandler) GetReviewsByDoctorID(c echo.Context) error {
+	doctorIDParam := c.Param("doctor_id")
+	if doctorIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	reviews, totalItems, errGet := rh.reviewQueryUsecase.GetReviewsByDoctorID(doctorIDParam, false, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(reviews) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	reviewResponses := dto.ListReviewEntityToReviewResponse(reviews)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		reviewResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetModerationReviewsByDoctorID lists every review of a doctor, hidden ones
+// included, for admins.
+func (rh *reviewHandler) GetModerationReviewsByDoctorID(c echo.Context) error {
+	doctorIDParam := c.Param("doctor_id")
+	if doctorIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	reviews, totalItems, errGet := rh.reviewQueryUsecase.GetReviewsByDoctorID(doctorIDParam, true, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(reviews) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	reviewResponses := dto.ListReviewEntityToReviewModerationResponse(reviews)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		reviewResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Command
+func (rh *reviewHandler) CreateReview(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	reviewRequest := dto.ReviewRequest{}
+
+	errBind := c.Bind(&reviewRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	reviewEntity := dto.ReviewRequestToReviewEntity(reviewRequest)
+	reviewEntity.UserID = userID
+
+	review, errCreate := rh.reviewCommandUsecase.CreateReview(reviewEntity)
+	if errCreate != nil {
+		switch errCreate.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errCreate.Error()))
+		case constant.ERROR_ROLE_ACCESS:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(errCreate.Error()))
+		case constant.ERROR_REVIEW_EXIST:
+			return c.JSON(http.StatusConflict, responses.ErrorResponse(errCreate.Error()))
+		default:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCreate.Error()))
+		}
+	}
+
+	reviewResponse := dto.ReviewEntityToReviewResponse(review)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_REVIEWED, reviewResponse))
+}
+
+func (rh *reviewHandler) ReplyReview(c echo.Context) error {
+	reviewIDParam := c.Param("review_id")
+	if reviewIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	replyRequest := dto.ReviewReplyRequest{}
+
+	errBind := c.Bind(&replyRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	review, errUpdate := rh.reviewCommandUsecase.ReplyReview(reviewIDParam, doctorID, replyRequest.Reply)
+	if errUpdate != nil {
+		switch errUpdate.Error() {
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errUpdate.Error()))
+		case constant.ERROR_ROLE_ACCESS:
+			return c.JSON(http.StatusForbidden, responses.ErrorResponse(errUpdate.Error()))
+		default:
+			return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errUpdate.Error()))
+		}
+	}
+
+	reviewResponse := dto.ReviewEntityToReviewResponse(review)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, reviewResponse))
+}
+
+func (rh *reviewHandler) UpdateReviewVisibility(c echo.Context) error {
+	reviewIDParam := c.Param("review_id")
+	if reviewIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	adminID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	visibilityRequest := dto.ReviewVisibilityRequest{}
+
+	errBind := c.Bind(&visibilityRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	review, errUpdate := rh.reviewCommandUsecase.UpdateReviewVisibility(reviewIDParam, adminID, visibilityRequest.Hidden, visibilityRequest.Reason)
+	if errUpdate != nil {
+		if errUpdate.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errUpdate.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errUpdate.Error()))
+	}
+
+	reviewResponse := dto.ReviewEntityToReviewModerationResponse(review)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, reviewResponse))
+}
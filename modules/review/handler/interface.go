@@ -0,0 +1,14 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type ReviewHandlerInterface interface {
+	// Query
+	GetReviewsByDoctorID(c echo.Context) error
+	GetModerationReviewsByDoctorID(c echo.Context) error
+
+	// Command
+	CreateReview(c echo.Context) error
+	ReplyReview(c echo.Context) error
+	UpdateReviewVisibility(c echo.Context) error
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Review) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	r.ID = UUID.String()
+	return nil
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type Review struct {
+	ID             string `gorm:"primarykey"`
+	ConsultationID string `gorm:"uniqueIndex;not null"`
+	UserID         string `gorm:"index;not null"`
+	DoctorID       string `gorm:"index;not null"`
+	Rating         int    `gorm:"not null"`
+	Comment        string `gorm:"type:text"`
+	Reply          string `gorm:"type:text"`
+	RepliedAt      *time.Time
+	Hidden         bool `gorm:"not null;default:false"`
+	HiddenBy       string
+	HiddenReason   string
+	HiddenAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
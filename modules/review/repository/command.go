@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"talkspace-api/modules/review/entity"
+	"talkspace-api/modules/review/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reviewCommandRepository struct {
+	db  *gorm.DB
+	rdb *redis.Client
+}
+
+func NewReviewCommandRepository(db *gorm.DB, rdb *redis.Client) ReviewCommandRepositoryInterface {
+	return &reviewCommandRepository{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+func (rcr *reviewCommandRepository) CreateReview(review entity.Review) (entity.Review, error) {
+	reviewModel := entity.ReviewEntityToReviewModel(review)
+
+	errTx := rcr.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		result := tx.Model(&model.Review{}).Where("consultation_id = ?", reviewModel.ConsultationID).Count(&existing)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if existing > 0 {
+			return errors.New(constant.ERROR_REVIEW_EXIST)
+		}
+
+		result = tx.Create(&reviewModel)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return refreshDoctorRating(tx, reviewModel.DoctorID)
+	})
+	if errTx != nil {
+		return entity.Review{}, errTx
+	}
+
+	rcr.clearDoctorCache(reviewModel.DoctorID)
+
+	reviewEntity := entity.ReviewModelToReviewEntity(reviewModel)
+
+	return reviewEntity, nil
+}
+
+func (rcr *reviewCommandRepository) UpdateReviewReply(id string, reply string, at time.Time) (entity.Review, error) {
+	reviewModel, _, errUpdate := rcr.updateReview(id, func(review *model.Review) bool {
+		review.Reply = reply
+		review.RepliedAt = &at
+		if reply == "" {
+			review.RepliedAt = nil
+		}
+		return true
+	})
+	if errUpdate != nil {
+		return entity.Review{}, errUpdate
+	}
+
+	reviewEntity := entity.ReviewModelToReviewEntity(reviewModel)
+
+	return reviewEntity, nil
+}
+
+// UpdateReviewVisibility hides a review from the public or shows it again.
+// Hidden reviews do not count towards the rating of the doctor.
+func (rcr *reviewCommandRepository) UpdateReviewVisibility(id string, hidden bool, hiddenBy string, reason string, at time.Time) (entity.Review, error) {
+	reviewModel, changed, errUpdate := rcr.updateReview(id, func(review *model.Review) bool {
+		if review.Hidden == hidden {
+			return false
+		}
+
+		review.Hidden = hidden
+		if hidden {
+			review.HiddenBy = hiddenBy
+			review.HiddenReason = reason
+			review.HiddenAt = &at
+		} else {
+			review.HiddenBy = ""
+			review.HiddenReason = ""
+			review.HiddenAt = nil
+		}
+		return true
+	})
+	if errUpdate != nil {
+		return entity.Review{}, errUpdate
+	}
+
+	if changed {
+		rcr.clearDoctorCache(reviewModel.DoctorID)
+	}
+
+	reviewEntity := entity.ReviewModelToReviewEntity(reviewModel)
+
+	return reviewEntity, nil
+}
+
+// updateReview locks a review, applies update and saves it when update
+// reports a change, refreshing the rating of the doctor in the same
+// transaction.
+func (rcr *reviewCommandRepository) updateReview(id string, update func(review *model.Review) bool) (model.Review, bool, error) {
+	reviewModel := model.Review{}
+	changed := false
+
+	errTx := rcr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&reviewModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		if !update(&reviewModel) {
+			return nil
+		}
+		changed = true
+
+		result = tx.Save(&reviewModel)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return refreshDoctorRating(tx, reviewModel.DoctorID)
+	})
+	if errTx != nil {
+		return model.Review{}, false, errTx
+	}
+
+	return reviewModel, changed, nil
+}
+
+// refreshDoctorRating recomputes the rating shown on the doctor profile from
+// the visible reviews, so it never drifts from them.
+func refreshDoctorRating(tx *gorm.DB, doctorID string) error {
+	return tx.Exec(`UPDATE doctors SET
+			rating_average = COALESCE((SELECT ROUND(AVG(rating)::numeric, 2) FROM reviews WHERE doctor_id = ? AND hidden = false), 0),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE doctor_id = ? AND hidden = false)
+		WHERE id = ?`, doctorID, doctorID, doctorID).Error
+}
+
+// clearDoctorCache drops the cached profile of the doctor and the cached
+// doctor lists, which carry its rating.
+func (rcr *reviewCommandRepository) clearDoctorCache(doctorID string) {
+	ctx := context.Background()
+	rcr.rdb.Del(ctx, "doctor:"+doctorID)
+
+	// every cached doctor list shows the rating and the rating sorts order
+	// by it, so all of them are dropped
+	keys := []string{}
+	iter := rcr.rdb.Scan(ctx, 0, "doctors:all:*", 100).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		logrus.Errorf("failed to scan doctor list cache: %v", err)
+	}
+
+	if len(keys) > 0 {
+		rcr.rdb.Del(ctx, keys...)
+	}
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"talkspace-api/modules/review/entity"
+	"time"
+)
+
+type ReviewCommandRepositoryInterface interface {
+	CreateReview(review entity.Review) (entity.Review, error)
+	UpdateReviewReply(id string, reply string, at time.Time) (entity.Review, error)
+	UpdateReviewVisibility(id string, hidden bool, hiddenBy string, reason string, at time.Time) (entity.Review, error)
+}
+
+type ReviewQueryRepositoryInterface interface {
+	GetReviewByID(id string) (entity.Review, error)
+	GetReviewByConsultationID(consultationID string) (entity.Review, error)
+	GetReviewsByDoctorID(doctorID string, includeHidden bool, page, limit int) ([]entity.Review, int, error)
+}
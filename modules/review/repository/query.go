@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/review/entity"
+	"talkspace-api/modules/review/model"
+	"talkspace-api/utils/constant"
+
+	"gorm.io/gorm"
+)
+
+type reviewQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewQueryRepository(db *gorm.DB) ReviewQueryRepositoryInterface {
+	return &reviewQueryRepository{
+		db: db,
+	}
+}
+
+func (rqr *reviewQueryRepository) GetReviewByID(id string) (entity.Review, error) {
+	return rqr.getReview("id = ?", id)
+}
+
+func (rqr *reviewQueryRepository) GetReviewByConsultationID(consultationID string) (entity.Review, error) {
+	return rqr.getReview("consultation_id = ?", consultationID)
+}
+
+func (rqr *reviewQueryRepository) getReview(condition string, id string) (entity.Review, error) {
+	reviewModel := model.Review{}
+	result := rqr.db.Where(condition, id).First(&reviewModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Review{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Review{}, result.Error
+	}
+
+	reviewEntity := entity.ReviewModelToReviewEntity(reviewModel)
+
+	return reviewEntity, nil
+}
+
+func (rqr *reviewQueryRepository) GetReviewsByDoctorID(doctorID string, includeHidden bool, page, limit int) ([]entity.Review, int, error) {
+	offset := (page - 1) * limit
+
+	byDoctor := func(db *gorm.DB) *gorm.DB {
+		db = db.Where("doctor_id = ?", doctorID)
+		if !includeHidden {
+			db = db.Where("hidden = ?", false)
+		}
+		return db
+	}
+
+	var totalItems int64
+	result := rqr.db.Model(&model.Review{}).Scopes(byDoctor).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var reviewModels []model.Review
+	result = rqr.db.Scopes(byDoctor).Order("created_at DESC").Offset(offset).Limit(limit).Find(&reviewModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	reviews := entity.ListReviewModelToReviewEntity(reviewModels)
+
+	return reviews, int(totalItems), nil
+}
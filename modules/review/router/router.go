@@ -0,0 +1,31 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/modules/review/handler"
+	"talkspace-api/modules/review/repository"
+	"talkspace-api/modules/review/usecase"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func ReviewRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	reviewQueryRepository := repository.NewReviewQueryRepository(db)
+	reviewCommandRepository := repository.NewReviewCommandRepository(db, rdb)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+
+	reviewQueryUsecase := usecase.NewReviewQueryUsecase(reviewQueryRepository)
+	reviewCommandUsecase := usecase.NewReviewCommandUsecase(reviewCommandRepository, reviewQueryRepository, consultationQueryRepository)
+
+	reviewHandler := handler.NewReviewHandler(reviewCommandUsecase, reviewQueryUsecase)
+
+	e.GET("/doctors/:doctor_id", reviewHandler.GetReviewsByDoctorID)
+	e.GET("/moderation/doctors/:doctor_id", reviewHandler.GetModerationReviewsByDoctorID, middlewares.JWTMiddleware(false))
+
+	e.POST("", reviewHandler.CreateReview, middlewares.JWTMiddleware(false))
+	e.PATCH("/:review_id/reply", reviewHandler.ReplyReview, middlewares.JWTMiddleware(false))
+	e.PATCH("/:review_id/visibility", reviewHandler.UpdateReviewVisibility, middlewares.JWTMiddleware(false))
+}
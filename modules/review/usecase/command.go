@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/modules/review/entity"
+	"talkspace-api/modules/review/repository"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type reviewCommandUsecase struct {
+	reviewCommandRepository     repository.ReviewCommandRepositoryInterface
+	reviewQueryRepository       repository.ReviewQueryRepositoryInterface
+	consultationQueryRepository cr.ConsultationQueryRepositoryInterface
+}
+
+func NewReviewCommandUsecase(rcr repository.ReviewCommandRepositoryInterface, rqr repository.ReviewQueryRepositoryInterface, cqr cr.ConsultationQueryRepositoryInterface) ReviewCommandUsecaseInterface {
+	return &reviewCommandUsecase{
+		reviewCommandRepository:     rcr,
+		reviewQueryRepository:       rqr,
+		consultationQueryRepository: cqr,
+	}
+}
+
+// CreateReview lets a user rate a consultation they took part in once it is
+// completed. The doctor is taken from the consultation, never the request.
+func (rcu *reviewCommandUsecase) CreateReview(review entity.Review) (entity.Review, error) {
+	if review.Rating < entity.RatingMin || review.Rating > entity.RatingMax {
+		return entity.Review{}, errors.New(constant.ERROR_RATING_RANGE)
+	}
+
+	review.Comment = strings.TrimSpace(review.Comment)
+	if len(review.Comment) > entity.TextMaxLength {
+		return entity.Review{}, errors.New(constant.ERROR_REVIEW_TOO_LONG)
+	}
+
+	consultation, errGetID := rcu.consultationQueryRepository.GetConsultationByID(review.ConsultationID)
+	if errGetID != nil {
+		return entity.Review{}, errGetID
+	}
+
+	if consultation.UserID != review.UserID {
+		return entity.Review{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	if consultation.Status != constant.CONSULTATION_COMPLETED {
+		return entity.Review{}, errors.New(constant.ERROR_REVIEW_STATUS)
+	}
+
+	_, errGet := rcu.reviewQueryRepository.GetReviewByConsultationID(review.ConsultationID)
+	if errGet == nil {
+		return entity.Review{}, errors.New(constant.ERROR_REVIEW_EXIST)
+	}
+	if errGet.Error() != constant.ERROR_ID_NOTFOUND {
+		return entity.Review{}, errGet
+	}
+
+	review.DoctorID = consultation.DoctorID
+
+	reviewEntity, errCreate := rcu.reviewCommandRepository.CreateReview(review)
+	if errCreate != nil {
+		return entity.Review{}, errCreate
+	}
+
+	return reviewEntity, nil
+}
+
+// ReplyReview sets the public answer of the reviewed doctor. An empty reply
+// removes it.
+func (rcu *reviewCommandUsecase) ReplyReview(id string, doctorID string, reply string) (entity.Review, error) {
+	reply = strings.TrimSpace(reply)
+	if len(reply) > entity.TextMaxLength {
+		return entity.Review{}, errors.New(constant.ERROR_REVIEW_TOO_LONG)
+	}
+
+	review, errGetID := rcu.reviewQueryRepository.GetReviewByID(id)
+	if errGetID != nil {
+		return entity.Review{}, errGetID
+	}
+
+	if review.DoctorID != doctorID {
+		return entity.Review{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	reviewEntity, errUpdate := rcu.reviewCommandRepository.UpdateReviewReply(id, reply, time.Now())
+	if errUpdate != nil {
+		return entity.Review{}, errUpdate
+	}
+
+	return reviewEntity, nil
+}
+
+func (rcu *reviewCommandUsecase) UpdateReviewVisibility(id string, adminID string, hidden bool, reason string) (entity.Review, error) {
+	reason = strings.TrimSpace(reason)
+	if len(reason) > entity.TextMaxLength {
+		return entity.Review{}, errors.New(constant.ERROR_REVIEW_TOO_LONG)
+	}
+
+	reviewEntity, errUpdate := rcu.reviewCommandRepository.UpdateReviewVisibility(id, hidden, adminID, reason, time.Now())
+	if errUpdate != nil {
+		return entity.Review{}, errUpdate
+	}
+
+	return reviewEntity, nil
+}
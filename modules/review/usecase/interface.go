@@ -0,0 +1,13 @@
+package usecase
+
+import "talkspace-api/modules/review/entity"
+
+type ReviewCommandUsecaseInterface interface {
+	CreateReview(review entity.Review) (entity.Review, error)
+	ReplyReview(id string, doctorID string, reply string) (entity.Review, error)
+	UpdateReviewVisibility(id string, adminID string, hidden bool, reason string) (entity.Review, error)
+}
+
+type ReviewQueryUsecaseInterface interface {
+	GetReviewsByDoctorID(doctorID string, includeHidden bool, page, limit int) ([]entity.Review, int, error)
+}
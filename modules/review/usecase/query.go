@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/review/entity"
+	"talkspace-api/modules/review/repository"
+	"talkspace-api/utils/constant"
+)
+
+type reviewQueryUsecase struct {
+	reviewQueryRepository repository.ReviewQueryRepositoryInterface
+}
+
+func NewReviewQueryUsecase(rqr repository.ReviewQueryRepositoryInterface) ReviewQueryUsecaseInterface {
+	return &reviewQueryUsecase{
+		reviewQueryRepository: rqr,
+	}
+}
+
+func (rqu *reviewQueryUsecase) GetReviewsByDoctorID(doctorID string, includeHidden bool, page, limit int) ([]entity.Review, int, error) {
+	if doctorID == "" {
+		return nil, 0, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	reviews, totalItems, errGet := rqu.reviewQueryRepository.GetReviewsByDoctorID(doctorID, includeHidden, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return reviews, totalItems, nil
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"talkspace-api/modules/subscription/entity"
+	te "talkspace-api/modules/transaction/entity"
+	"time"
+)
+
+// Request
+func PlanRequestToPlanEntity(request PlanRequest) entity.Plan {
+	status := true
+	if request.Status != nil {
+		status = *request.Status
+	}
+
+	return entity.Plan{
+		Code:           request.Code,
+		Name:           request.Name,
+		Description:    request.Description,
+		Price:          request.Price,
+		DurationMonths: request.DurationMonths,
+		GraceDays:      request.GraceDays,
+		Status:         status,
+	}
+}
+
+// Response
+func PlanEntityToPlanResponse(response entity.Plan) PlanResponse {
+	return PlanResponse{
+		ID:             response.ID,
+		Code:           response.Code,
+		Name:           response.Name,
+		Description:    response.Description,
+		Price:          response.Price,
+		DurationMonths: response.DurationMonths,
+		GraceDays:      response.GraceDays,
+		Status:         response.Status,
+	}
+}
+
+func ListPlanEntityToPlanResponse(response []entity.Plan) []PlanResponse {
+	planResponses := []PlanResponse{}
+	for _, plan := range response {
+		planResponse := PlanEntityToPlanResponse(plan)
+		planResponses = append(planResponses, planResponse)
+	}
+	return planResponses
+}
+
+func SubscriptionEntityToSubscriptionResponse(response entity.Subscription) SubscriptionResponse {
+	var premiumUntil *time.Time
+	if response.PeriodEnd != nil {
+		until := response.PremiumUntil()
+		premiumUntil = &until
+	}
+
+	return SubscriptionResponse{
+		ID:           response.ID,
+		UserID:       response.UserID,
+		Plan:         PlanEntityToPlanResponse(response.Plan),
+		Status:       response.CurrentStatus(time.Now()),
+		PeriodStart:  response.PeriodStart,
+		PeriodEnd:    response.PeriodEnd,
+		PremiumUntil: premiumUntil,
+		CancelledAt:  response.CancelledAt,
+		CreatedAt:    response.CreatedAt,
+	}
+}
+
+func ListSubscriptionEntityToSubscriptionResponse(response []entity.Subscription) []SubscriptionResponse {
+	subscriptionResponses := []SubscriptionResponse{}
+	for _, subscription := range response {
+		subscriptionResponse := SubscriptionEntityToSubscriptionResponse(subscription)
+		subscriptionResponses = append(subscriptionResponses, subscriptionResponse)
+	}
+	return subscriptionResponses
+}
+
+func SubscriptionEntityToSubscriptionPaymentResponse(subscription entity.Subscription, transaction te.Transaction) SubscriptionPaymentResponse {
+	return SubscriptionPaymentResponse{
+		Subscription:    SubscriptionEntityToSubscriptionResponse(subscription),
+		TransactionID:   transaction.ID,
+		TransactionCode: transaction.Code,
+		Amount:          transaction.Amount,
+		PaymentToken:    transaction.PaymentToken,
+		PaymentURL:      transaction.PaymentURL,
+	}
+}
@@ -0,0 +1,17 @@
+package dto
+
+type (
+	PlanRequest struct {
+		Code           string  `json:"code" form:"code"`
+		Name           string  `json:"name" form:"name"`
+		Description    string  `json:"description" form:"description"`
+		Price          float64 `json:"price" form:"price"`
+		DurationMonths int     `json:"duration_months" form:"duration_months"`
+		GraceDays      int     `json:"grace_days" form:"grace_days"`
+		Status         *bool   `json:"status" form:"status"`
+	}
+
+	SubscriptionCreateRequest struct {
+		PlanID string `json:"plan_id" form:"plan_id"`
+	}
+)
@@ -0,0 +1,37 @@
+package dto
+
+import "time"
+
+type (
+	PlanResponse struct {
+		ID             string  `json:"id"`
+		Code           string  `json:"code"`
+		Name           string  `json:"name"`
+		Description    string  `json:"description"`
+		Price          float64 `json:"price"`
+		DurationMonths int     `json:"duration_months"`
+		GraceDays      int     `json:"grace_days"`
+		Status         bool    `json:"status"`
+	}
+
+	SubscriptionResponse struct {
+		ID           string       `json:"id"`
+		UserID       string       `json:"user_id"`
+		Plan         PlanResponse `json:"plan"`
+		Status       string       `json:"status"`
+		PeriodStart  *time.Time   `json:"period_start"`
+		PeriodEnd    *time.Time   `json:"period_end"`
+		PremiumUntil *time.Time   `json:"premium_until"`
+		CancelledAt  *time.Time   `json:"cancelled_at"`
+		CreatedAt    time.Time    `json:"created_at"`
+	}
+
+	SubscriptionPaymentResponse struct {
+		Subscription    SubscriptionResponse `json:"subscription"`
+		TransactionID   string               `json:"transaction_id"`
+		TransactionCode string               `json:"transaction_code"`
+		Amount          float64              `json:"amount"`
+		PaymentToken    string               `json:"payment_token"`
+		PaymentURL      string               `json:"payment_url"`
+	}
+)
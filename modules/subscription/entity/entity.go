@@ -0,0 +1,99 @@
+package entity
+
+import (
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type Plan struct {
+	ID             string
+	Code           string
+	Name           string
+	Description    string
+	Price          float64
+	DurationMonths int
+	GraceDays      int
+	Status         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+type Subscription struct {
+	ID                string
+	UserID            string
+	PlanID            string
+	Plan              Plan
+	Status            string
+	PeriodStart       *time.Time
+	PeriodEnd         *time.Time
+	GraceDays         int
+	LastTransactionID string
+	CancelledAt       *time.Time
+	RemindedPeriodEnd *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         *time.Time
+}
+
+// CurrentStatus derives the lifecycle state of a subscription at now. Only
+// pending, active and cancelled are written by users; grace and expired
+// follow from the paid period so they never need to be written by hand. The
+// scheduler stores expired once access has ended.
+func (s Subscription) CurrentStatus(now time.Time) string {
+	if s.Status == constant.SUBSCRIPTION_EXPIRED {
+		return constant.SUBSCRIPTION_EXPIRED
+	}
+
+	if s.PeriodEnd == nil {
+		if s.Status == constant.SUBSCRIPTION_CANCELLED {
+			return constant.SUBSCRIPTION_CANCELLED
+		}
+		return constant.SUBSCRIPTION_PENDING
+	}
+
+	if now.Before(*s.PeriodEnd) {
+		if s.CancelledAt != nil {
+			return constant.SUBSCRIPTION_CANCELLED
+		}
+		return constant.SUBSCRIPTION_ACTIVE
+	}
+
+	if s.CancelledAt == nil && now.Before(s.GraceEnd()) {
+		return constant.SUBSCRIPTION_GRACE
+	}
+
+	return constant.SUBSCRIPTION_EXPIRED
+}
+
+// GraceEnd is the end of the paid period plus the grace days of the plan at
+// the time of the last payment.
+func (s Subscription) GraceEnd() time.Time {
+	if s.PeriodEnd == nil {
+		return time.Time{}
+	}
+	return s.PeriodEnd.AddDate(0, 0, s.GraceDays)
+}
+
+// PremiumUntil is the moment premium access ends. A cancelled subscription
+// keeps its paid period but loses the grace period.
+func (s Subscription) PremiumUntil() time.Time {
+	if s.PeriodEnd == nil {
+		return time.Time{}
+	}
+	if s.CancelledAt != nil {
+		return *s.PeriodEnd
+	}
+	return s.GraceEnd()
+}
+
+// NextPeriod returns the period covered by a new payment. Paying while
+// premium access is still running extends the current period from its end,
+// otherwise a fresh period starts now.
+func (s Subscription) NextPeriod(durationMonths int, now time.Time) (time.Time, time.Time) {
+	if s.PeriodStart != nil && s.PeriodEnd != nil && now.Before(s.PremiumUntil()) {
+		return *s.PeriodStart, s.PeriodEnd.AddDate(0, durationMonths, 0)
+	}
+
+	return now, now.AddDate(0, durationMonths, 0)
+}
@@ -0,0 +1,94 @@
+package entity
+
+import "talkspace-api/modules/subscription/model"
+
+func PlanEntityToPlanModel(planEntity Plan) model.Plan {
+	planModel := model.Plan{
+		ID:             planEntity.ID,
+		Code:           planEntity.Code,
+		Name:           planEntity.Name,
+		Description:    planEntity.Description,
+		Price:          planEntity.Price,
+		DurationMonths: planEntity.DurationMonths,
+		GraceDays:      planEntity.GraceDays,
+		Status:         planEntity.Status,
+		CreatedAt:      planEntity.CreatedAt,
+		UpdatedAt:      planEntity.UpdatedAt,
+		DeletedAt:      planEntity.DeletedAt,
+	}
+	return planModel
+}
+
+func PlanModelToPlanEntity(planModel model.Plan) Plan {
+	planEntity := Plan{
+		ID:             planModel.ID,
+		Code:           planModel.Code,
+		Name:           planModel.Name,
+		Description:    planModel.Description,
+		Price:          planModel.Price,
+		DurationMonths: planModel.DurationMonths,
+		GraceDays:      planModel.GraceDays,
+		Status:         planModel.Status,
+		CreatedAt:      planModel.CreatedAt,
+		UpdatedAt:      planModel.UpdatedAt,
+		DeletedAt:      planModel.DeletedAt,
+	}
+	return planEntity
+}
+
+func ListPlanModelToPlanEntity(planModels []model.Plan) []Plan {
+	listPlanEntity := []Plan{}
+	for _, plan := range planModels {
+		planEntity := PlanModelToPlanEntity(plan)
+		listPlanEntity = append(listPlanEntity, planEntity)
+	}
+	return listPlanEntity
+}
+
+func SubscriptionEntityToSubscriptionModel(subscriptionEntity Subscription) model.Subscription {
+	subscriptionModel := model.Subscription{
+		ID:                subscriptionEntity.ID,
+		UserID:            subscriptionEntity.UserID,
+		PlanID:            subscriptionEntity.PlanID,
+		Status:            subscriptionEntity.Status,
+		PeriodStart:       subscriptionEntity.PeriodStart,
+		PeriodEnd:         subscriptionEntity.PeriodEnd,
+		GraceDays:         subscriptionEntity.GraceDays,
+		LastTransactionID: subscriptionEntity.LastTransactionID,
+		CancelledAt:       subscriptionEntity.CancelledAt,
+		RemindedPeriodEnd: subscriptionEntity.RemindedPeriodEnd,
+		CreatedAt:         subscriptionEntity.CreatedAt,
+		UpdatedAt:         subscriptionEntity.UpdatedAt,
+		DeletedAt:         subscriptionEntity.DeletedAt,
+	}
+	return subscriptionModel
+}
+
+func SubscriptionModelToSubscriptionEntity(subscriptionModel model.Subscription) Subscription {
+	subscriptionEntity := Subscription{
+		ID:                subscriptionModel.ID,
+		UserID:            subscriptionModel.UserID,
+		PlanID:            subscriptionModel.PlanID,
+		Plan:              PlanModelToPlanEntity(subscriptionModel.Plan),
+		Status:            subscriptionModel.Status,
+		PeriodStart:       subscriptionModel.PeriodStart,
+		PeriodEnd:         subscriptionModel.PeriodEnd,
+		GraceDays:         subscriptionModel.GraceDays,
+		LastTransactionID: subscriptionModel.LastTransactionID,
+		CancelledAt:       subscriptionModel.CancelledAt,
+		RemindedPeriodEnd: subscriptionModel.RemindedPeriodEnd,
+		CreatedAt:         subscriptionModel.CreatedAt,
+		UpdatedAt:         subscriptionModel.UpdatedAt,
+		DeletedAt:         subscriptionModel.DeletedAt,
+	}
+	return subscriptionEntity
+}
+
+func ListSubscriptionModelToSubscriptionEntity(subscriptionModels []model.Subscription) []Subscription {
+	listSubscriptionEntity := []Subscription{}
+	for _, subscription := range subscriptionModels {
+		subscriptionEntity := SubscriptionModelToSubscriptionEntity(subscription)
+		listSubscriptionEntity = append(listSubscriptionEntity, subscriptionEntity)
+	}
+	return listSubscriptionEntity
+}
@@ -0,0 +1,278 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/subscription/dto"
+	"talkspace-api/modules/subscription/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type subscriptionHandler struct {
+	subscriptionCommandUsecase usecase.SubscriptionCommandUsecaseInterface
+	subscriptionQueryUsecase   usecase.SubscriptionQueryUsecaseInterface
+}
+
+func NewSubscriptionHandler(scu usecase.SubscriptionCommandUsecaseInterface, squ usecase.SubscriptionQueryUsecaseInterface) *subscriptionHandler {
+	return &subscriptionHandler{
+		subscriptionCommandUsecase: scu,
+		subscriptionQueryUsecase:   squ,
+	}
+}
+
+// Query
+func (sh *subscriptionHandler) GetAllPlans(c echo.Context) error {
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	plans, errGet := sh.subscriptionQueryUsecase.GetAllPlans(role == constant.ADMIN)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(plans) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	planResponses := dto.ListPlanEntityToPlanResponse(plans)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, planResponses))
+}
+
+func (sh *subscriptionHandler) GetSubscriptionByID(c echo.Context) error {
+	subscriptionIDParam := c.Param("subscription_id")
+	if subscriptionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	subscription, errGetID := sh.subscriptionQueryUsecase.GetSubscriptionByID(subscriptionIDParam)
+	if errGetID != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGetID.Error()))
+	}
+
+	if role != constant.ADMIN && (role != constant.USER || subscription.UserID != tokenID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	subscriptionResponse := dto.SubscriptionEntityToSubscriptionResponse(subscription)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, subscriptionResponse))
+}
+
+func (sh *subscriptionHandler) GetCurrentSubscription(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenUserID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN && (role != constant.USER || userIDParam != tokenUserID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	subscription, errGet := sh.subscriptionQueryUsecase.GetCurrentSubscription(userIDParam)
+	if errGet != nil {
+		if errGet.Error() == constant.ERROR_DATA_NOTFOUND {
+			return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGet.Error()))
+	}
+
+	subscriptionResponse := dto.SubscriptionEntityToSubscriptionResponse(subscription)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, subscriptionResponse))
+}
+
+func (sh *subscriptionHandler) GetSubscriptionsByUserID(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenUserID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN && (role != constant.USER || userIDParam != tokenUserID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	subscriptions, totalItems, errGet := sh.subscriptionQueryUsecase.GetSubscriptionsByUserID(userIDParam, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(subscriptions) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	subscriptionResponses := dto.ListSubscriptionEntityToSubscriptionResponse(subscriptions)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		subscriptionResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Command
+func (sh *subscriptionHandler) CreatePlan(c echo.Context) error {
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	planRequest := dto.PlanRequest{}
+
+	errBind := c.Bind(&planRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	planEntity := dto.PlanRequestToPlanEntity(planRequest)
+
+	plan, errCreate := sh.subscriptionCommandUsecase.CreatePlan(planEntity)
+	if errCreate != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCreate.Error()))
+	}
+
+	planResponse := dto.PlanEntityToPlanResponse(plan)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, planResponse))
+}
+
+func (sh *subscriptionHandler) UpdatePlan(c echo.Context) error {
+	planIDParam := c.Param("plan_id")
+	if planIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	planRequest := dto.PlanRequest{}
+
+	errBind := c.Bind(&planRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	planEntity := dto.PlanRequestToPlanEntity(planRequest)
+
+	plan, errUpdate := sh.subscriptionCommandUsecase.UpdatePlan(planIDParam, planEntity)
+	if errUpdate != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errUpdate.Error()))
+	}
+
+	planResponse := dto.PlanEntityToPlanResponse(plan)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, planResponse))
+}
+
+func (sh *subscriptionHandler) CreateSubscription(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	subscriptionRequest := dto.SubscriptionCreateRequest{}
+
+	errBind := c.Bind(&subscriptionRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	subscription, transaction, errCreate := sh.subscriptionCommandUsecase.CreateSubscription(userID, subscriptionRequest.PlanID)
+	if errCreate != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCreate.Error()))
+	}
+
+	subscriptionResponse := dto.SubscriptionEntityToSubscriptionPaymentResponse(subscription, transaction)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_SUBSCRIPTION, subscriptionResponse))
+}
+
+func (sh *subscriptionHandler) RenewSubscription(c echo.Context) error {
+	subscriptionIDParam := c.Param("subscription_id")
+	if subscriptionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	subscription, transaction, errRenew := sh.subscriptionCommandUsecase.RenewSubscription(subscriptionIDParam, userID)
+	if errRenew != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errRenew.Error()))
+	}
+
+	subscriptionResponse := dto.SubscriptionEntityToSubscriptionPaymentResponse(subscription, transaction)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_RENEWED, subscriptionResponse))
+}
+
+func (sh *subscriptionHandler) CancelSubscription(c echo.Context) error {
+	subscriptionIDParam := c.Param("subscription_id")
+	if subscriptionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	subscription, errCancel := sh.subscriptionCommandUsecase.CancelSubscription(subscriptionIDParam, userID)
+	if errCancel != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errCancel.Error()))
+	}
+
+	subscriptionResponse := dto.SubscriptionEntityToSubscriptionResponse(subscription)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_CANCELLED, subscriptionResponse))
+}
@@ -0,0 +1,18 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type SubscriptionHandlerInterface interface {
+	// Query
+	GetAllPlans(c echo.Context) error
+	GetSubscriptionByID(c echo.Context) error
+	GetCurrentSubscription(c echo.Context) error
+	GetSubscriptionsByUserID(c echo.Context) error
+
+	// Command
+	CreatePlan(c echo.Context) error
+	UpdatePlan(c echo.Context) error
+	CreateSubscription(c echo.Context) error
+	RenewSubscription(c echo.Context) error
+	CancelSubscription(c echo.Context) error
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (p *Plan) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	p.ID = UUID.String()
+	return nil
+}
+
+func (s *Subscription) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	s.ID = UUID.String()
+
+	if s.Status == "" {
+		s.Status = "pending"
+	}
+
+	return nil
+}
+
+func (sp *SubscriptionPayment) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	sp.ID = UUID.String()
+	return nil
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+type Plan struct {
+	ID             string `gorm:"primarykey"`
+	Code           string `gorm:"uniqueIndex;not null"`
+	Name           string `gorm:"not null"`
+	Description    string
+	Price          float64 `gorm:"not null"`
+	DurationMonths int     `gorm:"not null"`
+	GraceDays      int     `gorm:"not null;default:0"`
+	Status         bool    `gorm:"not null;default:true"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time `gorm:"index"`
+}
+
+type Subscription struct {
+	ID                string `gorm:"primarykey"`
+	UserID            string `gorm:"index;not null"`
+	PlanID            string `gorm:"not null"`
+	Plan              Plan   `gorm:"foreignKey:PlanID"`
+	Status            string `gorm:"type:varchar(20);not null;default:'pending'"`
+	PeriodStart       *time.Time
+	PeriodEnd         *time.Time
+	GraceDays         int
+	LastTransactionID string
+	CancelledAt       *time.Time
+	RemindedPeriodEnd *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	DeletedAt         *time.Time `gorm:"index"`
+}
+
+// SubscriptionPayment records a paid transaction that has been applied to a
+// subscription, so a payment is never counted towards a period twice.
+type SubscriptionPayment struct {
+	ID             string `gorm:"primarykey"`
+	SubscriptionID string `gorm:"uniqueIndex:idx_subscription_payment;not null"`
+	TransactionID  string `gorm:"uniqueIndex:idx_subscription_payment;not null"`
+	CreatedAt      time.Time
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"talkspace-api/modules/subscription/entity"
+	"talkspace-api/modules/subscription/model"
+	um "talkspace-api/modules/user/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type subscriptionCommandRepository struct {
+	db  *gorm.DB
+	rdb *redis.Client
+}
+
+func NewSubscriptionCommandRepository(db *gorm.DB, rdb *redis.Client) SubscriptionCommandRepositoryInterface {
+	return &subscriptionCommandRepository{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+func (scr *subscriptionCommandRepository) CreatePlan(plan entity.Plan) (entity.Plan, error) {
+	planModel := entity.PlanEntityToPlanModel(plan)
+
+	result := scr.db.Create(&planModel)
+	if result.Error != nil {
+		return entity.Plan{}, result.Error
+	}
+
+	planEntity := entity.PlanModelToPlanEntity(planModel)
+
+	return planEntity, nil
+}
+
+func (scr *subscriptionCommandRepository) UpdatePlan(id string, plan entity.Plan) (entity.Plan, error) {
+	planModel := entity.PlanEntityToPlanModel(plan)
+
+	result := scr.db.Model(&model.Plan{}).Where("id = ?", id).
+		Select("name", "description", "price", "duration_months", "grace_days", "status").
+		Updates(&planModel)
+	if result.Error != nil {
+		return entity.Plan{}, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.Plan{}, errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	result = scr.db.Where("id = ?", id).First(&planModel)
+	if result.Error != nil {
+		return entity.Plan{}, result.Error
+	}
+
+	planEntity := entity.PlanModelToPlanEntity(planModel)
+
+	return planEntity, nil
+}
+
+func (scr *subscriptionCommandRepository) CreateSubscription(subscription entity.Subscription) (entity.Subscription, error) {
+	subscriptionModel := entity.SubscriptionEntityToSubscriptionModel(subscription)
+
+	result := scr.db.Create(&subscriptionModel)
+	if result.Error != nil {
+		return entity.Subscription{}, result.Error
+	}
+
+	result = scr.db.Preload("Plan").Where("id = ?", subscriptionModel.ID).First(&subscriptionModel)
+	if result.Error != nil {
+		return entity.Subscription{}, result.Error
+	}
+
+	subscriptionEntity := entity.SubscriptionModelToSubscriptionEntity(subscriptionModel)
+
+	return subscriptionEntity, nil
+}
+
+// ActivateSubscription applies a settled payment to a subscription. Applied
+// transactions are recorded, so re-syncing or replaying the notification of
+// any earlier payment never extends the period again.
+func (scr *subscriptionCommandRepository) ActivateSubscription(id string, transactionID string) (entity.Subscription, error) {
+	return scr.updateSubscriptionTx(id, func(tx *gorm.DB, subscription *entity.Subscription, now time.Time) (bool, error) {
+		var applied int64
+		result := tx.Model(&model.SubscriptionPayment{}).
+			Where("subscription_id = ? AND transaction_id = ?", subscription.ID, transactionID).
+			Count(&applied)
+		if result.Error != nil {
+			return false, result.Error
+		}
+
+		if applied > 0 || subscription.LastTransactionID == transactionID {
+			return false, nil
+		}
+
+		result = tx.Create(&model.SubscriptionPayment{
+			SubscriptionID: subscription.ID,
+			TransactionID:  transactionID,
+		})
+		if result.Error != nil {
+			return false, result.Error
+		}
+
+		periodStart, periodEnd := subscription.NextPeriod(subscription.Plan.DurationMonths, now)
+
+		subscription.Status = constant.SUBSCRIPTION_ACTIVE
+		subscription.PeriodStart = &periodStart
+		subscription.PeriodEnd = &periodEnd
+		subscription.GraceDays = subscription.Plan.GraceDays
+		subscription.LastTransactionID = transactionID
+		subscription.CancelledAt = nil
+
+		return true, nil
+	})
+}
+
+// CancelSubscription stops a subscription from being renewed. Paid time is
+// kept until the end of the period but the grace period is dropped.
+func (scr *subscriptionCommandRepository) CancelSubscription(id string) (entity.Subscription, error) {
+	return scr.updateSubscription(id, func(subscription *entity.Subscription, now time.Time) bool {
+		subscription.Status = constant.SUBSCRIPTION_CANCELLED
+		subscription.CancelledAt = &now
+		return true
+	})
+}
+
+// RevokeSubscription ends premium access immediately, used when the payment
+// behind the current period is refunded.
+func (scr *subscriptionCommandRepository) RevokeSubscription(id string) (entity.Subscription, error) {
+	return scr.updateSubscription(id, func(subscription *entity.Subscription, now time.Time) bool {
+		subscription.Status = constant.SUBSCRIPTION_CANCELLED
+		subscription.CancelledAt = &now
+		if subscription.PeriodEnd != nil && subscription.PeriodEnd.After(now) {
+			subscription.PeriodEnd = &now
+		}
+		return true
+	})
+}
+
+// ExpireSubscription stores the expired state once premium access has
+// ended. It reports whether this call made the change so the expiry notice is
+// sent once.
+func (scr *subscriptionCommandRepository) ExpireSubscription(id string) (entity.Subscription, bool, error) {
+	changed := false
+
+	subscription, errUpdate := scr.updateSubscription(id, func(subscription *entity.Subscription, now time.Time) bool {
+		if subscription.Status == constant.SUBSCRIPTION_EXPIRED || subscription.CurrentStatus(now) != constant.SUBSCRIPTION_EXPIRED {
+			return false
+		}
+
+		subscription.Status = constant.SUBSCRIPTION_EXPIRED
+		changed = true
+		return true
+	})
+	if errUpdate != nil {
+		return entity.Subscription{}, false, errUpdate
+	}
+
+	return subscription, changed, nil
+}
+
+func (scr *subscriptionCommandRepository) MarkSubscriptionReminded(id string, periodEnd time.Time) error {
+	result := scr.db.Model(&model.Subscription{}).Where("id = ?", id).Update("reminded_period_end", periodEnd)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	return nil
+}
+
+// updateSubscription runs update on a locked subscription row and keeps the
+// user's PremiumExpired in sync with the result so premium checks elsewhere
+// keep reading a single column.
+func (scr *subscriptionCommandRepository) updateSubscription(id string, update func(subscription *entity.Subscription, now time.Time) bool) (entity.Subscription, error) {
+	return scr.updateSubscriptionTx(id, func(tx *gorm.DB, subscription *entity.Subscription, now time.Time) (bool, error) {
+		return update(subscription, now), nil
+	})
+}
+
+// updateSubscriptionTx is updateSubscription for updates that also write
+// other rows in the same transaction.
+func (scr *subscriptionCommandRepository) updateSubscriptionTx(id string, update func(tx *gorm.DB, subscription *entity.Subscription, now time.Time) (bool, error)) (entity.Subscription, error) {
+	subscriptionModel := model.Subscription{}
+	userModel := um.User{}
+
+	errTx := scr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&subscriptionModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		result = tx.Where("id = ?", subscriptionModel.PlanID).First(&subscriptionModel.Plan)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		subscription := entity.SubscriptionModelToSubscriptionEntity(subscriptionModel)
+		updated, errUpdate := update(tx, &subscription, time.Now())
+		if errUpdate != nil {
+			return errUpdate
+		}
+
+		if !updated {
+			return nil
+		}
+
+		plan := subscriptionModel.Plan
+		subscriptionModel = entity.SubscriptionEntityToSubscriptionModel(subscription)
+		subscriptionModel.Plan = plan
+
+		result = tx.Omit("Plan").Save(&subscriptionModel)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Select("id", "email").Where("id = ?", subscription.UserID).First(&userModel)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Model(&um.User{}).Where("id = ?", subscription.UserID).
+			Update("premium_expired", subscription.PremiumUntil()).Error
+	})
+	if errTx != nil {
+		return entity.Subscription{}, errTx
+	}
+
+	if userModel.ID != "" {
+		scr.rdb.Del(context.Background(),
+			"user:"+userModel.ID,
+			"user:id:"+userModel.ID,
+			"user:"+userModel.Email,
+			"user:email:"+userModel.Email,
+		)
+	}
+
+	subscriptionEntity := entity.SubscriptionModelToSubscriptionEntity(subscriptionModel)
+
+	return subscriptionEntity, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"talkspace-api/modules/subscription/entity"
+	"time"
+)
+
+type SubscriptionCommandRepositoryInterface interface {
+	CreatePlan(plan entity.Plan) (entity.Plan, error)
+	UpdatePlan(id string, plan entity.Plan) (entity.Plan, error)
+	CreateSubscription(subscription entity.Subscription) (entity.Subscription, error)
+	ActivateSubscription(id string, transactionID string) (entity.Subscription, error)
+	CancelSubscription(id string) (entity.Subscription, error)
+	RevokeSubscription(id string) (entity.Subscription, error)
+	ExpireSubscription(id string) (entity.Subscription, bool, error)
+	MarkSubscriptionReminded(id string, periodEnd time.Time) error
+}
+
+type SubscriptionQueryRepositoryInterface interface {
+	GetAllPlans(includeInactive bool) ([]entity.Plan, error)
+	GetPlanByID(id string) (entity.Plan, error)
+	GetSubscriptionByID(id string) (entity.Subscription, error)
+	GetLatestSubscriptionByUserID(userID string) (entity.Subscription, error)
+	GetSubscriptionsByUserID(userID string, page, limit int) ([]entity.Subscription, int, error)
+	GetLapsedSubscriptions(now time.Time) ([]entity.Subscription, error)
+	GetSubscriptionsEndingBefore(deadline time.Time) ([]entity.Subscription, error)
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/subscription/entity"
+	"talkspace-api/modules/subscription/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type subscriptionQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionQueryRepository(db *gorm.DB) SubscriptionQueryRepositoryInterface {
+	return &subscriptionQueryRepository{
+		db: db,
+	}
+}
+
+func (sqr *subscriptionQueryRepository) GetAllPlans(includeInactive bool) ([]entity.Plan, error) {
+	query := sqr.db.Order("price ASC")
+	if !includeInactive {
+		query = query.Where("status = ?", true)
+	}
+
+	var planModels []model.Plan
+	result := query.Find(&planModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	plans := entity.ListPlanModelToPlanEntity(planModels)
+
+	return plans, nil
+}
+
+func (sqr *subscriptionQueryRepository) GetPlanByID(id string) (entity.Plan, error) {
+	planModel := model.Plan{}
+	result := sqr.db.Where("id = ?", id).First(&planModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Plan{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Plan{}, result.Error
+	}
+
+	planEntity := entity.PlanModelToPlanEntity(planModel)
+
+	return planEntity, nil
+}
+
+func (sqr *subscriptionQueryRepository) GetSubscriptionByID(id string) (entity.Subscription, error) {
+	subscriptionModel := model.Subscription{}
+	result := sqr.db.Preload("Plan").Where("id = ?", id).First(&subscriptionModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Subscription{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Subscription{}, result.Error
+	}
+
+	subscriptionEntity := entity.SubscriptionModelToSubscriptionEntity(subscriptionModel)
+
+	return subscriptionEntity, nil
+}
+
+func (sqr *subscriptionQueryRepository) GetLatestSubscriptionByUserID(userID string) (entity.Subscription, error) {
+	subscriptionModel := model.Subscription{}
+	result := sqr.db.Preload("Plan").Where("user_id = ?", userID).Order("created_at DESC").First(&subscriptionModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Subscription{}, errors.New(constant.ERROR_DATA_NOTFOUND)
+		}
+		return entity.Subscription{}, result.Error
+	}
+
+	subscriptionEntity := entity.SubscriptionModelToSubscriptionEntity(subscriptionModel)
+
+	return subscriptionEntity, nil
+}
+
+func (sqr *subscriptionQueryRepository) GetSubscriptionsByUserID(userID string, page, limit int) ([]entity.Subscription, int, error) {
+	offset := (page - 1) * limit
+
+	var totalItems int64
+	result := sqr.db.Model(&model.Subscription{}).Where("user_id = ?", userID).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var subscriptionModels []model.Subscription
+	result = sqr.db.Preload("Plan").Where("user_id = ?", userID).Order("created_at DESC").Offset(offset).Limit(limit).Find(&subscriptionModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	subscriptions := entity.ListSubscriptionModelToSubscriptionEntity(subscriptionModels)
+
+	return subscriptions, int(totalItems), nil
+}
+
+// GetLapsedSubscriptions returns subscriptions whose premium access has ended
+// but that are not stored as expired yet.
+func (sqr *subscriptionQueryRepository) GetLapsedSubscriptions(now time.Time) ([]entity.Subscription, error) {
+	var subscriptionModels []model.Subscription
+	result := sqr.db.Preload("Plan").
+		Where("status IN ? AND period_end IS NOT NULL", []string{constant.SUBSCRIPTION_ACTIVE, constant.SUBSCRIPTION_CANCELLED}).
+		Where("(cancelled_at IS NOT NULL AND period_end <= ?) OR (cancelled_at IS NULL AND period_end + make_interval(days => grace_days) <= ?)", now, now).
+		Find(&subscriptionModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	subscriptions := entity.ListSubscriptionModelToSubscriptionEntity(subscriptionModels)
+
+	return subscriptions, nil
+}
+
+// GetSubscriptionsEndingBefore returns active subscriptions whose paid period
+// ends before deadline and whose current period has not been reminded yet.
+func (sqr *subscriptionQueryRepository) GetSubscriptionsEndingBefore(deadline time.Time) ([]entity.Subscription, error) {
+	var subscriptionModels []model.Subscription
+	result := sqr.db.Preload("Plan").
+		Where("status = ? AND cancelled_at IS NULL", constant.SUBSCRIPTION_ACTIVE).
+		Where("period_end > ? AND period_end <= ?", time.Now(), deadline).
+		Where("reminded_period_end IS NULL OR reminded_period_end <> period_end").
+		Find(&subscriptionModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	subscriptions := entity.ListSubscriptionModelToSubscriptionEntity(subscriptionModels)
+
+	return subscriptions, nil
+}
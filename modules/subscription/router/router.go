@@ -0,0 +1,53 @@
+package router
+
+import (
+	"talkspace-api/middlewares"
+	ar "talkspace-api/modules/appointment/repository"
+	cr "talkspace-api/modules/consultation/repository"
+	dr "talkspace-api/modules/doctor/repository"
+	"talkspace-api/modules/subscription/handler"
+	"talkspace-api/modules/subscription/repository"
+	"talkspace-api/modules/subscription/usecase"
+	tr "talkspace-api/modules/transaction/repository"
+	tu "talkspace-api/modules/transaction/usecase"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/helper/midtrans"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func SubscriptionRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	subscriptionQueryRepository := repository.NewSubscriptionQueryRepository(db)
+	subscriptionCommandRepository := repository.NewSubscriptionCommandRepository(db, rdb)
+	transactionQueryRepository := tr.NewTransactionQueryRepository(db, rdb)
+	transactionCommandRepository := tr.NewTransactionCommandRepository(db, rdb)
+	doctorQueryRepository := dr.NewDoctorQueryRepository(db, rdb)
+	userQueryRepository := ur.NewUserQueryRepository(db, rdb)
+	consultationCommandRepository := cr.NewConsultationCommandRepository(db, rdb)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+	appointmentCommandRepository := ar.NewAppointmentCommandRepository(db)
+	paymentGateway := midtrans.NewPaymentGateway()
+
+	transactionCommandUsecase := tu.NewTransactionCommandUsecase(transactionCommandRepository, transactionQueryRepository, doctorQueryRepository, userQueryRepository, consultationCommandRepository, consultationQueryRepository, appointmentCommandRepository, subscriptionCommandRepository, subscriptionQueryRepository, paymentGateway)
+
+	subscriptionQueryUsecase := usecase.NewSubscriptionQueryUsecase(subscriptionCommandRepository, subscriptionQueryRepository)
+	subscriptionCommandUsecase := usecase.NewSubscriptionCommandUsecase(subscriptionCommandRepository, subscriptionQueryRepository, transactionCommandUsecase, userQueryRepository)
+
+	subscriptionHandler := handler.NewSubscriptionHandler(subscriptionCommandUsecase, subscriptionQueryUsecase)
+
+	plans := e.Group("/plans", middlewares.JWTMiddleware(false))
+	plans.GET("", subscriptionHandler.GetAllPlans)
+	plans.POST("", subscriptionHandler.CreatePlan)
+	plans.PUT("/:plan_id", subscriptionHandler.UpdatePlan)
+
+	users := e.Group("/users", middlewares.JWTMiddleware(false))
+	users.GET("/:user_id", subscriptionHandler.GetSubscriptionsByUserID)
+	users.GET("/:user_id/current", subscriptionHandler.GetCurrentSubscription)
+
+	e.POST("", subscriptionHandler.CreateSubscription, middlewares.JWTMiddleware(false))
+	e.GET("/:subscription_id", subscriptionHandler.GetSubscriptionByID, middlewares.JWTMiddleware(false))
+	e.POST("/:subscription_id/renew", subscriptionHandler.RenewSubscription, middlewares.JWTMiddleware(false))
+	e.PATCH("/:subscription_id/cancel", subscriptionHandler.CancelSubscription, middlewares.JWTMiddleware(false))
+}
@@ -0,0 +1,298 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"talkspace-api/modules/subscription/entity"
+	"talkspace-api/modules/subscription/repository"
+	te "talkspace-api/modules/transaction/entity"
+	tu "talkspace-api/modules/transaction/usecase"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/email/mailer"
+	"talkspace-api/utils/validator"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type subscriptionCommandUsecase struct {
+	subscriptionCommandRepository repository.SubscriptionCommandRepositoryInterface
+	subscriptionQueryRepository   repository.SubscriptionQueryRepositoryInterface
+	transactionCommandUsecase     tu.TransactionCommandUsecaseInterface
+	userQueryRepository           ur.UserQueryRepositoryInterface
+}
+
+func NewSubscriptionCommandUsecase(scr repository.SubscriptionCommandRepositoryInterface, sqr repository.SubscriptionQueryRepositoryInterface, tcu tu.TransactionCommandUsecaseInterface, uqr ur.UserQueryRepositoryInterface) SubscriptionCommandUsecaseInterface {
+	return &subscriptionCommandUsecase{
+		subscriptionCommandRepository: scr,
+		subscriptionQueryRepository:   sqr,
+		transactionCommandUsecase:     tcu,
+		userQueryRepository:           uqr,
+	}
+}
+
+func (scu *subscriptionCommandUsecase) CreatePlan(plan entity.Plan) (entity.Plan, error) {
+	errEmpty := validator.IsDataEmpty([]string{"code", "name"}, plan.Code, plan.Name)
+	if errEmpty != nil {
+		return entity.Plan{}, errEmpty
+	}
+
+	errPlan := validatePlan(plan)
+	if errPlan != nil {
+		return entity.Plan{}, errPlan
+	}
+
+	plan.Code = strings.ToLower(plan.Code)
+
+	planEntity, errCreate := scu.subscriptionCommandRepository.CreatePlan(plan)
+	if errCreate != nil {
+		return entity.Plan{}, errCreate
+	}
+
+	return planEntity, nil
+}
+
+func (scu *subscriptionCommandUsecase) UpdatePlan(id string, plan entity.Plan) (entity.Plan, error) {
+	if id == "" {
+		return entity.Plan{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	errEmpty := validator.IsDataEmpty([]string{"name"}, plan.Name)
+	if errEmpty != nil {
+		return entity.Plan{}, errEmpty
+	}
+
+	errPlan := validatePlan(plan)
+	if errPlan != nil {
+		return entity.Plan{}, errPlan
+	}
+
+	planEntity, errUpdate := scu.subscriptionCommandRepository.UpdatePlan(id, plan)
+	if errUpdate != nil {
+		return entity.Plan{}, errUpdate
+	}
+
+	return planEntity, nil
+}
+
+func (scu *subscriptionCommandUsecase) CreateSubscription(userID string, planID string) (entity.Subscription, te.Transaction, error) {
+	errEmpty := validator.IsDataEmpty([]string{"user_id", "plan_id"}, userID, planID)
+	if errEmpty != nil {
+		return entity.Subscription{}, te.Transaction{}, errEmpty
+	}
+
+	plan, errGetPlan := scu.subscriptionQueryRepository.GetPlanByID(planID)
+	if errGetPlan != nil {
+		return entity.Subscription{}, te.Transaction{}, errGetPlan
+	}
+
+	if !plan.Status {
+		return entity.Subscription{}, te.Transaction{}, errors.New(constant.ERROR_PLAN_INACTIVE)
+	}
+
+	latest, errGetLatest := scu.subscriptionQueryRepository.GetLatestSubscriptionByUserID(userID)
+	if errGetLatest != nil && errGetLatest.Error() != constant.ERROR_DATA_NOTFOUND {
+		return entity.Subscription{}, te.Transaction{}, errGetLatest
+	}
+
+	if errGetLatest == nil {
+		switch latest.CurrentStatus(time.Now()) {
+		case constant.SUBSCRIPTION_ACTIVE, constant.SUBSCRIPTION_GRACE:
+			return entity.Subscription{}, te.Transaction{}, errors.New(constant.ERROR_SUBSCRIPTION_EXIST)
+		case constant.SUBSCRIPTION_PENDING:
+			// only one unpaid checkout is kept per user, and the replaced
+			// one must not be payable any more
+			errExpire := scu.transactionCommandUsecase.ExpireSubscriptionTransactions(latest.ID)
+			if errExpire != nil {
+				return entity.Subscription{}, te.Transaction{}, errExpire
+			}
+
+			_, errCancel := scu.subscriptionCommandRepository.CancelSubscription(latest.ID)
+			if errCancel != nil {
+				return entity.Subscription{}, te.Transaction{}, errCancel
+			}
+		}
+	}
+
+	subscription, errCreate := scu.subscriptionCommandRepository.CreateSubscription(entity.Subscription{
+		UserID:    userID,
+		PlanID:    plan.ID,
+		Status:    constant.SUBSCRIPTION_PENDING,
+		GraceDays: plan.GraceDays,
+	})
+	if errCreate != nil {
+		return entity.Subscription{}, te.Transaction{}, errCreate
+	}
+
+	transaction, errCharge := scu.charge(subscription, plan)
+	if errCharge != nil {
+		return entity.Subscription{}, te.Transaction{}, errCharge
+	}
+
+	return subscription, transaction, nil
+}
+
+func (scu *subscriptionCommandUsecase) RenewSubscription(id string, userID string) (entity.Subscription, te.Transaction, error) {
+	subscription, errGet := scu.getOwnedSubscription(id, userID)
+	if errGet != nil {
+		return entity.Subscription{}, te.Transaction{}, errGet
+	}
+
+	if subscription.CurrentStatus(time.Now()) == constant.SUBSCRIPTION_PENDING {
+		return entity.Subscription{}, te.Transaction{}, errors.New(constant.ERROR_SUBSCRIPTION_STATUS)
+	}
+
+	if !subscription.Plan.Status {
+		return entity.Subscription{}, te.Transaction{}, errors.New(constant.ERROR_PLAN_INACTIVE)
+	}
+
+	latest, errGetLatest := scu.subscriptionQueryRepository.GetLatestSubscriptionByUserID(userID)
+	if errGetLatest != nil {
+		return entity.Subscription{}, te.Transaction{}, errGetLatest
+	}
+
+	if latest.ID != subscription.ID {
+		return entity.Subscription{}, te.Transaction{}, errors.New(constant.ERROR_SUBSCRIPTION_STATUS)
+	}
+
+	transaction, errCharge := scu.charge(subscription, subscription.Plan)
+	if errCharge != nil {
+		return entity.Subscription{}, te.Transaction{}, errCharge
+	}
+
+	return subscription, transaction, nil
+}
+
+func (scu *subscriptionCommandUsecase) CancelSubscription(id string, userID string) (entity.Subscription, error) {
+	subscription, errGet := scu.getOwnedSubscription(id, userID)
+	if errGet != nil {
+		return entity.Subscription{}, errGet
+	}
+
+	switch subscription.CurrentStatus(time.Now()) {
+	case constant.SUBSCRIPTION_PENDING, constant.SUBSCRIPTION_ACTIVE, constant.SUBSCRIPTION_GRACE:
+	default:
+		return entity.Subscription{}, errors.New(constant.ERROR_SUBSCRIPTION_STATUS)
+	}
+
+	subscriptionEntity, errCancel := scu.subscriptionCommandRepository.CancelSubscription(id)
+	if errCancel != nil {
+		return entity.Subscription{}, errCancel
+	}
+
+	return subscriptionEntity, nil
+}
+
+// ExpireSubscriptions stores the expired state of every subscription whose
+// premium access has ended and notifies its user. It returns how many were
+// expired.
+func (scu *subscriptionCommandUsecase) ExpireSubscriptions() (int, error) {
+	subscriptions, errGet := scu.subscriptionQueryRepository.GetLapsedSubscriptions(time.Now())
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	expired := 0
+	for _, subscription := range subscriptions {
+		_, changed, errExpire := scu.subscriptionCommandRepository.ExpireSubscription(subscription.ID)
+		if errExpire != nil {
+			logrus.Errorf("failed to expire subscription %s: %v", subscription.ID, errExpire)
+			continue
+		}
+
+		if !changed {
+			continue
+		}
+		expired++
+
+		user, errGetUser := scu.userQueryRepository.GetUserByID(subscription.UserID)
+		if errGetUser != nil {
+			logrus.Errorf("failed to send expiry notice for subscription %s: %v", subscription.ID, errGetUser)
+			continue
+		}
+
+		mailer.SendEmailPremiumExpired(user.Email, map[string]string{
+			"Fullname": user.Fullname,
+			"Plan":     subscription.Plan.Name,
+		})
+	}
+
+	return expired, nil
+}
+
+// SendRenewalReminders emails users whose paid period ends within daysBefore
+// days. Each period is reminded once, so a missed run is caught up by the
+// next one without sending duplicates.
+func (scu *subscriptionCommandUsecase) SendRenewalReminders(daysBefore int) (int, error) {
+	subscriptions, errGet := scu.subscriptionQueryRepository.GetSubscriptionsEndingBefore(time.Now().AddDate(0, 0, daysBefore))
+	if errGet != nil {
+		return 0, errGet
+	}
+
+	reminded := 0
+	for _, subscription := range subscriptions {
+		user, errGetUser := scu.userQueryRepository.GetUserByID(subscription.UserID)
+		if errGetUser != nil {
+			logrus.Errorf("failed to send renewal reminder for subscription %s: %v", subscription.ID, errGetUser)
+			continue
+		}
+
+		errMark := scu.subscriptionCommandRepository.MarkSubscriptionReminded(subscription.ID, *subscription.PeriodEnd)
+		if errMark != nil {
+			logrus.Errorf("failed to mark subscription %s as reminded: %v", subscription.ID, errMark)
+			continue
+		}
+
+		mailer.SendEmailRenewalReminder(user.Email, map[string]string{
+			"Fullname":  user.Fullname,
+			"Plan":      subscription.Plan.Name,
+			"PeriodEnd": subscription.PeriodEnd.Format("02 January 2006"),
+			"GraceEnd":  subscription.GraceEnd().Format("02 January 2006"),
+		})
+		reminded++
+	}
+
+	return reminded, nil
+}
+
+func (scu *subscriptionCommandUsecase) getOwnedSubscription(id string, userID string) (entity.Subscription, error) {
+	if id == "" {
+		return entity.Subscription{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	subscription, errGetID := scu.subscriptionQueryRepository.GetSubscriptionByID(id)
+	if errGetID != nil {
+		return entity.Subscription{}, errGetID
+	}
+
+	if subscription.UserID != userID {
+		return entity.Subscription{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	return subscription, nil
+}
+
+func (scu *subscriptionCommandUsecase) charge(subscription entity.Subscription, plan entity.Plan) (te.Transaction, error) {
+	return scu.transactionCommandUsecase.CreateSubscriptionTransaction(te.Transaction{
+		UserID:         subscription.UserID,
+		SubscriptionID: subscription.ID,
+		Amount:         plan.Price,
+	}, "TalkSpace Premium - "+plan.Name)
+}
+
+func validatePlan(plan entity.Plan) error {
+	if plan.Price <= 0 {
+		return errors.New(constant.ERROR_PLAN_PRICE)
+	}
+
+	if plan.DurationMonths < 1 {
+		return errors.New(constant.ERROR_PLAN_DURATION)
+	}
+
+	if plan.GraceDays < 0 {
+		return errors.New(constant.ERROR_DATA_INVALID + "grace_days >= 0")
+	}
+
+	return nil
+}
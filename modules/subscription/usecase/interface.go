@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"talkspace-api/modules/subscription/entity"
+	te "talkspace-api/modules/transaction/entity"
+)
+
+type SubscriptionCommandUsecaseInterface interface {
+	CreatePlan(plan entity.Plan) (entity.Plan, error)
+	UpdatePlan(id string, plan entity.Plan) (entity.Plan, error)
+	CreateSubscription(userID string, planID string) (entity.Subscription, te.Transaction, error)
+	RenewSubscription(id string, userID string) (entity.Subscription, te.Transaction, error)
+	CancelSubscription(id string, userID string) (entity.Subscription, error)
+	ExpireSubscriptions() (int, error)
+	SendRenewalReminders(daysBefore int) (int, error)
+}
+
+type SubscriptionQueryUsecaseInterface interface {
+	GetAllPlans(includeInactive bool) ([]entity.Plan, error)
+	GetSubscriptionByID(id string) (entity.Subscription, error)
+	GetCurrentSubscription(userID string) (entity.Subscription, error)
+	GetSubscriptionsByUserID(userID string, page, limit int) ([]entity.Subscription, int, error)
+}
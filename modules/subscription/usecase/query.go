@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/subscription/entity"
+	"talkspace-api/modules/subscription/repository"
+	"talkspace-api/utils/constant"
+)
+
+type subscriptionQueryUsecase struct {
+	subscriptionCommandRepository repository.SubscriptionCommandRepositoryInterface
+	subscriptionQueryRepository   repository.SubscriptionQueryRepositoryInterface
+}
+
+func NewSubscriptionQueryUsecase(scr repository.SubscriptionCommandRepositoryInterface, sqr repository.SubscriptionQueryRepositoryInterface) SubscriptionQueryUsecaseInterface {
+	return &subscriptionQueryUsecase{
+		subscriptionCommandRepository: scr,
+		subscriptionQueryRepository:   sqr,
+	}
+}
+
+func (squ *subscriptionQueryUsecase) GetAllPlans(includeInactive bool) ([]entity.Plan, error) {
+	plans, errGet := squ.subscriptionQueryRepository.GetAllPlans(includeInactive)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return plans, nil
+}
+
+func (squ *subscriptionQueryUsecase) GetSubscriptionByID(id string) (entity.Subscription, error) {
+	if id == "" {
+		return entity.Subscription{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	subscription, errGetID := squ.subscriptionQueryRepository.GetSubscriptionByID(id)
+	if errGetID != nil {
+		return entity.Subscription{}, errGetID
+	}
+
+	return subscription, nil
+}
+
+func (squ *subscriptionQueryUsecase) GetCurrentSubscription(userID string) (entity.Subscription, error) {
+	if userID == "" {
+		return entity.Subscription{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	subscription, errGet := squ.subscriptionQueryRepository.GetLatestSubscriptionByUserID(userID)
+	if errGet != nil {
+		return entity.Subscription{}, errGet
+	}
+
+	return subscription, nil
+}
+
+func (squ *subscriptionQueryUsecase) GetSubscriptionsByUserID(userID string, page, limit int) ([]entity.Subscription, int, error) {
+	if userID == "" {
+		return nil, 0, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	subscriptions, totalItems, errGet := squ.subscriptionQueryRepository.GetSubscriptionsByUserID(userID, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return subscriptions, totalItems, nil
+}
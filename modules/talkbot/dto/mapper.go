@@ -1,11 +1,24 @@
 package dto
 
-import "talkspace-api/modules/talkbot/entity"
+import (
+	"talkspace-api/modules/talkbot/entity"
+	"talkspace-api/utils/helper/risk"
+)
 
 // Request
 func TalkbotRequestToTalkbotEntity(request TalkbotRequest) entity.Talkbot {
 	return entity.Talkbot{
-		Message: request.Message,
+		ConversationID: request.ConversationID,
+		Message:        request.Message,
+	}
+}
+
+func QuotaRequestToQuotaValuesEntity(request QuotaRequest) entity.QuotaValues {
+	return entity.QuotaValues{
+		DailyMessages:   request.DailyMessages,
+		MonthlyMessages: request.MonthlyMessages,
+		DailyTokens:     request.DailyTokens,
+		MonthlyTokens:   request.MonthlyTokens,
 	}
 }
 
@@ -15,3 +28,80 @@ func TalkbotEntityToTalkbotResponse(entity entity.Talkbot) TalkbotResponse {
 		Message: entity.Message,
 	}
 }
+
+func TalkbotReplyEntityToTalkbotResponse(entity entity.TalkbotReply) TalkbotResponse {
+	response := TalkbotResponse{
+		ConversationID: entity.ConversationID,
+		Message:        entity.Message,
+		Flagged:        entity.Flagged,
+	}
+
+	if entity.Flagged {
+		response.Resources = risk.Hotlines
+	}
+
+	if entity.Quota != nil {
+		quotaResponse := QuotaEntityToQuotaResponse(*entity.Quota)
+		response.Quota = &quotaResponse
+	}
+
+	return response
+}
+
+func ConversationEntityToConversationResponse(entity entity.Conversation) ConversationResponse {
+	return ConversationResponse{
+		ID:        entity.ID,
+		Title:     entity.Title,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+func ListConversationEntityToConversationResponse(entities []entity.Conversation) []ConversationResponse {
+	listConversationResponse := []ConversationResponse{}
+	for _, conversation := range entities {
+		conversationResponse := ConversationEntityToConversationResponse(conversation)
+		listConversationResponse = append(listConversationResponse, conversationResponse)
+	}
+	return listConversationResponse
+}
+
+func TalkbotEntityToTalkbotMessageResponse(entity entity.Talkbot) TalkbotMessageResponse {
+	return TalkbotMessageResponse{
+		ID:          entity.ID,
+		Role:        entity.Role,
+		Message:     entity.Message,
+		Flagged:     entity.Flagged,
+		Interrupted: entity.Interrupted,
+		CreatedAt:   entity.CreatedAt,
+	}
+}
+
+func ListTalkbotEntityToTalkbotMessageResponse(entities []entity.Talkbot) []TalkbotMessageResponse {
+	listMessageResponse := []TalkbotMessageResponse{}
+	for _, message := range entities {
+		messageResponse := TalkbotEntityToTalkbotMessageResponse(message)
+		listMessageResponse = append(listMessageResponse, messageResponse)
+	}
+	return listMessageResponse
+}
+
+func QuotaValuesEntityToQuotaValuesResponse(entity entity.QuotaValues) QuotaValuesResponse {
+	return QuotaValuesResponse{
+		DailyMessages:   entity.DailyMessages,
+		MonthlyMessages: entity.MonthlyMessages,
+		DailyTokens:     entity.DailyTokens,
+		MonthlyTokens:   entity.MonthlyTokens,
+	}
+}
+
+func QuotaEntityToQuotaResponse(entity entity.Quota) QuotaResponse {
+	return QuotaResponse{
+		UserID:     entity.UserID,
+		Premium:    entity.Premium,
+		Overridden: entity.Overridden,
+		Limits:     QuotaValuesEntityToQuotaValuesResponse(entity.Limits),
+		Used:       QuotaValuesEntityToQuotaValuesResponse(entity.Used),
+		Remaining:  QuotaValuesEntityToQuotaValuesResponse(entity.Remaining()),
+	}
+}
@@ -1,6 +1,19 @@
 package dto
 
+type ConversationRequest struct {
+	Title string `json:"title" form:"title"`
+}
+
+// QuotaRequest replaces the limits of a user, a limit left out is zero.
+type QuotaRequest struct {
+	DailyMessages   int `json:"daily_messages" form:"daily_messages"`
+	MonthlyMessages int `json:"monthly_messages" form:"monthly_messages"`
+	DailyTokens     int `json:"daily_tokens" form:"daily_tokens"`
+	MonthlyTokens   int `json:"monthly_tokens" form:"monthly_tokens"`
+}
+
 type TalkbotRequest struct {
-	Message string `json:"message" form:"message"`
+	ConversationID string `json:"conversation_id" form:"conversation_id"`
+	Message        string `json:"message" form:"message"`
 }
 
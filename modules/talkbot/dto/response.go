@@ -1,5 +1,53 @@
 package dto
 
-type TalkbotResponse struct {
-	Message string `json:"message"`
-}
+import (
+	"talkspace-api/utils/helper/risk"
+	"time"
+)
+
+type (
+	TalkbotResponse struct {
+		ConversationID string          `json:"conversation_id,omitempty"`
+		Message        string          `json:"message"`
+		Flagged        bool            `json:"flagged"`
+		Resources      []risk.Resource `json:"resources,omitempty"`
+		Quota          *QuotaResponse  `json:"quota,omitempty"`
+	}
+
+	QuotaValuesResponse struct {
+		DailyMessages   int `json:"daily_messages"`
+		MonthlyMessages int `json:"monthly_messages"`
+		DailyTokens     int `json:"daily_tokens"`
+		MonthlyTokens   int `json:"monthly_tokens"`
+	}
+
+	QuotaResponse struct {
+		UserID     string              `json:"user_id"`
+		Premium    bool                `json:"premium"`
+		Overridden bool                `json:"overridden"`
+		Limits     QuotaValuesResponse `json:"limits"`
+		Used       QuotaValuesResponse `json:"used"`
+		Remaining  QuotaValuesResponse `json:"remaining"`
+	}
+
+	ConversationResponse struct {
+		ID        string    `json:"id"`
+		Title     string    `json:"title"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	TalkbotMessageResponse struct {
+		ID          string    `json:"id"`
+		Role        string    `json:"role"`
+		Message     string    `json:"message"`
+		Flagged     bool      `json:"flagged"`
+		Interrupted bool      `json:"interrupted"`
+		CreatedAt   time.Time `json:"created_at"`
+	}
+
+	// TalkbotDeltaResponse is a piece of a streamed reply.
+	TalkbotDeltaResponse struct {
+		Content string `json:"content"`
+	}
+)
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"talkspace-api/utils/constant"
+	"unicode/utf8"
+)
+
+// messageOverhead is what the chat format adds to every message on top of
+// its content.
+const messageOverhead = 4
+
+// EstimateTokens approximates the tokens a message takes in the prompt at
+// about four characters a token. It errs on the high side so the estimate
+// stays within the budget of the model.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text)+3)/4 + messageOverhead
+}
+
+// Fit splits the history, oldest first, into the older turns that do not fit
+// the budget and the most recent turns that do. A turn is never cut in half.
+func Fit(history []Talkbot, budget int) (older []Talkbot, recent []Talkbot) {
+	used := 0
+	start := len(history)
+	for start > 0 {
+		tokens := EstimateTokens(history[start-1].Message)
+		if used+tokens > budget {
+			break
+		}
+
+		used += tokens
+		start--
+	}
+
+	return history[:start], history[start:]
+}
+
+// ValidateTitle trims a title given by the user and checks its length.
+func ValidateTitle(title string) (string, error) {
+	title = strings.TrimSpace(title)
+	if title == "" || utf8.RuneCountInString(title) > TitleMaxLength {
+		return "", errors.New(constant.ERROR_CONVERSATION_TITLE)
+	}
+
+	return title, nil
+}
+
+// Title names a conversation after its first message.
+func Title(message string) string {
+	title := strings.Join(strings.Fields(message), " ")
+	if utf8.RuneCountInString(title) <= TitleMaxLength {
+		return title
+	}
+
+	runes := []rune(title)
+	return strings.TrimSpace(string(runes[:TitleMaxLength])) + "..."
+}
@@ -4,9 +4,56 @@ import (
 	"time"
 )
 
+// CrisisPrompt is added to the conversation when the message of the user was
+// flagged for self-harm risk.
+const CrisisPrompt = "Pesan terakhir pengguna menunjukkan kemungkinan risiko menyakiti diri sendiri. Tanggapi dengan tenang dan penuh empati tanpa menghakimi, jangan pernah memberi informasi tentang cara menyakiti diri, dan dorong pengguna untuk segera menghubungi Layanan SEJIWA di 119 ext. 8, atau 112 jika sedang dalam bahaya."
+
+// SummaryPrompt asks the bot to condense the turns that no longer fit the
+// prompt, SummaryContext introduces the result in later prompts.
+const (
+	SummaryPrompt  = "Ringkas percakapan antara pengguna dan TalkBot berikut dalam beberapa kalimat. Pertahankan perasaan, masalah, dan tujuan yang diceritakan pengguna serta saran yang sudah diberikan, agar percakapan bisa dilanjutkan tanpa membaca pesan aslinya. Jika ada ringkasan sebelumnya, gabungkan ke dalam ringkasan baru."
+	SummaryContext = "Ringkasan percakapan sebelumnya:\n"
+)
+
+const (
+	// DefaultContextTokens is the prompt budget used when
+	// OPENAI_CONTEXT_TOKENS is not set.
+	DefaultContextTokens = 3000
+	// SummaryMaxTokens caps the length of a conversation summary.
+	SummaryMaxTokens = 300
+	TitleMaxLength   = 50
+)
+
+type Conversation struct {
+	ID              string
+	UserID          string
+	Title           string
+	Summary         string
+	SummarizedUntil *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Talkbot is a turn of a conversation. Interrupted marks a reply that was
+// cut off before it was complete, or a message of the user that the bot
+// could not answer.
 type Talkbot struct {
-	ID        string
-	UserID    string
-	Message   string
-	CreatedAt time.Time
+	ID             string
+	ConversationID string
+	UserID         string
+	Role           string
+	Message        string
+	Flagged        bool
+	Interrupted    bool
+	CreatedAt      time.Time
+}
+
+// TalkbotReply is the answer of the bot to a message, Flagged when the
+// message was flagged for self-harm risk. Quota is what the user has left
+// after the reply, nil when it could not be read.
+type TalkbotReply struct {
+	ConversationID string
+	Message        string
+	Flagged        bool
+	Quota          *Quota
 }
@@ -2,12 +2,49 @@ package entity
 
 import "talkspace-api/modules/talkbot/model"
 
+func ConversationEntityToConversationModel(conversationEntity Conversation) model.Conversation {
+	return model.Conversation{
+		ID:              conversationEntity.ID,
+		UserID:          conversationEntity.UserID,
+		Title:           conversationEntity.Title,
+		Summary:         conversationEntity.Summary,
+		SummarizedUntil: conversationEntity.SummarizedUntil,
+		CreatedAt:       conversationEntity.CreatedAt,
+		UpdatedAt:       conversationEntity.UpdatedAt,
+	}
+}
+
+func ConversationModelToConversationEntity(conversationModel model.Conversation) Conversation {
+	return Conversation{
+		ID:              conversationModel.ID,
+		UserID:          conversationModel.UserID,
+		Title:           conversationModel.Title,
+		Summary:         conversationModel.Summary,
+		SummarizedUntil: conversationModel.SummarizedUntil,
+		CreatedAt:       conversationModel.CreatedAt,
+		UpdatedAt:       conversationModel.UpdatedAt,
+	}
+}
+
+func ListConversationModelToConversationEntity(conversationModels []model.Conversation) []Conversation {
+	listConversationEntity := []Conversation{}
+	for _, conversation := range conversationModels {
+		conversationEntity := ConversationModelToConversationEntity(conversation)
+		listConversationEntity = append(listConversationEntity, conversationEntity)
+	}
+	return listConversationEntity
+}
+
 func TalkbotEntityToTalkbotModel(talkbotEntity Talkbot) model.Talkbot {
 	return model.Talkbot{
-		ID:        talkbotEntity.ID,
-		UserID:    talkbotEntity.UserID,
-		Message:   talkbotEntity.Message,
-		CreatedAt: talkbotEntity.CreatedAt,
+		ID:             talkbotEntity.ID,
+		ConversationID: talkbotEntity.ConversationID,
+		UserID:         talkbotEntity.UserID,
+		Role:           talkbotEntity.Role,
+		Message:        talkbotEntity.Message,
+		Flagged:        talkbotEntity.Flagged,
+		Interrupted:    talkbotEntity.Interrupted,
+		CreatedAt:      talkbotEntity.CreatedAt,
 	}
 }
 
@@ -23,10 +60,14 @@ func ListTalkbotEntityToTalkbotModel(talkbotEntities []Talkbot) []model.Talkbot
 
 func TalkbotModelToTalkbotEntity(talkbotModel model.Talkbot) Talkbot {
 	return Talkbot{
-		ID:        talkbotModel.ID,
-		UserID:    talkbotModel.UserID,
-		Message:   talkbotModel.Message,
-		CreatedAt: talkbotModel.CreatedAt,
+		ID:             talkbotModel.ID,
+		ConversationID: talkbotModel.ConversationID,
+		UserID:         talkbotModel.UserID,
+		Role:           talkbotModel.Role,
+		Message:        talkbotModel.Message,
+		Flagged:        talkbotModel.Flagged,
+		Interrupted:    talkbotModel.Interrupted,
+		CreatedAt:      talkbotModel.CreatedAt,
 	}
 }
 
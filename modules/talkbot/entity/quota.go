@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"errors"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+// QuotaValues counts messages and tokens per day and per month, as limits
+// or as usage.
+type QuotaValues struct {
+	DailyMessages   int `json:"daily_messages"`
+	MonthlyMessages int `json:"monthly_messages"`
+	DailyTokens     int `json:"daily_tokens"`
+	MonthlyTokens   int `json:"monthly_tokens"`
+}
+
+// FreeLimits and PremiumLimits apply to users without and with an active
+// premium subscription, unless an admin overrode the limits of the user.
+var (
+	FreeLimits = QuotaValues{
+		DailyMessages:   30,
+		MonthlyMessages: 300,
+		DailyTokens:     30000,
+		MonthlyTokens:   300000,
+	}
+
+	PremiumLimits = QuotaValues{
+		DailyMessages:   200,
+		MonthlyMessages: 3000,
+		DailyTokens:     200000,
+		MonthlyTokens:   3000000,
+	}
+)
+
+// Quota is what a user may still spend on TalkBot. Days and months follow
+// the clock of the server.
+type Quota struct {
+	UserID     string
+	Premium    bool
+	Overridden bool
+	Limits     QuotaValues
+	Used       QuotaValues
+}
+
+// NewQuota picks the limits of a user, override when an admin set one.
+func NewQuota(userID string, premiumExpired time.Time, override *QuotaValues, used QuotaValues, now time.Time) Quota {
+	quota := Quota{
+		UserID:  userID,
+		Premium: premiumExpired.After(now),
+		Limits:  FreeLimits,
+		Used:    used,
+	}
+
+	if quota.Premium {
+		quota.Limits = PremiumLimits
+	}
+
+	if override != nil {
+		quota.Overridden = true
+		quota.Limits = *override
+	}
+
+	return quota
+}
+
+func (q Quota) Remaining() QuotaValues {
+	return QuotaValues{
+		DailyMessages:   remaining(q.Limits.DailyMessages, q.Used.DailyMessages),
+		MonthlyMessages: remaining(q.Limits.MonthlyMessages, q.Used.MonthlyMessages),
+		DailyTokens:     remaining(q.Limits.DailyTokens, q.Used.DailyTokens),
+		MonthlyTokens:   remaining(q.Limits.MonthlyTokens, q.Used.MonthlyTokens),
+	}
+}
+
+// Exceeded reports whether any limit is used up. A reply may take the token
+// usage past its limit, the message after it is refused.
+func (q Quota) Exceeded() bool {
+	left := q.Remaining()
+	return left.DailyMessages == 0 || left.MonthlyMessages == 0 || left.DailyTokens == 0 || left.MonthlyTokens == 0
+}
+
+// Overdrawn is Exceeded for a quota whose usage already counts the message
+// being answered, it reports whether a limit was used up before it.
+func (q Quota) Overdrawn() bool {
+	return q.Spend(-1, 0).Exceeded()
+}
+
+// Spend adds a reply to the usage, so a response can show what is left
+// without reading the usage again.
+func (q Quota) Spend(messages int, tokens int) Quota {
+	q.Used.DailyMessages += messages
+	q.Used.MonthlyMessages += messages
+	q.Used.DailyTokens += tokens
+	q.Used.MonthlyTokens += tokens
+	return q
+}
+
+func (v QuotaValues) Validate() error {
+	if v.DailyMessages < 0 || v.MonthlyMessages < 0 || v.DailyTokens < 0 || v.MonthlyTokens < 0 {
+		return errors.New(constant.ERROR_QUOTA_LIMITS)
+	}
+
+	return nil
+}
+
+func remaining(limit int, used int) int {
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
@@ -2,26 +2,286 @@ package handler
 
 import (
 	"net/http"
-	"talkspace-api/app/configs"
 	"talkspace-api/middlewares"
 	"talkspace-api/modules/talkbot/dto"
+	"talkspace-api/modules/talkbot/entity"
 	"talkspace-api/modules/talkbot/usecase"
 	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/sse"
 	"talkspace-api/utils/responses"
 
 	"github.com/labstack/echo/v4"
 )
 
 type talkbotHandler struct {
+	talkbotCommandUsecase usecase.TalkbotCommandUsecaseInterface
 	talkbotQueryUsecase   usecase.TalkbotQueryUsecaseInterface
 }
 
-func NewTalkbotHandler(tqu usecase.TalkbotQueryUsecaseInterface) *talkbotHandler {
+func NewTalkbotHandler(tcu usecase.TalkbotCommandUsecaseInterface, tqu usecase.TalkbotQueryUsecaseInterface) *talkbotHandler {
 	return &talkbotHandler{
+		talkbotCommandUsecase: tcu,
 		talkbotQueryUsecase:   tqu,
 	}
 }
 
+// Query
+func (th *talkbotHandler) GetQuota(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	quota, errGet := th.talkbotQueryUsecase.GetQuota(userID)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	quotaResponse := dto.QuotaEntityToQuotaResponse(quota)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, quotaResponse))
+}
+
+func (th *talkbotHandler) GetQuotaByUserID(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	quota, errGet := th.talkbotQueryUsecase.GetQuota(userIDParam)
+	if errGet != nil {
+		return talkbotError(c, errGet)
+	}
+
+	quotaResponse := dto.QuotaEntityToQuotaResponse(quota)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, quotaResponse))
+}
+
+func (th *talkbotHandler) GetConversations(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	conversations, totalItems, errGet := th.talkbotQueryUsecase.GetConversations(userID, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(conversations) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	conversationResponses := dto.ListConversationEntityToConversationResponse(conversations)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		conversationResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (th *talkbotHandler) GetConversationMessages(c echo.Context) error {
+	conversationIDParam := c.Param("conversation_id")
+	if conversationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	messages, totalItems, errGet := th.talkbotQueryUsecase.GetConversationMessages(conversationIDParam, userID, page, limit)
+	if errGet != nil {
+		return talkbotError(c, errGet)
+	}
+
+	if len(messages) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	messageResponses := dto.ListTalkbotEntityToTalkbotMessageResponse(messages)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		messageResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Command
+func (th *talkbotHandler) UpdateQuotaLimits(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	quotaRequest := dto.QuotaRequest{}
+
+	errBind := c.Bind(&quotaRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	limits := dto.QuotaRequestToQuotaValuesEntity(quotaRequest)
+
+	quota, errUpdate := th.talkbotCommandUsecase.UpdateQuotaLimits(userIDParam, limits)
+	if errUpdate != nil {
+		return talkbotError(c, errUpdate)
+	}
+
+	quotaResponse := dto.QuotaEntityToQuotaResponse(quota)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, quotaResponse))
+}
+
+// DeleteQuotaLimits removes the limits an admin set, the user is back on
+// the limits of their plan.
+func (th *talkbotHandler) DeleteQuotaLimits(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	quota, errDelete := th.talkbotCommandUsecase.DeleteQuotaLimits(userIDParam)
+	if errDelete != nil {
+		return talkbotError(c, errDelete)
+	}
+
+	quotaResponse := dto.QuotaEntityToQuotaResponse(quota)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_DELETED, quotaResponse))
+}
+
+func (th *talkbotHandler) UpdateConversationTitle(c echo.Context) error {
+	conversationIDParam := c.Param("conversation_id")
+	if conversationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	conversationRequest := dto.ConversationRequest{}
+
+	errBind := c.Bind(&conversationRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	conversation, errUpdate := th.talkbotCommandUsecase.UpdateConversationTitle(conversationIDParam, userID, conversationRequest.Title)
+	if errUpdate != nil {
+		return talkbotError(c, errUpdate)
+	}
+
+	conversationResponse := dto.ConversationEntityToConversationResponse(conversation)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, conversationResponse))
+}
+
+// DeleteConversation erases a conversation for good, there is no way to
+// restore it.
+func (th *talkbotHandler) DeleteConversation(c echo.Context) error {
+	conversationIDParam := c.Param("conversation_id")
+	if conversationIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	errDelete := th.talkbotCommandUsecase.DeleteConversation(conversationIDParam, userID)
+	if errDelete != nil {
+		return talkbotError(c, errDelete)
+	}
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_DELETED, nil))
+}
+
+// DeleteConversations erases the whole TalkBot history of the user.
+func (th *talkbotHandler) DeleteConversations(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	errDelete := th.talkbotCommandUsecase.DeleteConversations(userID)
+	if errDelete != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errDelete.Error()))
+	}
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_DELETED, nil))
+}
+
 // Command
 func (th *talkbotHandler) CreateTalkBotMessage(c echo.Context) error {
 	talkbotRequest := dto.TalkbotRequest{}
@@ -39,17 +299,89 @@ func (th *talkbotHandler) CreateTalkBotMessage(c echo.Context) error {
 		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtractToken.Error()))
 	}
 
-	config, err := configs.LoadConfig()
-	if err != nil {
-		return err
-	}
-
 	talkbotEntity :=  dto.TalkbotRequestToTalkbotEntity(talkbotRequest)
 
-	promptResponse, errGetPrompt := th.talkbotQueryUsecase.GetTalkBotPrompt(userID, talkbotEntity, config.OPENAI.OPENAI_API_KEY)
+	reply, errGetPrompt := th.talkbotQueryUsecase.GetTalkBotPrompt(c.Request().Context(), userID, talkbotEntity)
 	if errGetPrompt != nil {
-		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGetPrompt.Error()))
+		return talkbotError(c, errGetPrompt)
+	}
+
+	talkbotResponse := dto.TalkbotReplyEntityToTalkbotResponse(reply)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, talkbotResponse))
+}
+
+// StreamTalkBotMessage relays the reply as Server-Sent Events: start once
+// the message is stored, delta for every piece of the reply and done with
+// the whole reply, or error when the bot fails halfway. Errors before the
+// stream starts are answered as json. A client that disconnects cancels the
+// request to the provider.
+func (th *talkbotHandler) StreamTalkBotMessage(c echo.Context) error {
+	talkbotRequest := dto.TalkbotRequest{}
+
+	errBind := c.Bind(&talkbotRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	userID, role, errExtractToken := middlewares.ExtractToken(c)
+	if errExtractToken != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtractToken.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	talkbotEntity := dto.TalkbotRequestToTalkbotEntity(talkbotRequest)
+
+	var stream *sse.Stream
+
+	onStart := func(reply entity.TalkbotReply) error {
+		stream = sse.NewStream(c.Response())
+		return stream.Send(constant.STREAM_START, dto.TalkbotReplyEntityToTalkbotResponse(reply))
 	}
 
-	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, promptResponse))
+	onDelta := func(delta string) error {
+		return stream.Send(constant.STREAM_DELTA, dto.TalkbotDeltaResponse{Content: delta})
+	}
+
+	ctx := c.Request().Context()
+
+	reply, errStream := th.talkbotQueryUsecase.StreamTalkBotPrompt(ctx, userID, talkbotEntity, onStart, onDelta)
+	if errStream != nil {
+		if stream == nil {
+			return talkbotError(c, errStream)
+		}
+
+		// nobody is left to tell when the client went away
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		return stream.Send(constant.STREAM_ERROR, responses.ErrorResponse(errStream.Error()))
+	}
+
+	return stream.Send(constant.STREAM_DONE, dto.TalkbotReplyEntityToTalkbotResponse(reply))
+}
+
+func talkbotError(c echo.Context, err error) error {
+	switch err.Error() {
+	case constant.ERROR_ID_NOTFOUND:
+		return c.JSON(http.StatusNotFound, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_ROLE_ACCESS:
+		return c.JSON(http.StatusForbidden, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_CONVERSATION_TITLE, constant.ERROR_QUOTA_LIMITS:
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_TALKBOT_QUOTA:
+		return c.JSON(http.StatusTooManyRequests, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_TALKBOT_TIMEOUT:
+		return c.JSON(http.StatusGatewayTimeout, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_TALKBOT_BUSY, constant.ERROR_TALKBOT_UNAVAILABLE:
+		return c.JSON(http.StatusServiceUnavailable, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_TALKBOT_REJECTED:
+		return c.JSON(http.StatusBadGateway, responses.ErrorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(err.Error()))
+	}
 }
@@ -2,7 +2,19 @@ package handler
 
 import "github.com/labstack/echo/v4"
 
-type UserHandlerInterface interface {
+type TalkbotHandlerInterface interface {
+	// Query
+	GetQuota(c echo.Context) error
+	GetQuotaByUserID(c echo.Context) error
+	GetConversations(c echo.Context) error
+	GetConversationMessages(c echo.Context) error
+
 	// Command
-	CreateTalkBot(c echo.Context) error
+	UpdateQuotaLimits(c echo.Context) error
+	DeleteQuotaLimits(c echo.Context) error
+	UpdateConversationTitle(c echo.Context) error
+	DeleteConversation(c echo.Context) error
+	DeleteConversations(c echo.Context) error
+	CreateTalkBotMessage(c echo.Context) error
+	StreamTalkBotMessage(c echo.Context) error
 }
@@ -11,3 +11,10 @@ func (t *Talkbot) BeforeCreate(tx *gorm.DB) (err error) {
 
 	return nil
 }
+
+func (c *Conversation) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	c.ID = UUID.String()
+
+	return nil
+}
@@ -2,9 +2,25 @@ package model
 
 import "time"
 
+// Conversation groups the turns of a user with TalkBot. Summary condenses
+// the turns up to SummarizedUntil that no longer fit the prompt.
+type Conversation struct {
+	ID              string `gorm:"primaryKey"`
+	UserID          string `gorm:"index;not null"`
+	Title           string `gorm:"not null"`
+	Summary         string `gorm:"type:text"`
+	SummarizedUntil *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
 type Talkbot struct {
-	ID        string    `gorm:"primaryKey"`
-	UserID    string    `gorm:"not null"`
-	Message   string    `gorm:"type:text;not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	ID             string    `gorm:"primaryKey"`
+	ConversationID string    `gorm:"index"`
+	UserID         string    `gorm:"not null"`
+	Role           string    `gorm:"type:varchar(20);not null;default:'user'"`
+	Message        string    `gorm:"type:text;not null"`
+	Flagged        bool      `gorm:"not null;default:false"`
+	Interrupted    bool      `gorm:"not null;default:false"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
 }
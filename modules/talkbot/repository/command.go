@@ -1,28 +1,238 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"talkspace-api/modules/talkbot/entity"
+	"talkspace-api/modules/talkbot/model"
+	"talkspace-api/utils/constant"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type talkbotCommandRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	rdb *redis.Client
 }
 
-func NewTalkbotCommandRepository(db *gorm.DB) TalkbotCommandRepositoryInterface {
+func NewTalkbotCommandRepository(db *gorm.DB, rdb *redis.Client) TalkbotCommandRepositoryInterface {
 	return &talkbotCommandRepository{
-		db: db,
+		db:  db,
+		rdb: rdb,
 	}
 }
 
-func (tr *talkbotCommandRepository) SaveUserMessage(talkbot entity.Talkbot) error {
+func (tr *talkbotCommandRepository) CreateConversation(conversation entity.Conversation) (entity.Conversation, error) {
+	conversationModel := entity.ConversationEntityToConversationModel(conversation)
+
+	result := tr.db.Create(&conversationModel)
+	if result.Error != nil {
+		return entity.Conversation{}, result.Error
+	}
+
+	conversationEntity := entity.ConversationModelToConversationEntity(conversationModel)
+
+	return conversationEntity, nil
+}
+
+func (tr *talkbotCommandRepository) UpdateConversationSummary(conversationID string, summary string, summarizedUntil time.Time) error {
+	result := tr.db.Model(&model.Conversation{}).Where("id = ?", conversationID).Updates(map[string]interface{}{
+		"summary":          summary,
+		"summarized_until": summarizedUntil,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	return nil
+}
+
+// SaveMessage stores a turn of the user or the bot and moves its
+// conversation to the top of the list. The conversation is locked first, so
+// a reply that was still on its way when the user deleted the conversation
+// is turned away instead of outliving it.
+func (tr *talkbotCommandRepository) SaveMessage(talkbot entity.Talkbot) (entity.Talkbot, error) {
 	talkbotModel := entity.TalkbotEntityToTalkbotModel(talkbot)
 
-	result := tr.db.Create(&talkbotModel)
+	errTx := tr.db.Transaction(func(tx *gorm.DB) error {
+		conversationModel := model.Conversation{}
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", talkbotModel.ConversationID).First(&conversationModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		if err := tx.Create(&talkbotModel).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&conversationModel).Update("updated_at", time.Now()).Error
+	})
+	if errTx != nil {
+		return entity.Talkbot{}, errTx
+	}
+
+	talkbotEntity := entity.TalkbotModelToTalkbotEntity(talkbotModel)
+
+	return talkbotEntity, nil
+}
+
+// MarkMessageInterrupted flags a message of the user that the bot could not
+// answer.
+func (tr *talkbotCommandRepository) MarkMessageInterrupted(messageID string) error {
+	result := tr.db.Model(&model.Talkbot{}).Where("id = ?", messageID).Update("interrupted", true)
 	if result.Error != nil {
 		return result.Error
 	}
 
 	return nil
 }
+
+func (tr *talkbotCommandRepository) UpdateConversationTitle(conversationID string, title string) (entity.Conversation, error) {
+	conversationModel := model.Conversation{}
+
+	result := tr.db.Where("id = ?", conversationID).First(&conversationModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Conversation{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Conversation{}, result.Error
+	}
+
+	// a rename does not count as activity, the list stays in order
+	result = tr.db.Model(&conversationModel).UpdateColumn("title", title)
+	if result.Error != nil {
+		return entity.Conversation{}, result.Error
+	}
+
+	conversationEntity := entity.ConversationModelToConversationEntity(conversationModel)
+
+	return conversationEntity, nil
+}
+
+// DeleteConversation removes a conversation with its messages and summary
+// for good. Escalations of its flagged messages stay, they never held the
+// text of a message.
+func (tr *talkbotCommandRepository) DeleteConversation(conversationID string) error {
+	errTx := tr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("conversation_id = ?", conversationID).Delete(&model.Talkbot{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Where("id = ?", conversationID).Delete(&model.Conversation{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+
+		return nil
+	})
+	if errTx != nil {
+		return errTx
+	}
+
+	return nil
+}
+
+// DeleteConversationsByUserID removes every conversation and message of a
+// user for good, including messages sent before conversations existed.
+func (tr *talkbotCommandRepository) DeleteConversationsByUserID(userID string) error {
+	errTx := tr.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.Talkbot{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&model.Conversation{}).Error
+	})
+	if errTx != nil {
+		return errTx
+	}
+
+	return nil
+}
+
+// ReserveMessage counts a message against the daily and monthly usage of a
+// user before it is answered and returns the usage including it. The counters
+// are raised atomically, so parallel messages each see the others and cannot
+// all slip under a limit. A reservation is given back with AddUsage.
+func (tr *talkbotCommandRepository) ReserveMessage(userID string, now time.Time) (entity.QuotaValues, error) {
+	ctx := context.Background()
+	dailyKey := dailyUsageKey(userID, now)
+	monthlyKey := monthlyUsageKey(userID, now)
+
+	pipe := tr.rdb.TxPipeline()
+	dailyMessages := pipe.HIncrBy(ctx, dailyKey, "messages", 1)
+	dailyTokens := pipe.HIncrBy(ctx, dailyKey, "tokens", 0)
+	pipe.Expire(ctx, dailyKey, dailyUsageTTL)
+	monthlyMessages := pipe.HIncrBy(ctx, monthlyKey, "messages", 1)
+	monthlyTokens := pipe.HIncrBy(ctx, monthlyKey, "tokens", 0)
+	pipe.Expire(ctx, monthlyKey, monthlyUsageTTL)
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return entity.QuotaValues{}, err
+	}
+
+	return entity.QuotaValues{
+		DailyMessages:   int(dailyMessages.Val()),
+		MonthlyMessages: int(monthlyMessages.Val()),
+		DailyTokens:     int(dailyTokens.Val()),
+		MonthlyTokens:   int(monthlyTokens.Val()),
+	}, nil
+}
+
+// AddUsage counts a reply against the daily and monthly usage of a user.
+// Negative counts take usage back, such as a reserved message that was not
+// answered.
+func (tr *talkbotCommandRepository) AddUsage(userID string, messages int, tokens int, now time.Time) error {
+	ctx := context.Background()
+
+	pipe := tr.rdb.TxPipeline()
+	for key, ttl := range map[string]time.Duration{
+		dailyUsageKey(userID, now):   dailyUsageTTL,
+		monthlyUsageKey(userID, now): monthlyUsageTTL,
+	} {
+		pipe.HIncrBy(ctx, key, "messages", int64(messages))
+		pipe.HIncrBy(ctx, key, "tokens", int64(tokens))
+		pipe.Expire(ctx, key, ttl)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (tr *talkbotCommandRepository) SetQuotaOverride(userID string, limits entity.QuotaValues) error {
+	data, err := json.Marshal(limits)
+	if err != nil {
+		return err
+	}
+
+	err = tr.rdb.Set(context.Background(), quotaOverrideKey(userID), data, 0).Err()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (tr *talkbotCommandRepository) DeleteQuotaOverride(userID string) error {
+	err := tr.rdb.Del(context.Background(), quotaOverrideKey(userID)).Err()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
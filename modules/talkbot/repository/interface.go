@@ -1,13 +1,29 @@
 package repository
 
-import "talkspace-api/modules/talkbot/entity"
-
+import (
+	"talkspace-api/modules/talkbot/entity"
+	"time"
+)
 
 type TalkbotCommandRepositoryInterface interface {
-	SaveUserMessage(talkbot entity.Talkbot) error
-
+	CreateConversation(conversation entity.Conversation) (entity.Conversation, error)
+	UpdateConversationSummary(conversationID string, summary string, summarizedUntil time.Time) error
+	SaveMessage(talkbot entity.Talkbot) (entity.Talkbot, error)
+	MarkMessageInterrupted(messageID string) error
+	UpdateConversationTitle(conversationID string, title string) (entity.Conversation, error)
+	DeleteConversation(conversationID string) error
+	DeleteConversationsByUserID(userID string) error
+	ReserveMessage(userID string, now time.Time) (entity.QuotaValues, error)
+	AddUsage(userID string, messages int, tokens int, now time.Time) error
+	SetQuotaOverride(userID string, limits entity.QuotaValues) error
+	DeleteQuotaOverride(userID string) error
 }
 
 type TalkbotQueryRepositoryInterface interface {
-	GetUserMessages(userID string) ([]entity.Talkbot, error)
-}
\ No newline at end of file
+	GetConversationByID(conversationID string) (entity.Conversation, error)
+	GetConversationMessages(conversationID string, after *time.Time) ([]entity.Talkbot, error)
+	GetConversationsByUserID(userID string, page, limit int) ([]entity.Conversation, int, error)
+	GetMessagesByConversationID(conversationID string, page, limit int) ([]entity.Talkbot, int, error)
+	GetUsage(userID string, now time.Time) (entity.QuotaValues, error)
+	GetQuotaOverride(userID string) (*entity.QuotaValues, error)
+}
@@ -1,29 +1,145 @@
 package repository
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"talkspace-api/modules/talkbot/entity"
+	"talkspace-api/modules/talkbot/model"
+	"talkspace-api/utils/constant"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
 type talkbotQueryRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	rdb *redis.Client
 }
 
-func NewTalkbotQueryRepository(db *gorm.DB) TalkbotQueryRepositoryInterface {
+func NewTalkbotQueryRepository(db *gorm.DB, rdb *redis.Client) TalkbotQueryRepositoryInterface {
 	return &talkbotQueryRepository{
-		db: db,
+		db:  db,
+		rdb: rdb,
 	}
 }
 
-func (tr *talkbotQueryRepository) GetUserMessages(userID string) ([]entity.Talkbot, error) {
+func (tr *talkbotQueryRepository) GetConversationByID(conversationID string) (entity.Conversation, error) {
+	conversationModel := model.Conversation{}
+	result := tr.db.Where("id = ?", conversationID).First(&conversationModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Conversation{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Conversation{}, result.Error
+	}
+
+	conversationEntity := entity.ConversationModelToConversationEntity(conversationModel)
 
-	var talkbot []entity.Talkbot
+	return conversationEntity, nil
+}
+
+// GetConversationMessages lists the turns of a conversation oldest first,
+// only those after the given time when it is set.
+func (tr *talkbotQueryRepository) GetConversationMessages(conversationID string, after *time.Time) ([]entity.Talkbot, error) {
+	talkbotModels := []model.Talkbot{}
 
-	result := tr.db.Where("user_id = ?", userID).Order("created_at DESC").Limit(1).Find(&talkbot)
+	query := tr.db.Where("conversation_id = ?", conversationID)
+	if after != nil {
+		query = query.Where("created_at > ?", *after)
+	}
+
+	result := query.Order("created_at ASC").Find(&talkbotModels)
 	if result.Error != nil {
 		return []entity.Talkbot{}, result.Error
 	}
 
-	return talkbot, nil
+	talkbotEntities := entity.ListTalkbotModelToTalkbotEntity(talkbotModels)
+
+	return talkbotEntities, nil
+}
+
+// GetConversationsByUserID lists the conversations of a user, the one with
+// the latest message first.
+func (tr *talkbotQueryRepository) GetConversationsByUserID(userID string, page, limit int) ([]entity.Conversation, int, error) {
+	offset := (page - 1) * limit
+
+	var totalItems int64
+	result := tr.db.Model(&model.Conversation{}).Where("user_id = ?", userID).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var conversationModels []model.Conversation
+	result = tr.db.Where("user_id = ?", userID).Order("updated_at DESC").Offset(offset).Limit(limit).Find(&conversationModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	conversations := entity.ListConversationModelToConversationEntity(conversationModels)
+
+	return conversations, int(totalItems), nil
+}
+
+// GetMessagesByConversationID lists the messages of a conversation newest
+// first, so the first page is where the conversation left off.
+func (tr *talkbotQueryRepository) GetMessagesByConversationID(conversationID string, page, limit int) ([]entity.Talkbot, int, error) {
+	offset := (page - 1) * limit
+
+	var totalItems int64
+	result := tr.db.Model(&model.Talkbot{}).Where("conversation_id = ?", conversationID).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var talkbotModels []model.Talkbot
+	result = tr.db.Where("conversation_id = ?", conversationID).Order("created_at DESC").Offset(offset).Limit(limit).Find(&talkbotModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	messages := entity.ListTalkbotModelToTalkbotEntity(talkbotModels)
+
+	return messages, int(totalItems), nil
+}
+
+// GetUsage reads what a user spent on TalkBot today and this month.
+func (tr *talkbotQueryRepository) GetUsage(userID string, now time.Time) (entity.QuotaValues, error) {
+	ctx := context.Background()
+
+	pipe := tr.rdb.Pipeline()
+	daily := pipe.HGetAll(ctx, dailyUsageKey(userID, now))
+	monthly := pipe.HGetAll(ctx, monthlyUsageKey(userID, now))
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && err != redis.Nil {
+		return entity.QuotaValues{}, err
+	}
+
+	return entity.QuotaValues{
+		DailyMessages:   usageField(daily.Val(), "messages"),
+		MonthlyMessages: usageField(monthly.Val(), "messages"),
+		DailyTokens:     usageField(daily.Val(), "tokens"),
+		MonthlyTokens:   usageField(monthly.Val(), "tokens"),
+	}, nil
+}
+
+// GetQuotaOverride returns the limits an admin set for a user, nil when
+// the user has the limits of their plan.
+func (tr *talkbotQueryRepository) GetQuotaOverride(userID string) (*entity.QuotaValues, error) {
+	data, err := tr.rdb.Get(context.Background(), quotaOverrideKey(userID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	limits := entity.QuotaValues{}
+	if err := json.Unmarshal([]byte(data), &limits); err != nil {
+		return nil, err
+	}
+
+	return &limits, nil
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"strconv"
+	"time"
+)
+
+// Usage is counted in a redis hash per user and day and per user and month,
+// kept a little past the period they count.
+const (
+	dailyUsageTTL   = 48 * time.Hour
+	monthlyUsageTTL = 32 * 24 * time.Hour
+)
+
+func dailyUsageKey(userID string, now time.Time) string {
+	return "talkbot:usage:" + userID + ":" + now.Format("2006-01-02")
+}
+
+func monthlyUsageKey(userID string, now time.Time) string {
+	return "talkbot:usage:" + userID + ":" + now.Format("2006-01")
+}
+
+func quotaOverrideKey(userID string) string {
+	return "talkbot:quota:" + userID
+}
+
+func usageField(fields map[string]string, name string) int {
+	value, err := strconv.Atoi(fields[name])
+	if err != nil {
+		return 0
+	}
+	return value
+}
@@ -2,22 +2,48 @@ package router
 
 import (
 	"talkspace-api/middlewares"
+	er "talkspace-api/modules/escalation/repository"
+	eu "talkspace-api/modules/escalation/usecase"
 	"talkspace-api/modules/talkbot/handler"
 	"talkspace-api/modules/talkbot/repository"
 	"talkspace-api/modules/talkbot/usecase"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/helper/chat"
+	"talkspace-api/utils/helper/risk"
 
 	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 )
 
-func TalkbotRoutes(e *echo.Group, db *gorm.DB) {
-	talkbotQueryRepository := repository.NewTalkbotQueryRepository(db)
-	talkbotCommandRepository := repository.NewTalkbotCommandRepository(db)
+func TalkbotRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	talkbotQueryRepository := repository.NewTalkbotQueryRepository(db, rdb)
+	talkbotCommandRepository := repository.NewTalkbotCommandRepository(db, rdb)
+	userQueryRepository := ur.NewUserQueryRepository(db, rdb)
 
-	talkbotQueryUsecase := usecase.NewTalkbotQueryUsecase(talkbotCommandRepository, talkbotQueryRepository)
-	
-	talkbotHandler := handler.NewTalkbotHandler(talkbotQueryUsecase)
+	escalationCommandRepository := er.NewEscalationCommandRepository(db)
+	escalationQueryRepository := er.NewEscalationQueryRepository(db)
+
+	escalationCommandUsecase := eu.NewEscalationCommandUsecase(escalationCommandRepository, escalationQueryRepository, risk.NewDetector())
+	talkbotQueryUsecase := usecase.NewTalkbotQueryUsecase(talkbotCommandRepository, talkbotQueryRepository, userQueryRepository, escalationCommandUsecase, chat.NewChatProvider())
+	talkbotCommandUsecase := usecase.NewTalkbotCommandUsecase(talkbotCommandRepository, talkbotQueryRepository, userQueryRepository)
+
+	talkbotHandler := handler.NewTalkbotHandler(talkbotCommandUsecase, talkbotQueryUsecase)
 
 	e.POST("", talkbotHandler.CreateTalkBotMessage, middlewares.JWTMiddleware(false))
+	e.POST("/stream", talkbotHandler.StreamTalkBotMessage, middlewares.JWTMiddleware(false))
+	e.GET("/quota", talkbotHandler.GetQuota, middlewares.JWTMiddleware(false))
+
+	quotas := e.Group("/quotas", middlewares.JWTMiddleware(false))
+	quotas.GET("/:user_id", talkbotHandler.GetQuotaByUserID)
+	quotas.PUT("/:user_id", talkbotHandler.UpdateQuotaLimits)
+	quotas.DELETE("/:user_id", talkbotHandler.DeleteQuotaLimits)
+
+	conversations := e.Group("/conversations", middlewares.JWTMiddleware(false))
+	conversations.GET("", talkbotHandler.GetConversations)
+	conversations.DELETE("", talkbotHandler.DeleteConversations)
+	conversations.GET("/:conversation_id/messages", talkbotHandler.GetConversationMessages)
+	conversations.PATCH("/:conversation_id", talkbotHandler.UpdateConversationTitle)
+	conversations.DELETE("/:conversation_id", talkbotHandler.DeleteConversation)
 
 }
@@ -1 +1,100 @@
-package usecase
\ No newline at end of file
+package usecase
+
+import (
+	"talkspace-api/modules/talkbot/entity"
+	"talkspace-api/modules/talkbot/repository"
+	ur "talkspace-api/modules/user/repository"
+	"time"
+)
+
+type talkbotCommandUsecase struct {
+	talkbotCommandRepository repository.TalkbotCommandRepositoryInterface
+	talkbotQueryRepository   repository.TalkbotQueryRepositoryInterface
+	userQueryRepository      ur.UserQueryRepositoryInterface
+}
+
+func NewTalkbotCommandUsecase(tcr repository.TalkbotCommandRepositoryInterface, tqr repository.TalkbotQueryRepositoryInterface, uqr ur.UserQueryRepositoryInterface) TalkbotCommandUsecaseInterface {
+	return &talkbotCommandUsecase{
+		talkbotCommandRepository: tcr,
+		talkbotQueryRepository:   tqr,
+		userQueryRepository:      uqr,
+	}
+}
+
+func (tcu *talkbotCommandUsecase) UpdateConversationTitle(conversationID string, userID string, title string) (entity.Conversation, error) {
+	title, errTitle := entity.ValidateTitle(title)
+	if errTitle != nil {
+		return entity.Conversation{}, errTitle
+	}
+
+	_, errGet := ownConversation(tcu.talkbotQueryRepository, conversationID, userID)
+	if errGet != nil {
+		return entity.Conversation{}, errGet
+	}
+
+	conversation, errUpdate := tcu.talkbotCommandRepository.UpdateConversationTitle(conversationID, title)
+	if errUpdate != nil {
+		return entity.Conversation{}, errUpdate
+	}
+
+	return conversation, nil
+}
+
+func (tcu *talkbotCommandUsecase) DeleteConversation(conversationID string, userID string) error {
+	_, errGet := ownConversation(tcu.talkbotQueryRepository, conversationID, userID)
+	if errGet != nil {
+		return errGet
+	}
+
+	errDelete := tcu.talkbotCommandRepository.DeleteConversation(conversationID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+func (tcu *talkbotCommandUsecase) DeleteConversations(userID string) error {
+	errDelete := tcu.talkbotCommandRepository.DeleteConversationsByUserID(userID)
+	if errDelete != nil {
+		return errDelete
+	}
+
+	return nil
+}
+
+// UpdateQuotaLimits replaces the plan limits of a user with limits set by an
+// admin, until they are deleted again.
+func (tcu *talkbotCommandUsecase) UpdateQuotaLimits(userID string, limits entity.QuotaValues) (entity.Quota, error) {
+	errValidate := limits.Validate()
+	if errValidate != nil {
+		return entity.Quota{}, errValidate
+	}
+
+	_, errGet := tcu.userQueryRepository.GetUserByID(userID)
+	if errGet != nil {
+		return entity.Quota{}, errGet
+	}
+
+	errUpdate := tcu.talkbotCommandRepository.SetQuotaOverride(userID, limits)
+	if errUpdate != nil {
+		return entity.Quota{}, errUpdate
+	}
+
+	return quotaOf(tcu.talkbotQueryRepository, tcu.userQueryRepository, userID, time.Now())
+}
+
+// DeleteQuotaLimits puts a user back on the limits of their plan.
+func (tcu *talkbotCommandUsecase) DeleteQuotaLimits(userID string) (entity.Quota, error) {
+	_, errGet := tcu.userQueryRepository.GetUserByID(userID)
+	if errGet != nil {
+		return entity.Quota{}, errGet
+	}
+
+	errDelete := tcu.talkbotCommandRepository.DeleteQuotaOverride(userID)
+	if errDelete != nil {
+		return entity.Quota{}, errDelete
+	}
+
+	return quotaOf(tcu.talkbotQueryRepository, tcu.userQueryRepository, userID, time.Now())
+}
@@ -3,11 +3,23 @@ package usecase
 import (
 	"context"
 	"talkspace-api/modules/talkbot/entity"
-
-	"github.com/sashabaranov/go-openai"
+	"talkspace-api/utils/helper/chat"
 )
 
+type TalkbotCommandUsecaseInterface interface {
+	UpdateConversationTitle(conversationID string, userID string, title string) (entity.Conversation, error)
+	DeleteConversation(conversationID string, userID string) error
+	DeleteConversations(userID string) error
+	UpdateQuotaLimits(userID string, limits entity.QuotaValues) (entity.Quota, error)
+	DeleteQuotaLimits(userID string) (entity.Quota, error)
+}
+
 type TalkbotQueryUsecaseInterface interface {
-	GetTalkBotPrompt(userID string, talkbot entity.Talkbot, key string) (string, error)
-	GetCompletionMessages(ctx context.Context, client *openai.Client, messages []openai.ChatCompletionMessage, model string) (openai.ChatCompletionResponse, error)
+	GetQuota(userID string) (entity.Quota, error)
+	GetConversations(userID string, page, limit int) ([]entity.Conversation, int, error)
+	GetConversationMessages(conversationID string, userID string, page, limit int) ([]entity.Talkbot, int, error)
+	GetTalkBotPrompt(ctx context.Context, userID string, talkbot entity.Talkbot) (entity.TalkbotReply, error)
+	StreamTalkBotPrompt(ctx context.Context, userID string, talkbot entity.Talkbot, onStart func(reply entity.TalkbotReply) error, onDelta func(delta string) error) (entity.TalkbotReply, error)
+	GetCompletionMessages(ctx context.Context, request chat.Request) (chat.Response, error)
+	StreamCompletionMessages(ctx context.Context, request chat.Request, onDelta func(delta string) error) (chat.Response, error)
 }
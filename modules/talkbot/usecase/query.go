@@ -2,90 +2,506 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"os"
+	"strconv"
+	"strings"
+	"talkspace-api/app/configs"
+	ee "talkspace-api/modules/escalation/entity"
+	eu "talkspace-api/modules/escalation/usecase"
 	"talkspace-api/modules/talkbot/entity"
 	"talkspace-api/modules/talkbot/repository"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/chat"
+	"talkspace-api/utils/helper/risk"
+	"time"
 
-	"github.com/sashabaranov/go-openai"
+	"github.com/sirupsen/logrus"
 )
 
 type talkbotQueryUsecase struct {
 	talkbotCommandRepository repository.TalkbotCommandRepositoryInterface
 	talkbotQueryRepository   repository.TalkbotQueryRepositoryInterface
+	userQueryRepository      ur.UserQueryRepositoryInterface
+	escalationCommandUsecase eu.EscalationCommandUsecaseInterface
+	chatProvider             chat.ChatProvider
 }
 
-func NewTalkbotQueryUsecase(tcr repository.TalkbotCommandRepositoryInterface, tqr repository.TalkbotQueryRepositoryInterface) TalkbotQueryUsecaseInterface {
+func NewTalkbotQueryUsecase(tcr repository.TalkbotCommandRepositoryInterface, tqr repository.TalkbotQueryRepositoryInterface, uqr ur.UserQueryRepositoryInterface, ecu eu.EscalationCommandUsecaseInterface, chatProvider chat.ChatProvider) TalkbotQueryUsecaseInterface {
 	return &talkbotQueryUsecase{
 		talkbotCommandRepository: tcr,
 		talkbotQueryRepository:   tqr,
+		userQueryRepository:      uqr,
+		escalationCommandUsecase: ecu,
+		chatProvider:             chatProvider,
 	}
 }
 
-func (tqs *talkbotQueryUsecase) GetCompletionMessages(ctx context.Context, client *openai.Client, messages []openai.ChatCompletionMessage, model string) (openai.ChatCompletionResponse, error) {
-	if model == "" {
-		model = openai.GPT3Dot5Turbo
+func (tqs *talkbotQueryUsecase) GetQuota(userID string) (entity.Quota, error) {
+	quota, errGet := quotaOf(tqs.talkbotQueryRepository, tqs.userQueryRepository, userID, time.Now())
+	if errGet != nil {
+		return entity.Quota{}, errGet
 	}
 
-	promptResponse, err := client.CreateChatCompletion(
-		ctx,
-		openai.ChatCompletionRequest{
-			Model:    model,
-			Messages: messages,
-		},
-	)
-	return promptResponse, err
+	return quota, nil
+}
+
+func (tqs *talkbotQueryUsecase) GetConversations(userID string, page, limit int) ([]entity.Conversation, int, error) {
+	conversations, totalItems, errGet := tqs.talkbotQueryRepository.GetConversationsByUserID(userID, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return conversations, totalItems, nil
+}
+
+func (tqs *talkbotQueryUsecase) GetConversationMessages(conversationID string, userID string, page, limit int) ([]entity.Talkbot, int, error) {
+	_, errGet := ownConversation(tqs.talkbotQueryRepository, conversationID, userID)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	messages, totalItems, errGet := tqs.talkbotQueryRepository.GetMessagesByConversationID(conversationID, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return messages, totalItems, nil
+}
+
+// GetCompletionMessages asks the chat provider to complete the messages.
+func (tqs *talkbotQueryUsecase) GetCompletionMessages(ctx context.Context, request chat.Request) (chat.Response, error) {
+	response, err := tqs.chatProvider.Complete(ctx, request)
+	if err != nil {
+		return chat.Response{}, completionError(err)
+	}
+
+	return response, nil
+}
+
+// StreamCompletionMessages asks the chat provider to complete the messages
+// and passes the reply to onDelta as it arrives. A stream that breaks off
+// returns the part of the reply that was passed on with the error.
+func (tqs *talkbotQueryUsecase) StreamCompletionMessages(ctx context.Context, request chat.Request, onDelta func(delta string) error) (chat.Response, error) {
+	response, err := tqs.chatProvider.Stream(ctx, request, onDelta)
+	if err != nil {
+		return response, completionError(err)
+	}
+
+	return response, nil
+}
+
+// completionError logs a provider error and replaces it with a message fit
+// for users. A request the user walked away from is not worth a log.
+func completionError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	logrus.Errorf("failed to complete talkbot messages: %v", err)
+
+	switch {
+	case errors.Is(err, chat.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return errors.New(constant.ERROR_TALKBOT_TIMEOUT)
+	case errors.Is(err, chat.ErrRateLimited):
+		return errors.New(constant.ERROR_TALKBOT_BUSY)
+	case errors.Is(err, chat.ErrRejected):
+		return errors.New(constant.ERROR_TALKBOT_REJECTED)
+	default:
+		return errors.New(constant.ERROR_TALKBOT_UNAVAILABLE)
+	}
 }
 
-func (tqs *talkbotQueryUsecase) GetTalkBotPrompt(userID string, talkbot entity.Talkbot, key string) (string, error) {
+// GetTalkBotPrompt answers a message of the user within a conversation, a
+// new one when the message names none. The message is screened for
+// self-harm risk and stored before the bot is asked, so a flagged message is
+// escalated even when the completion fails. The reply is stored as well so
+// the bot remembers its own answers.
+func (tqs *talkbotQueryUsecase) GetTalkBotPrompt(ctx context.Context, userID string, talkbot entity.Talkbot) (entity.TalkbotReply, error) {
+	return tqs.answer(ctx, userID, talkbot, nil, nil)
+}
+
+// StreamTalkBotPrompt answers like GetTalkBotPrompt while passing the reply
+// to onDelta as it arrives. onStart is called once the message is stored,
+// before the bot is asked. A reply cut off by ctx or a failing onDelta is
+// stored as far as it reached the user and marked as interrupted.
+func (tqs *talkbotQueryUsecase) StreamTalkBotPrompt(ctx context.Context, userID string, talkbot entity.Talkbot, onStart func(reply entity.TalkbotReply) error, onDelta func(delta string) error) (entity.TalkbotReply, error) {
+	return tqs.answer(ctx, userID, talkbot, onStart, onDelta)
+}
+
+// answer streams the reply when onDelta is set and completes it at once
+// otherwise.
+func (tqs *talkbotQueryUsecase) answer(ctx context.Context, userID string, talkbot entity.Talkbot, onStart func(reply entity.TalkbotReply) error, onDelta func(delta string) error) (entity.TalkbotReply, error) {
+	if strings.TrimSpace(talkbot.Message) == "" {
+		return entity.TalkbotReply{}, errors.New(constant.ERROR_MESSAGE_EMPTY)
+	}
+
 	filePath := "utils/helper/prompt/talkbot-prompt-setup.txt"
 
 	promptSetup, err := os.ReadFile(filePath)
 	if err != nil {
-		return "", err
+		return entity.TalkbotReply{}, err
+	}
+
+	assessment, err := tqs.escalationCommandUsecase.Assess(talkbot.Message)
+	if err != nil {
+		logrus.Errorf("failed to screen talkbot message: %v", err)
+		assessment = risk.Assessment{}
+	}
+
+	// neither a used up quota nor one that cannot be read keeps a user from
+	// support, a flagged message is always answered and an outage is logged.
+	// The message is reserved up front and given back unless some of the
+	// reply reached the user.
+	now := time.Now()
+	replied := false
+	quota, errQuota := tqs.reserveQuota(userID, now)
+	if errQuota != nil {
+		logrus.Errorf("failed to reserve talkbot quota of user %s: %v", userID, errQuota)
+	} else {
+		defer func() {
+			if !replied {
+				tqs.addUsage(userID, -1, 0, now)
+			}
+		}()
+	}
+
+	if errQuota == nil && quota.Overdrawn() && !assessment.Flagged {
+		return entity.TalkbotReply{}, errors.New(constant.ERROR_TALKBOT_QUOTA)
+	}
+
+	conversation, err := tqs.conversation(userID, talkbot)
+	if err != nil {
+		return entity.TalkbotReply{}, err
+	}
+
+	history, err := tqs.talkbotQueryRepository.GetConversationMessages(conversation.ID, conversation.SummarizedUntil)
+	if err != nil {
+		return entity.TalkbotReply{}, err
+	}
+
+	userMessage, err := tqs.talkbotCommandRepository.SaveMessage(entity.Talkbot{
+		ConversationID: conversation.ID,
+		UserID:         userID,
+		Role:           constant.TALKBOT_USER,
+		Message:        talkbot.Message,
+		Flagged:        assessment.Flagged,
+	})
+	if err != nil {
+		return entity.TalkbotReply{}, err
+	}
+
+	if assessment.Flagged {
+		_, errEscalate := tqs.escalationCommandUsecase.Escalate(ee.Escalation{
+			Source:      constant.ESCALATION_TALKBOT,
+			ReferenceID: userMessage.ID,
+			UserID:      userID,
+		}, assessment)
+		if errEscalate != nil {
+			logrus.Errorf("failed to escalate talkbot message %s: %v", userMessage.ID, errEscalate)
+		}
+	}
+
+	if onStart != nil {
+		errStart := onStart(entity.TalkbotReply{
+			ConversationID: conversation.ID,
+			Flagged:        assessment.Flagged,
+		})
+		if errStart != nil {
+			return entity.TalkbotReply{}, errStart
+		}
+	}
+
+	system := []string{string(promptSetup)}
+	if assessment.Flagged {
+		system = append(system, entity.CrisisPrompt)
+	}
+
+	messages, summaryTokens := tqs.prompt(ctx, conversation, history, system, talkbot.Message)
+	request := chat.Request{
+		Messages: messages,
+	}
+
+	var promptResponse chat.Response
+	if onDelta == nil {
+		promptResponse, err = tqs.GetCompletionMessages(ctx, request)
+	} else {
+		promptResponse, err = tqs.StreamCompletionMessages(ctx, request, onDelta)
+	}
+
+	// tokens are charged even for a reply that was cut off, the provider
+	// spent them all the same
+	tokens := summaryTokens
+	if promptResponse.Content != "" {
+		replied = true
+		tokens += spentTokens(request, promptResponse)
+	}
+	if tokens > 0 {
+		tqs.addUsage(userID, 0, tokens, now)
+	}
+
+	if err != nil {
+		tqs.interrupt(userMessage, promptResponse.Content)
+		return entity.TalkbotReply{}, err
+	}
+
+	assistantMessage, err := tqs.talkbotCommandRepository.SaveMessage(entity.Talkbot{
+		ConversationID: conversation.ID,
+		UserID:         userID,
+		Role:           constant.TALKBOT_ASSISTANT,
+		Message:        promptResponse.Content,
+	})
+	if err != nil {
+		return entity.TalkbotReply{}, err
+	}
+
+	reply := entity.TalkbotReply{
+		ConversationID: conversation.ID,
+		Message:        assistantMessage.Message,
+		Flagged:        assessment.Flagged,
+	}
+
+	if errQuota == nil {
+		spent := quota.Spend(0, tokens)
+		reply.Quota = &spent
+	}
+
+	return reply, nil
+}
+
+// interrupt records a reply that failed, so the conversation shows what the
+// user saw: the part of the reply that reached them, or that the message got
+// no reply at all.
+func (tqs *talkbotQueryUsecase) interrupt(userMessage entity.Talkbot, partial string) {
+	if partial == "" {
+		err := tqs.talkbotCommandRepository.MarkMessageInterrupted(userMessage.ID)
+		if err != nil {
+			logrus.Errorf("failed to mark talkbot message %s as interrupted: %v", userMessage.ID, err)
+		}
+		return
+	}
+
+	_, err := tqs.talkbotCommandRepository.SaveMessage(entity.Talkbot{
+		ConversationID: userMessage.ConversationID,
+		UserID:         userMessage.UserID,
+		Role:           constant.TALKBOT_ASSISTANT,
+		Message:        partial,
+		Interrupted:    true,
+	})
+	if err != nil {
+		logrus.Errorf("failed to save interrupted talkbot reply to message %s: %v", userMessage.ID, err)
+	}
+}
+
+// conversation returns the conversation the message continues, or starts one
+// named after the message.
+func (tqs *talkbotQueryUsecase) conversation(userID string, talkbot entity.Talkbot) (entity.Conversation, error) {
+	if talkbot.ConversationID == "" {
+		return tqs.talkbotCommandRepository.CreateConversation(entity.Conversation{
+			UserID: userID,
+			Title:  entity.Title(talkbot.Message),
+		})
+	}
+
+	return ownConversation(tqs.talkbotQueryRepository, talkbot.ConversationID, userID)
+}
+
+// reserveQuota reserves a message of the user and returns the quota with the
+// usage including it.
+func (tqs *talkbotQueryUsecase) reserveQuota(userID string, now time.Time) (entity.Quota, error) {
+	user, err := tqs.userQueryRepository.GetUserByID(userID)
+	if err != nil {
+		return entity.Quota{}, err
 	}
 
-	previousMessages, err := tqs.talkbotQueryRepository.GetUserMessages(userID)
+	override, err := tqs.talkbotQueryRepository.GetQuotaOverride(userID)
 	if err != nil {
-		return "", err
+		return entity.Quota{}, err
 	}
 
-	messages := []openai.ChatCompletionMessage{
+	used, err := tqs.talkbotCommandRepository.ReserveMessage(userID, now)
+	if err != nil {
+		return entity.Quota{}, err
+	}
+
+	return entity.NewQuota(userID, user.PremiumExpired, override, used, now), nil
+}
+
+// addUsage counts usage of the user, a failure is only logged so it does not
+// cost the user a reply.
+func (tqs *talkbotQueryUsecase) addUsage(userID string, messages int, tokens int, now time.Time) {
+	err := tqs.talkbotCommandRepository.AddUsage(userID, messages, tokens, now)
+	if err != nil {
+		logrus.Errorf("failed to count talkbot usage of user %s: %v", userID, err)
+	}
+}
+
+// quotaOf reads the limits and usage of a user. Limits follow the premium
+// status of the user unless an admin overrode them.
+func quotaOf(tqr repository.TalkbotQueryRepositoryInterface, uqr ur.UserQueryRepositoryInterface, userID string, now time.Time) (entity.Quota, error) {
+	user, err := uqr.GetUserByID(userID)
+	if err != nil {
+		return entity.Quota{}, err
+	}
+
+	override, err := tqr.GetQuotaOverride(userID)
+	if err != nil {
+		return entity.Quota{}, err
+	}
+
+	used, err := tqr.GetUsage(userID, now)
+	if err != nil {
+		return entity.Quota{}, err
+	}
+
+	return entity.NewQuota(userID, user.PremiumExpired, override, used, now), nil
+}
+
+// ownConversation returns a conversation of the user, others are off limits.
+func ownConversation(tqr repository.TalkbotQueryRepositoryInterface, conversationID string, userID string) (entity.Conversation, error) {
+	conversation, err := tqr.GetConversationByID(conversationID)
+	if err != nil {
+		return entity.Conversation{}, err
+	}
+
+	if conversation.UserID != userID {
+		return entity.Conversation{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	return conversation, nil
+}
+
+// prompt builds the messages sent to the bot: the system prompts, the
+// summary of the conversation so far, as many recent turns as fit the token
+// budget and the new message. Turns that no longer fit are folded into the
+// summary, which room is always kept for. It also returns the tokens spent
+// on summarizing.
+func (tqs *talkbotQueryUsecase) prompt(ctx context.Context, conversation entity.Conversation, history []entity.Talkbot, system []string, message string) ([]chat.Message, int) {
+	budget := contextTokens() - entity.SummaryMaxTokens - entity.EstimateTokens(message)
+	for _, content := range system {
+		budget -= entity.EstimateTokens(content)
+	}
+
+	older, recent := entity.Fit(history, budget)
+
+	summary := conversation.Summary
+	summaryTokens := 0
+	if len(older) > 0 {
+		newSummary, tokens, err := tqs.summarize(ctx, summary, older)
+		if err != nil {
+			logrus.Errorf("failed to summarize talkbot conversation %s: %v", conversation.ID, err)
+		} else {
+			summarizedUntil := older[len(older)-1].CreatedAt
+			errUpdate := tqs.talkbotCommandRepository.UpdateConversationSummary(conversation.ID, newSummary, summarizedUntil)
+			if errUpdate != nil {
+				logrus.Errorf("failed to save summary of talkbot conversation %s: %v", conversation.ID, errUpdate)
+			}
+			summary = newSummary
+			summaryTokens = tokens
+		}
+	}
+
+	messages := []chat.Message{
 		{
-			Role:    "system",
-			Content: string(promptSetup),
+			Role:    chat.RoleSystem,
+			Content: system[0],
 		},
 	}
 
-	for _, msg := range previousMessages {
-		messages = append(messages, openai.ChatCompletionMessage{
-			Role:    "user",
+	if summary != "" {
+		messages = append(messages, chat.Message{
+			Role:    chat.RoleSystem,
+			Content: entity.SummaryContext + summary,
+		})
+	}
+
+	for _, msg := range recent {
+		messages = append(messages, chat.Message{
+			Role:    msg.Role,
 			Content: msg.Message,
 		})
 	}
 
-	messages = append(messages, openai.ChatCompletionMessage{
-		Role:    "user",
-		Content: talkbot.Message,
+	for _, content := range system[1:] {
+		messages = append(messages, chat.Message{
+			Role:    chat.RoleSystem,
+			Content: content,
+		})
+	}
+
+	messages = append(messages, chat.Message{
+		Role:    chat.RoleUser,
+		Content: message,
 	})
 
-	ctx := context.Background()
-	client := openai.NewClient(key)
-	model := openai.GPT3Dot5Turbo
+	return messages, summaryTokens
+}
+
+// summarize folds older turns into the previous summary of a conversation.
+func (tqs *talkbotQueryUsecase) summarize(ctx context.Context, summary string, older []entity.Talkbot) (string, int, error) {
+	transcript := strings.Builder{}
+	if summary != "" {
+		transcript.WriteString(entity.SummaryContext + summary + "\n\n")
+	}
+
+	for _, msg := range older {
+		speaker := "Pengguna"
+		if msg.Role == constant.TALKBOT_ASSISTANT {
+			speaker = "TalkBot"
+		}
+		transcript.WriteString(speaker + ": " + msg.Message + "\n")
+	}
 
-	promptResponse, err := tqs.GetCompletionMessages(ctx, client, messages, model)
+	request := chat.Request{
+		MaxTokens: entity.SummaryMaxTokens,
+		Messages: []chat.Message{
+			{
+				Role:    chat.RoleSystem,
+				Content: entity.SummaryPrompt,
+			},
+			{
+				Role:    chat.RoleUser,
+				Content: transcript.String(),
+			},
+		},
+	}
+
+	response, err := tqs.GetCompletionMessages(ctx, request)
 	if err != nil {
-		return "", err
+		return "", 0, err
+	}
+
+	return response.Content, spentTokens(request, response), nil
+}
+
+// spentTokens is the usage the provider reported for a completion, or an
+// estimate when it reported none.
+func spentTokens(request chat.Request, response chat.Response) int {
+	if tokens := response.PromptTokens + response.CompletionTokens; tokens > 0 {
+		return tokens
 	}
 
-	response := promptResponse.Choices[0].Message.Content
-	userMessage := entity.Talkbot{
-		UserID:  userID,
-		Message: talkbot.Message,
+	tokens := entity.EstimateTokens(response.Content)
+	for _, message := range request.Messages {
+		tokens += entity.EstimateTokens(message.Content)
 	}
 
-	if err := tqs.talkbotCommandRepository.SaveUserMessage(userMessage); err != nil {
-		return "", err
+	return tokens
+}
+
+// contextTokens is the token budget of a prompt, OPENAI_CONTEXT_TOKENS or
+// DefaultContextTokens when it is not set.
+func contextTokens() int {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		return entity.DefaultContextTokens
 	}
 
-	return response, nil
+	tokens, err := strconv.Atoi(config.OPENAI.OPENAI_CONTEXT_TOKENS)
+	if err != nil || tokens <= 0 {
+		return entity.DefaultContextTokens
+	}
+
+	return tokens
 }
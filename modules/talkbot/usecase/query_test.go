@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"talkspace-api/modules/talkbot/entity"
+	"talkspace-api/modules/talkbot/repository"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/chat"
+	"testing"
+	"time"
+)
+
+// stubCommandRepository records the summaries saved by the usecase, the
+// other methods are not used by these tests.
+type stubCommandRepository struct {
+	repository.TalkbotCommandRepositoryInterface
+	summaries       []string
+	summarizedUntil time.Time
+}
+
+func (sr *stubCommandRepository) UpdateConversationSummary(conversationID string, summary string, summarizedUntil time.Time) error {
+	sr.summaries = append(sr.summaries, summary)
+	sr.summarizedUntil = summarizedUntil
+	return nil
+}
+
+// The numbers below are picked so that two turns of history fit: a context
+// of 350 tokens leaves 34 after the summary, system prompt and message, and
+// every turn takes 13.
+const (
+	testContextTokens = "350"
+	testSystem        = "Kamu adalah TalkBot."
+	testMessage       = "Aku sedih"
+)
+
+func testHistory(turns int) []entity.Talkbot {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	history := []entity.Talkbot{}
+	for i := 0; i < turns; i++ {
+		role := constant.TALKBOT_USER
+		if i%2 == 1 {
+			role = constant.TALKBOT_ASSISTANT
+		}
+		history = append(history, entity.Talkbot{
+			Role:      role,
+			Message:   fmt.Sprintf("pesan %02d ", i) + strings.Repeat("a", 27),
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	return history
+}
+
+func newTestUsecase(script ...chat.FakeReply) (*talkbotQueryUsecase, *stubCommandRepository, *chat.FakeProvider) {
+	commandRepository := &stubCommandRepository{}
+	provider := chat.NewFakeProvider(script...)
+
+	return &talkbotQueryUsecase{
+		talkbotCommandRepository: commandRepository,
+		chatProvider:             provider,
+	}, commandRepository, provider
+}
+
+func promptTokens(messages []chat.Message) int {
+	tokens := 0
+	for _, message := range messages {
+		tokens += entity.EstimateTokens(message.Content)
+	}
+	return tokens
+}
+
+func TestPromptFitsRecentTurnsAndSummarizesTheRest(t *testing.T) {
+	t.Setenv("OPENAI_CONTEXT_TOKENS", testContextTokens)
+
+	tqs, commandRepository, provider := newTestUsecase(chat.FakeReply{Content: "Pengguna merasa sedih."})
+	history := testHistory(10)
+	conversation := entity.Conversation{ID: "conversation", Summary: "Ringkasan lama."}
+
+	messages, summaryTokens := tqs.prompt(context.Background(), conversation, history, []string{testSystem}, testMessage)
+
+	requests := provider.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("provider got %d requests, want one summary request", len(requests))
+	}
+
+	summaryRequest := requests[0]
+	if summaryRequest.MaxTokens != entity.SummaryMaxTokens {
+		t.Errorf("summary request MaxTokens = %d, want %d", summaryRequest.MaxTokens, entity.SummaryMaxTokens)
+	}
+	transcript := summaryRequest.Messages[1].Content
+	if !strings.Contains(transcript, "Ringkasan lama.") {
+		t.Errorf("summary transcript does not fold in the previous summary:\n%s", transcript)
+	}
+	for _, turn := range history[:8] {
+		if !strings.Contains(transcript, turn.Message) {
+			t.Errorf("summary transcript misses older turn %q", turn.Message)
+		}
+	}
+	for _, turn := range history[8:] {
+		if strings.Contains(transcript, turn.Message) {
+			t.Errorf("summary transcript holds recent turn %q", turn.Message)
+		}
+	}
+
+	if len(commandRepository.summaries) != 1 || commandRepository.summaries[0] != "Pengguna merasa sedih." {
+		t.Fatalf("saved summaries = %q", commandRepository.summaries)
+	}
+	if !commandRepository.summarizedUntil.Equal(history[7].CreatedAt) {
+		t.Errorf("summarized until %s, want %s", commandRepository.summarizedUntil, history[7].CreatedAt)
+	}
+	if summaryTokens == 0 {
+		t.Error("summary tokens are not counted")
+	}
+
+	want := []chat.Message{
+		{Role: chat.RoleSystem, Content: testSystem},
+		{Role: chat.RoleSystem, Content: entity.SummaryContext + "Pengguna merasa sedih."},
+		{Role: history[8].Role, Content: history[8].Message},
+		{Role: history[9].Role, Content: history[9].Message},
+		{Role: chat.RoleUser, Content: testMessage},
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("prompt has %d messages, want %d: %+v", len(messages), len(want), messages)
+	}
+	for i := range want {
+		if messages[i] != want[i] {
+			t.Errorf("prompt message %d = %+v, want %+v", i, messages[i], want[i])
+		}
+	}
+}
+
+func TestPromptStaysWithinBudget(t *testing.T) {
+	t.Setenv("OPENAI_CONTEXT_TOKENS", testContextTokens)
+
+	tqs, _, _ := newTestUsecase(chat.FakeReply{Content: strings.Repeat("ringkasan ", 100)})
+
+	messages, _ := tqs.prompt(context.Background(), entity.Conversation{ID: "conversation"}, testHistory(20), []string{testSystem}, testMessage)
+
+	// the summary is capped by SummaryMaxTokens at the provider, room for it
+	// is kept whatever it turns out to be
+	withoutSummary := promptTokens(messages) - entity.EstimateTokens(messages[1].Content)
+	if withoutSummary+entity.SummaryMaxTokens > 350 {
+		t.Errorf("prompt takes %d tokens besides the summary, over the budget of 350", withoutSummary)
+	}
+}
+
+func TestPromptWithoutOverflowDoesNotSummarize(t *testing.T) {
+	t.Setenv("OPENAI_CONTEXT_TOKENS", "1000")
+
+	tqs, commandRepository, provider := newTestUsecase()
+	history := testHistory(2)
+
+	messages, summaryTokens := tqs.prompt(context.Background(), entity.Conversation{ID: "conversation"}, history, []string{testSystem, entity.CrisisPrompt}, testMessage)
+
+	if len(provider.Requests()) != 0 || len(commandRepository.summaries) != 0 || summaryTokens != 0 {
+		t.Errorf("a history that fits was summarized")
+	}
+
+	// further system prompts follow the history, right before the message
+	want := []string{testSystem, history[0].Message, history[1].Message, entity.CrisisPrompt, testMessage}
+	if len(messages) != len(want) {
+		t.Fatalf("prompt has %d messages, want %d", len(messages), len(want))
+	}
+	for i := range want {
+		if messages[i].Content != want[i] {
+			t.Errorf("prompt message %d = %q, want %q", i, messages[i].Content, want[i])
+		}
+	}
+}
+
+func TestPromptKeepsPreviousSummaryWhenSummarizingFails(t *testing.T) {
+	t.Setenv("OPENAI_CONTEXT_TOKENS", testContextTokens)
+
+	tqs, commandRepository, _ := newTestUsecase(chat.FakeReply{Err: chat.ErrUnavailable})
+	history := testHistory(10)
+	conversation := entity.Conversation{ID: "conversation", Summary: "Ringkasan lama."}
+
+	messages, summaryTokens := tqs.prompt(context.Background(), conversation, history, []string{testSystem}, testMessage)
+
+	if len(commandRepository.summaries) != 0 || summaryTokens != 0 {
+		t.Errorf("a failed summary was saved or counted")
+	}
+	if messages[1].Content != entity.SummaryContext+"Ringkasan lama." {
+		t.Errorf("prompt message 1 = %q, want the previous summary", messages[1].Content)
+	}
+	if len(messages) != 5 || messages[2].Content != history[8].Message {
+		t.Errorf("prompt does not hold the two recent turns: %+v", messages)
+	}
+}
+
+func TestGetCompletionMessagesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"timeout", fmt.Errorf("%w: no answer", chat.ErrTimeout), constant.ERROR_TALKBOT_TIMEOUT},
+		{"deadline", context.DeadlineExceeded, constant.ERROR_TALKBOT_TIMEOUT},
+		{"rate limited", fmt.Errorf("%w: 429", chat.ErrRateLimited), constant.ERROR_TALKBOT_BUSY},
+		{"rejected", fmt.Errorf("%w: 400", chat.ErrRejected), constant.ERROR_TALKBOT_REJECTED},
+		{"unavailable", fmt.Errorf("%w: 500", chat.ErrUnavailable), constant.ERROR_TALKBOT_UNAVAILABLE},
+		{"unknown", errors.New("boom"), constant.ERROR_TALKBOT_UNAVAILABLE},
+		{"cancelled", context.Canceled, context.Canceled.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tqs, _, _ := newTestUsecase(chat.FakeReply{Err: tt.err})
+
+			_, err := tqs.GetCompletionMessages(context.Background(), chat.Request{})
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("GetCompletionMessages() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreamCompletionMessagesKeepsPartialReply(t *testing.T) {
+	tqs, _, _ := newTestUsecase(chat.FakeReply{Content: "Aku di sini untukmu"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	response, err := tqs.StreamCompletionMessages(ctx, chat.Request{}, func(delta string) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("StreamCompletionMessages() error = %v, want %v", err, context.Canceled)
+	}
+	if response.Content != "Aku " {
+		t.Errorf("StreamCompletionMessages() returned %q, want the relayed part", response.Content)
+	}
+}
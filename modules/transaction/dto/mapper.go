@@ -1 +1,62 @@
-package dto
\ No newline at end of file
+package dto
+
+import (
+	"talkspace-api/modules/transaction/entity"
+	"talkspace-api/utils/helper/midtrans"
+)
+
+// Request
+func TransactionUpdateStatusRequestToTransactionEntity(request TransactionUpdateStatusRequest) entity.Transaction {
+	return entity.Transaction{
+		Status: request.Status,
+	}
+}
+
+func TransactionNotificationRequestToNotification(request TransactionNotificationRequest) midtrans.Notification {
+	return midtrans.Notification{
+		OrderID:           request.OrderID,
+		StatusCode:        request.StatusCode,
+		GrossAmount:       request.GrossAmount,
+		SignatureKey:      request.SignatureKey,
+		PaymentType:       request.PaymentType,
+		TransactionStatus: request.TransactionStatus,
+		FraudStatus:       request.FraudStatus,
+	}
+}
+
+// Response
+func TransactionEntityToTransactionResponse(response entity.Transaction) TransactionResponse {
+	return TransactionResponse{
+		ID:             response.ID,
+		Code:           response.Code,
+		UserID:         response.UserID,
+		DoctorID:       response.DoctorID,
+		SubscriptionID: response.SubscriptionID,
+		AppointmentID:  response.AppointmentID,
+		Type:           response.Type,
+		Amount:         response.Amount,
+		Method:         response.Method,
+		Status:         response.Status,
+		PaymentToken:   response.PaymentToken,
+		PaymentURL:     response.PaymentURL,
+		PaidAt:         response.PaidAt,
+		CreatedAt:      response.CreatedAt,
+		UpdatedAt:      response.UpdatedAt,
+	}
+}
+
+func ListTransactionEntityToTransactionResponse(response []entity.Transaction) []TransactionResponse {
+	transactionResponses := []TransactionResponse{}
+	for _, transaction := range response {
+		transactionResponse := TransactionEntityToTransactionResponse(transaction)
+		transactionResponses = append(transactionResponses, transactionResponse)
+	}
+	return transactionResponses
+}
+
+func TransactionEntityToTransactionUpdateStatusResponse(response entity.Transaction) TransactionUpdateStatusResponse {
+	return TransactionUpdateStatusResponse{
+		ID:     response.ID,
+		Status: response.Status,
+	}
+}
@@ -1 +1,26 @@
-package dto
\ No newline at end of file
+package dto
+
+type (
+	TransactionUpdateStatusRequest struct {
+		Status string `json:"status" form:"status"`
+	}
+
+	TransactionRefundRequest struct {
+		Amount float64 `json:"amount" form:"amount"`
+		Reason string  `json:"reason" form:"reason"`
+	}
+
+	TransactionNotificationRequest struct {
+		OrderID           string `json:"order_id"`
+		StatusCode        string `json:"status_code"`
+		GrossAmount       string `json:"gross_amount"`
+		SignatureKey      string `json:"signature_key"`
+		PaymentType       string `json:"payment_type"`
+		TransactionStatus string `json:"transaction_status"`
+		FraudStatus       string `json:"fraud_status"`
+	}
+
+	TransactionSimulateRequest struct {
+		Status string `json:"status" form:"status"`
+	}
+)
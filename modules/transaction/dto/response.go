@@ -1 +1,28 @@
-package dto
\ No newline at end of file
+package dto
+
+import "time"
+
+type (
+	TransactionResponse struct {
+		ID             string     `json:"id"`
+		Code           string     `json:"code"`
+		UserID         string     `json:"user_id"`
+		DoctorID       string     `json:"doctor_id"`
+		SubscriptionID string     `json:"subscription_id"`
+		AppointmentID  string     `json:"appointment_id"`
+		Type           string     `json:"type"`
+		Amount         float64    `json:"amount"`
+		Method         string     `json:"method"`
+		Status         string     `json:"status"`
+		PaymentToken   string     `json:"payment_token"`
+		PaymentURL     string     `json:"payment_url"`
+		PaidAt         *time.Time `json:"paid_at"`
+		CreatedAt      time.Time  `json:"created_at"`
+		UpdatedAt      time.Time  `json:"updated_at"`
+	}
+
+	TransactionUpdateStatusResponse struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+)
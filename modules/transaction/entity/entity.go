@@ -1 +1,39 @@
-package entity
\ No newline at end of file
+package entity
+
+import (
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type Transaction struct {
+	ID             string
+	DoctorID       string
+	UserID         string
+	SubscriptionID string
+	AppointmentID  string
+	Type           string
+	Status         string
+	Amount         float64
+	Method         string
+	Code           string
+	PaymentToken   string
+	PaymentURL     string
+	PaidAt         *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time
+}
+
+var transactionTransitions = map[string][]string{
+	constant.TRANSACTION_PENDING: {constant.TRANSACTION_PAID, constant.TRANSACTION_EXPIRED, constant.TRANSACTION_CANCELLED},
+	constant.TRANSACTION_PAID:    {constant.TRANSACTION_REFUNDED},
+}
+
+func IsValidTransactionTransition(from, to string) bool {
+	for _, status := range transactionTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
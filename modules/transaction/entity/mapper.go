@@ -1 +1,81 @@
-package entity
\ No newline at end of file
+package entity
+
+import "talkspace-api/modules/transaction/model"
+
+func TransactionEntityToTransactionModel(transactionEntity Transaction) model.Transaction {
+	transactionModel := model.Transaction{
+		ID:             transactionEntity.ID,
+		DoctorID:       nullableString(transactionEntity.DoctorID),
+		UserID:         transactionEntity.UserID,
+		SubscriptionID: nullableString(transactionEntity.SubscriptionID),
+		AppointmentID:  nullableString(transactionEntity.AppointmentID),
+		Type:           transactionEntity.Type,
+		Status:         transactionEntity.Status,
+		Amount:         transactionEntity.Amount,
+		Method:         transactionEntity.Method,
+		Code:           transactionEntity.Code,
+		PaymentToken:   transactionEntity.PaymentToken,
+		PaymentURL:     transactionEntity.PaymentURL,
+		PaidAt:         transactionEntity.PaidAt,
+		CreatedAt:      transactionEntity.CreatedAt,
+		UpdatedAt:      transactionEntity.UpdatedAt,
+		DeletedAt:      transactionEntity.DeletedAt,
+	}
+	return transactionModel
+}
+
+func ListTransactionEntityToTransactionModel(transactionEntities []Transaction) []model.Transaction {
+	listTransactionModel := []model.Transaction{}
+	for _, transaction := range transactionEntities {
+		transactionModel := TransactionEntityToTransactionModel(transaction)
+		listTransactionModel = append(listTransactionModel, transactionModel)
+	}
+	return listTransactionModel
+}
+
+func TransactionModelToTransactionEntity(transactionModel model.Transaction) Transaction {
+	transactionEntity := Transaction{
+		ID:             transactionModel.ID,
+		DoctorID:       stringValue(transactionModel.DoctorID),
+		UserID:         transactionModel.UserID,
+		SubscriptionID: stringValue(transactionModel.SubscriptionID),
+		AppointmentID:  stringValue(transactionModel.AppointmentID),
+		Type:           transactionModel.Type,
+		Status:         transactionModel.Status,
+		Amount:         transactionModel.Amount,
+		Method:         transactionModel.Method,
+		Code:           transactionModel.Code,
+		PaymentToken:   transactionModel.PaymentToken,
+		PaymentURL:     transactionModel.PaymentURL,
+		PaidAt:         transactionModel.PaidAt,
+		CreatedAt:      transactionModel.CreatedAt,
+		UpdatedAt:      transactionModel.UpdatedAt,
+		DeletedAt:      transactionModel.DeletedAt,
+	}
+	return transactionEntity
+}
+
+func ListTransactionModelToTransactionEntity(transactionModels []model.Transaction) []Transaction {
+	listTransactionEntity := []Transaction{}
+	for _, transaction := range transactionModels {
+		transactionEntity := TransactionModelToTransactionEntity(transaction)
+		listTransactionEntity = append(listTransactionEntity, transactionEntity)
+	}
+	return listTransactionEntity
+}
+
+// nullableString keeps optional references such as the doctor of a
+// subscription payment as NULL so their foreign keys stay valid.
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
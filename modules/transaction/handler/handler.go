@@ -1 +1,322 @@
-package handler
\ No newline at end of file
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/transaction/dto"
+	"talkspace-api/modules/transaction/entity"
+	"talkspace-api/modules/transaction/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type transactionHandler struct {
+	transactionCommandUsecase usecase.TransactionCommandUsecaseInterface
+	transactionQueryUsecase   usecase.TransactionQueryUsecaseInterface
+}
+
+func NewTransactionHandler(tcu usecase.TransactionCommandUsecaseInterface, tqu usecase.TransactionQueryUsecaseInterface) *transactionHandler {
+	return &transactionHandler{
+		transactionCommandUsecase: tcu,
+		transactionQueryUsecase:   tqu,
+	}
+}
+
+// Query
+func (th *transactionHandler) GetTransactionByID(c echo.Context) error {
+	transactionIDParam := c.Param("transaction_id")
+	if transactionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	transaction, errGetID := th.transactionQueryUsecase.GetTransactionByID(transactionIDParam)
+	if errGetID != nil {
+		if errGetID.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGetID.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGetID.Error()))
+	}
+
+	if !canAccessTransaction(transaction, tokenID, role) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	transactionResponse := dto.TransactionEntityToTransactionResponse(transaction)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, transactionResponse))
+}
+
+func (th *transactionHandler) GetTransactionsByUserID(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenUserID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN && (role != constant.USER || userIDParam != tokenUserID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	transactions, totalItems, errGet := th.transactionQueryUsecase.GetTransactionsByUserID(userIDParam, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(transactions) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	transactionResponses := dto.ListTransactionEntityToTransactionResponse(transactions)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		transactionResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (th *transactionHandler) GetTransactionsByDoctorID(c echo.Context) error {
+	doctorIDParam := c.Param("doctor_id")
+	if doctorIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenDoctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN && (role != constant.DOCTOR || doctorIDParam != tokenDoctorID) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	transactions, totalItems, errGet := th.transactionQueryUsecase.GetTransactionsByDoctorID(doctorIDParam, page, limit)
+	if errGet != nil {
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(errGet.Error()))
+	}
+
+	if len(transactions) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	transactionResponses := dto.ListTransactionEntityToTransactionResponse(transactions)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		transactionResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Command
+func (th *transactionHandler) UpdateTransactionStatus(c echo.Context) error {
+	transactionIDParam := c.Param("transaction_id")
+	if transactionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	transactionRequest := dto.TransactionUpdateStatusRequest{}
+
+	errBind := c.Bind(&transactionRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	transactionEntity := dto.TransactionUpdateStatusRequestToTransactionEntity(transactionRequest)
+
+	transaction, errUpdate := th.transactionCommandUsecase.UpdateTransactionStatus(transactionIDParam, transactionEntity.Status)
+	if errUpdate != nil {
+		if errUpdate.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errUpdate.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errUpdate.Error()))
+	}
+
+	transactionResponse := dto.TransactionEntityToTransactionUpdateStatusResponse(transaction)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_STATUS_UPDATED, transactionResponse))
+}
+
+func (th *transactionHandler) SyncTransactionPayment(c echo.Context) error {
+	transactionIDParam := c.Param("transaction_id")
+	if transactionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	transaction, errGetID := th.transactionQueryUsecase.GetTransactionByID(transactionIDParam)
+	if errGetID != nil {
+		if errGetID.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGetID.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGetID.Error()))
+	}
+
+	if !canAccessTransaction(transaction, tokenID, role) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	transaction, errSync := th.transactionCommandUsecase.SyncTransactionPayment(transactionIDParam)
+	if errSync != nil {
+		if errSync.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errSync.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errSync.Error()))
+	}
+
+	transactionResponse := dto.TransactionEntityToTransactionResponse(transaction)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, transactionResponse))
+}
+
+func (th *transactionHandler) RefundTransaction(c echo.Context) error {
+	transactionIDParam := c.Param("transaction_id")
+	if transactionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	_, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.ADMIN {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	refundRequest := dto.TransactionRefundRequest{}
+
+	errBind := c.Bind(&refundRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	transaction, errRefund := th.transactionCommandUsecase.RefundTransaction(transactionIDParam, refundRequest.Amount, refundRequest.Reason)
+	if errRefund != nil {
+		if errRefund.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errRefund.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errRefund.Error()))
+	}
+
+	transactionResponse := dto.TransactionEntityToTransactionResponse(transaction)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_PAYMENT_REFUNDED, transactionResponse))
+}
+
+func (th *transactionHandler) HandlePaymentNotification(c echo.Context) error {
+	notificationRequest := dto.TransactionNotificationRequest{}
+
+	errBind := c.Bind(&notificationRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	notification := dto.TransactionNotificationRequestToNotification(notificationRequest)
+
+	transaction, errNotification := th.transactionCommandUsecase.HandlePaymentNotification(notification)
+	if errNotification != nil {
+		switch errNotification.Error() {
+		case constant.ERROR_PAYMENT_SIGNATURE:
+			return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errNotification.Error()))
+		case constant.ERROR_ID_NOTFOUND:
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errNotification.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errNotification.Error()))
+	}
+
+	transactionResponse := dto.TransactionEntityToTransactionUpdateStatusResponse(transaction)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_NOTIFICATION, transactionResponse))
+}
+
+func (th *transactionHandler) SimulateTransactionPayment(c echo.Context) error {
+	transactionIDParam := c.Param("transaction_id")
+	if transactionIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	transaction, errGetID := th.transactionQueryUsecase.GetTransactionByID(transactionIDParam)
+	if errGetID != nil {
+		if errGetID.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errGetID.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errGetID.Error()))
+	}
+
+	if role == constant.DOCTOR || !canAccessTransaction(transaction, tokenID, role) {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	simulateRequest := dto.TransactionSimulateRequest{}
+
+	errBind := c.Bind(&simulateRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	transaction, errSimulate := th.transactionCommandUsecase.SimulateTransactionPayment(transactionIDParam, simulateRequest.Status)
+	if errSimulate != nil {
+		if errSimulate.Error() == constant.ERROR_ID_NOTFOUND {
+			return c.JSON(http.StatusNotFound, responses.ErrorResponse(errSimulate.Error()))
+		}
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errSimulate.Error()))
+	}
+
+	transactionResponse := dto.TransactionEntityToTransactionResponse(transaction)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_STATUS_UPDATED, transactionResponse))
+}
+
+func canAccessTransaction(transaction entity.Transaction, tokenID string, role string) bool {
+	switch role {
+	case constant.USER:
+		return transaction.UserID == tokenID
+	case constant.DOCTOR:
+		return transaction.DoctorID == tokenID
+	case constant.ADMIN:
+		return true
+	}
+	return false
+}
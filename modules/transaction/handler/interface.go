@@ -1 +1,17 @@
-package handler
\ No newline at end of file
+package handler
+
+import "github.com/labstack/echo/v4"
+
+type TransactionHandlerInterface interface {
+	// Query
+	GetTransactionByID(c echo.Context) error
+	GetTransactionsByUserID(c echo.Context) error
+	GetTransactionsByDoctorID(c echo.Context) error
+
+	// Command
+	UpdateTransactionStatus(c echo.Context) error
+	SyncTransactionPayment(c echo.Context) error
+	RefundTransaction(c echo.Context) error
+	HandlePaymentNotification(c echo.Context) error
+	SimulateTransactionPayment(c echo.Context) error
+}
@@ -1 +1,21 @@
-package model
\ No newline at end of file
+package model
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (t *Transaction) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	t.ID = UUID.String()
+
+	if t.Status == "" {
+		t.Status = "pending"
+	}
+
+	if t.Type == "" {
+		t.Type = "consultation"
+	}
+
+	return nil
+}
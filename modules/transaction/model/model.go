@@ -5,14 +5,20 @@ import (
 )
 
 type Transaction struct {
-	ID        string `gorm:"primarykey"`
-	DoctorID  string `gorm:"foreignKey:DoctorID"`
-	UserID    string `gorm:"foreignKey:UserID"`
-	Status    bool   `gorm:"not null;default:false"`
-	Amount    float64
-	Method    string
-	Code      string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time `gorm:"index"`
+	ID             string  `gorm:"primarykey"`
+	DoctorID       *string `gorm:"default:NULL"`
+	UserID         string  `gorm:"foreignKey:UserID"`
+	SubscriptionID *string `gorm:"index;default:NULL"`
+	AppointmentID  *string `gorm:"index;default:NULL"`
+	Type           string  `gorm:"type:varchar(20);not null;default:'consultation'"`
+	Status         string  `gorm:"type:varchar(20);not null;default:'pending'"`
+	Amount         float64
+	Method         string
+	Code           string `gorm:"uniqueIndex"`
+	PaymentToken   string
+	PaymentURL     string
+	PaidAt         *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      *time.Time `gorm:"index"`
 }
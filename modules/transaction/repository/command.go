@@ -1 +1,107 @@
-package repository
\ No newline at end of file
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"talkspace-api/modules/transaction/entity"
+	"talkspace-api/modules/transaction/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type transactionCommandRepository struct {
+	db  *gorm.DB
+	rdb *redis.Client
+}
+
+func NewTransactionCommandRepository(db *gorm.DB, rdb *redis.Client) TransactionCommandRepositoryInterface {
+	return &transactionCommandRepository{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+func (tcr *transactionCommandRepository) CreateTransaction(transaction entity.Transaction) (entity.Transaction, error) {
+	transactionModel := entity.TransactionEntityToTransactionModel(transaction)
+
+	result := tcr.db.Create(&transactionModel)
+	if result.Error != nil {
+		return entity.Transaction{}, result.Error
+	}
+
+	transactionEntity := entity.TransactionModelToTransactionEntity(transactionModel)
+
+	data, err := json.Marshal(transactionEntity)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+
+	cacheKey := "transaction:" + transactionEntity.ID
+	err = tcr.rdb.Set(context.Background(), cacheKey, data, 24*time.Hour).Err()
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+
+	return transactionEntity, nil
+}
+
+// UpdateTransactionStatus moves a transaction to status under a row lock. It
+// reports whether the row changed so callers can run side effects once, and
+// rejects transitions that are not allowed from the current status.
+func (tcr *transactionCommandRepository) UpdateTransactionStatus(id string, status string, method string) (entity.Transaction, bool, error) {
+	transactionModel := model.Transaction{}
+	changed := false
+
+	errTx := tcr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&transactionModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		if transactionModel.Status == status {
+			return nil
+		}
+
+		if !entity.IsValidTransactionTransition(transactionModel.Status, status) {
+			return errors.New(constant.ERROR_TRANSACTION_STATUS)
+		}
+
+		transactionModel.Status = status
+		if method != "" {
+			transactionModel.Method = method
+		}
+		if status == constant.TRANSACTION_PAID {
+			paidAt := time.Now()
+			transactionModel.PaidAt = &paidAt
+		}
+
+		changed = true
+		return tx.Save(&transactionModel).Error
+	})
+	if errTx != nil {
+		return entity.Transaction{}, false, errTx
+	}
+
+	transactionEntity := entity.TransactionModelToTransactionEntity(transactionModel)
+
+	data, err := json.Marshal(transactionEntity)
+	if err != nil {
+		return entity.Transaction{}, false, err
+	}
+
+	cacheKey := "transaction:" + id
+	err = tcr.rdb.Set(context.Background(), cacheKey, data, 24*time.Hour).Err()
+	if err != nil {
+		return entity.Transaction{}, false, err
+	}
+
+	return transactionEntity, changed, nil
+}
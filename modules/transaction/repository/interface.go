@@ -1 +1,16 @@
-package repository
\ No newline at end of file
+package repository
+
+import "talkspace-api/modules/transaction/entity"
+
+type TransactionCommandRepositoryInterface interface {
+	CreateTransaction(transaction entity.Transaction) (entity.Transaction, error)
+	UpdateTransactionStatus(id string, status string, method string) (entity.Transaction, bool, error)
+}
+
+type TransactionQueryRepositoryInterface interface {
+	GetTransactionByID(id string) (entity.Transaction, error)
+	GetTransactionByCode(code string) (entity.Transaction, error)
+	GetTransactionsByUserID(userID string, page, limit int) ([]entity.Transaction, int, error)
+	GetTransactionsByDoctorID(doctorID string, page, limit int) ([]entity.Transaction, int, error)
+	GetPendingTransactionsBySubscriptionID(subscriptionID string) ([]entity.Transaction, error)
+}
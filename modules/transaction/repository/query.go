@@ -1 +1,111 @@
-package repository
\ No newline at end of file
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"talkspace-api/modules/transaction/entity"
+	"talkspace-api/modules/transaction/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+type transactionQueryRepository struct {
+	db  *gorm.DB
+	rdb *redis.Client
+}
+
+func NewTransactionQueryRepository(db *gorm.DB, rdb *redis.Client) TransactionQueryRepositoryInterface {
+	return &transactionQueryRepository{
+		db:  db,
+		rdb: rdb,
+	}
+}
+
+func (tqr *transactionQueryRepository) GetTransactionByID(id string) (entity.Transaction, error) {
+	cacheKey := "transaction:" + id
+	cachedTransaction, err := tqr.rdb.Get(context.Background(), cacheKey).Result()
+	if err == nil && cachedTransaction != "" {
+		var transaction entity.Transaction
+		if err := json.Unmarshal([]byte(cachedTransaction), &transaction); err != nil {
+			return entity.Transaction{}, err
+		}
+		return transaction, nil
+	}
+
+	transactionModel := model.Transaction{}
+	result := tqr.db.Where("id = ?", id).First(&transactionModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Transaction{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Transaction{}, result.Error
+	}
+
+	transactionEntity := entity.TransactionModelToTransactionEntity(transactionModel)
+
+	transactionData, err := json.Marshal(transactionEntity)
+	if err == nil {
+		tqr.rdb.Set(context.Background(), cacheKey, string(transactionData), 10*time.Minute)
+	}
+
+	return transactionEntity, nil
+}
+
+func (tqr *transactionQueryRepository) GetTransactionByCode(code string) (entity.Transaction, error) {
+	transactionModel := model.Transaction{}
+	result := tqr.db.Where("code = ?", code).First(&transactionModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.Transaction{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.Transaction{}, result.Error
+	}
+
+	transactionEntity := entity.TransactionModelToTransactionEntity(transactionModel)
+
+	return transactionEntity, nil
+}
+
+func (tqr *transactionQueryRepository) GetTransactionsByUserID(userID string, page, limit int) ([]entity.Transaction, int, error) {
+	return tqr.getTransactions("user_id = ?", userID, page, limit)
+}
+
+func (tqr *transactionQueryRepository) GetTransactionsByDoctorID(doctorID string, page, limit int) ([]entity.Transaction, int, error) {
+	return tqr.getTransactions("doctor_id = ?", doctorID, page, limit)
+}
+
+func (tqr *transactionQueryRepository) GetPendingTransactionsBySubscriptionID(subscriptionID string) ([]entity.Transaction, error) {
+	var transactionModels []model.Transaction
+	result := tqr.db.Where("subscription_id = ? AND status = ?", subscriptionID, constant.TRANSACTION_PENDING).Find(&transactionModels)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	transactions := entity.ListTransactionModelToTransactionEntity(transactionModels)
+
+	return transactions, nil
+}
+
+func (tqr *transactionQueryRepository) getTransactions(condition string, value string, page, limit int) ([]entity.Transaction, int, error) {
+	offset := (page - 1) * limit
+
+	var totalItems int64
+	result := tqr.db.Model(&model.Transaction{}).Where(condition, value).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var transactionModels []model.Transaction
+	result = tqr.db.Where(condition, value).Order("created_at DESC").Offset(offset).Limit(limit).Find(&transactionModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	transactions := entity.ListTransactionModelToTransactionEntity(transactionModels)
+
+	return transactions, int(totalItems), nil
+}
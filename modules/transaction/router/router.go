@@ -1 +1,50 @@
-package router
\ No newline at end of file
+package router
+
+import (
+	"talkspace-api/middlewares"
+	ar "talkspace-api/modules/appointment/repository"
+	cr "talkspace-api/modules/consultation/repository"
+	dr "talkspace-api/modules/doctor/repository"
+	sr "talkspace-api/modules/subscription/repository"
+	"talkspace-api/modules/transaction/handler"
+	"talkspace-api/modules/transaction/repository"
+	"talkspace-api/modules/transaction/usecase"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/helper/midtrans"
+
+	"github.com/labstack/echo/v4"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+func TransactionRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
+	transactionQueryRepository := repository.NewTransactionQueryRepository(db, rdb)
+	transactionCommandRepository := repository.NewTransactionCommandRepository(db, rdb)
+	doctorQueryRepository := dr.NewDoctorQueryRepository(db, rdb)
+	userQueryRepository := ur.NewUserQueryRepository(db, rdb)
+	consultationCommandRepository := cr.NewConsultationCommandRepository(db, rdb)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
+	appointmentCommandRepository := ar.NewAppointmentCommandRepository(db)
+	subscriptionCommandRepository := sr.NewSubscriptionCommandRepository(db, rdb)
+	subscriptionQueryRepository := sr.NewSubscriptionQueryRepository(db)
+	paymentGateway := midtrans.NewPaymentGateway()
+
+	transactionQueryUsecase := usecase.NewTransactionQueryUsecase(transactionCommandRepository, transactionQueryRepository)
+	transactionCommandUsecase := usecase.NewTransactionCommandUsecase(transactionCommandRepository, transactionQueryRepository, doctorQueryRepository, userQueryRepository, consultationCommandRepository, consultationQueryRepository, appointmentCommandRepository, subscriptionCommandRepository, subscriptionQueryRepository, paymentGateway)
+
+	transactionHandler := handler.NewTransactionHandler(transactionCommandUsecase, transactionQueryUsecase)
+
+	e.POST("/notifications", transactionHandler.HandlePaymentNotification)
+	e.GET("/:transaction_id", transactionHandler.GetTransactionByID, middlewares.JWTMiddleware(false))
+	e.PATCH("/:transaction_id/status", transactionHandler.UpdateTransactionStatus, middlewares.JWTMiddleware(false))
+	e.GET("/users/:user_id", transactionHandler.GetTransactionsByUserID, middlewares.JWTMiddleware(false))
+	e.GET("/doctors/:doctor_id", transactionHandler.GetTransactionsByDoctorID, middlewares.JWTMiddleware(false))
+
+	payment := e.Group("/:transaction_id/payment", middlewares.JWTMiddleware(false))
+	payment.GET("", transactionHandler.SyncTransactionPayment)
+	payment.POST("/refund", transactionHandler.RefundTransaction)
+
+	if _, ok := paymentGateway.(*midtrans.FakeGateway); ok {
+		payment.POST("/simulate", transactionHandler.SimulateTransactionPayment)
+	}
+}
@@ -1 +1,583 @@
-package usecase
\ No newline at end of file
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	ae "talkspace-api/modules/appointment/entity"
+	ar "talkspace-api/modules/appointment/repository"
+	ce "talkspace-api/modules/consultation/entity"
+	cr "talkspace-api/modules/consultation/repository"
+	dr "talkspace-api/modules/doctor/repository"
+	sr "talkspace-api/modules/subscription/repository"
+	"talkspace-api/modules/transaction/entity"
+	"talkspace-api/modules/transaction/repository"
+	ur "talkspace-api/modules/user/repository"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/generator"
+	"talkspace-api/utils/helper/email/mailer"
+	"talkspace-api/utils/helper/midtrans"
+	"talkspace-api/utils/validator"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type transactionCommandUsecase struct {
+	transactionCommandRepository  repository.TransactionCommandRepositoryInterface
+	transactionQueryRepository    repository.TransactionQueryRepositoryInterface
+	doctorQueryRepository         dr.DoctorQueryRepositoryInterface
+	userQueryRepository           ur.UserQueryRepositoryInterface
+	consultationCommandRepository cr.ConsultationCommandRepositoryInterface
+	consultationQueryRepository   cr.ConsultationQueryRepositoryInterface
+	appointmentCommandRepository  ar.AppointmentCommandRepositoryInterface
+	subscriptionCommandRepository sr.SubscriptionCommandRepositoryInterface
+	subscriptionQueryRepository   sr.SubscriptionQueryRepositoryInterface
+	paymentGateway                midtrans.PaymentGateway
+}
+
+func NewTransactionCommandUsecase(tcr repository.TransactionCommandRepositoryInterface, tqr repository.TransactionQueryRepositoryInterface, dqr dr.DoctorQueryRepositoryInterface, uqr ur.UserQueryRepositoryInterface, ccr cr.ConsultationCommandRepositoryInterface, cqr cr.ConsultationQueryRepositoryInterface, acr ar.AppointmentCommandRepositoryInterface, scr sr.SubscriptionCommandRepositoryInterface, sqr sr.SubscriptionQueryRepositoryInterface, pg midtrans.PaymentGateway) TransactionCommandUsecaseInterface {
+	return &transactionCommandUsecase{
+		transactionCommandRepository:  tcr,
+		transactionQueryRepository:    tqr,
+		doctorQueryRepository:         dqr,
+		userQueryRepository:           uqr,
+		consultationCommandRepository: ccr,
+		consultationQueryRepository:   cqr,
+		appointmentCommandRepository:  acr,
+		subscriptionCommandRepository: scr,
+		subscriptionQueryRepository:   sqr,
+		paymentGateway:                pg,
+	}
+}
+
+func (tcu *transactionCommandUsecase) CreateTransaction(transaction entity.Transaction) (entity.Transaction, error) {
+	errEmpty := validator.IsDataEmpty([]string{"user_id", "doctor_id", "appointment_id"}, transaction.UserID, transaction.DoctorID, transaction.AppointmentID)
+	if errEmpty != nil {
+		return entity.Transaction{}, errEmpty
+	}
+
+	doctor, errGetDoctor := tcu.doctorQueryRepository.GetDoctorByID(transaction.DoctorID)
+	if errGetDoctor != nil {
+		return entity.Transaction{}, errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	if !doctor.Status {
+		return entity.Transaction{}, errors.New(constant.ERROR_DOCTOR_INACTIVE)
+	}
+
+	transaction.Type = constant.TRANSACTION_CONSULTATION
+	transaction.SubscriptionID = ""
+	transaction.Amount = doctor.Price
+
+	// the payment page closes when the booking releases its slot
+	return tcu.createCharge(transaction, doctor.ID, "Consultation with "+doctor.Fullname, ae.PaymentHold)
+}
+
+func (tcu *transactionCommandUsecase) CreateSubscriptionTransaction(transaction entity.Transaction, itemName string) (entity.Transaction, error) {
+	errEmpty := validator.IsDataEmpty([]string{"user_id", "subscription_id"}, transaction.UserID, transaction.SubscriptionID)
+	if errEmpty != nil {
+		return entity.Transaction{}, errEmpty
+	}
+
+	if transaction.Amount <= 0 {
+		return entity.Transaction{}, errors.New(constant.ERROR_PLAN_PRICE)
+	}
+
+	transaction.Type = constant.TRANSACTION_SUBSCRIPTION
+	transaction.DoctorID = ""
+	transaction.AppointmentID = ""
+
+	return tcu.createCharge(transaction, transaction.SubscriptionID, itemName, 0)
+}
+
+func (tcu *transactionCommandUsecase) createCharge(transaction entity.Transaction, itemID string, itemName string, expiry time.Duration) (entity.Transaction, error) {
+	user, errGetUser := tcu.userQueryRepository.GetUserByID(transaction.UserID)
+	if errGetUser != nil {
+		return entity.Transaction{}, errGetUser
+	}
+
+	code, errGenerate := generator.GenerateTransactionCode()
+	if errGenerate != nil {
+		return entity.Transaction{}, errors.New(constant.ERROR_TRANSACTION_CODE)
+	}
+
+	transaction.Code = code
+	transaction.Status = constant.TRANSACTION_PENDING
+
+	charge, errCharge := tcu.paymentGateway.CreateCharge(midtrans.ChargeRequest{
+		OrderID:       transaction.Code,
+		Amount:        transaction.Amount,
+		ItemID:        itemID,
+		ItemName:      itemName,
+		CustomerName:  user.Fullname,
+		CustomerEmail: user.Email,
+		Expiry:        expiry,
+	})
+	if errCharge != nil {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_CHARGE)
+	}
+
+	transaction.PaymentToken = charge.Token
+	transaction.PaymentURL = charge.RedirectURL
+
+	transactionEntity, errCreate := tcu.transactionCommandRepository.CreateTransaction(transaction)
+	if errCreate != nil {
+		return entity.Transaction{}, errCreate
+	}
+
+	return transactionEntity, nil
+}
+
+func (tcu *transactionCommandUsecase) UpdateTransactionStatus(id string, status string) (entity.Transaction, error) {
+	if id == "" {
+		return entity.Transaction{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	validStatus := []interface{}{
+		constant.TRANSACTION_PENDING,
+		constant.TRANSACTION_PAID,
+		constant.TRANSACTION_EXPIRED,
+		constant.TRANSACTION_CANCELLED,
+		constant.TRANSACTION_REFUNDED,
+	}
+	errStatus := validator.IsDataValid(status, validStatus, true)
+	if errStatus != nil {
+		return entity.Transaction{}, errStatus
+	}
+
+	transaction, errGetID := tcu.transactionQueryRepository.GetTransactionByID(id)
+	if errGetID != nil {
+		return entity.Transaction{}, errGetID
+	}
+
+	return tcu.applyTransactionStatus(transaction, status, "")
+}
+
+func (tcu *transactionCommandUsecase) SyncTransactionPayment(id string) (entity.Transaction, error) {
+	if id == "" {
+		return entity.Transaction{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	transaction, errGetID := tcu.transactionQueryRepository.GetTransactionByID(id)
+	if errGetID != nil {
+		return entity.Transaction{}, errGetID
+	}
+
+	paymentStatus, errStatus := tcu.paymentGateway.GetStatus(transaction.Code)
+	if errStatus != nil {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_STATUS)
+	}
+
+	return tcu.applyPaymentStatus(transaction, paymentStatus)
+}
+
+func (tcu *transactionCommandUsecase) RefundTransaction(id string, amount float64, reason string) (entity.Transaction, error) {
+	if id == "" {
+		return entity.Transaction{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	errEmpty := validator.IsDataEmpty([]string{"reason"}, reason)
+	if errEmpty != nil {
+		return entity.Transaction{}, errEmpty
+	}
+
+	transaction, errGetID := tcu.transactionQueryRepository.GetTransactionByID(id)
+	if errGetID != nil {
+		return entity.Transaction{}, errGetID
+	}
+
+	if transaction.Status != constant.TRANSACTION_PAID {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_UNPAID)
+	}
+
+	// refunds are made in full: a refunded transaction takes back what it
+	// granted, which cannot be done in part
+	if amount <= 0 {
+		amount = transaction.Amount
+	}
+	if math.Round(amount) != math.Round(transaction.Amount) {
+		return entity.Transaction{}, errors.New(constant.ERROR_REFUND_AMOUNT)
+	}
+
+	paymentStatus, errRefund := tcu.paymentGateway.Refund(transaction.Code, amount, reason)
+	if errRefund != nil {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_REFUND)
+	}
+
+	return tcu.applyPaymentStatus(transaction, paymentStatus)
+}
+
+func (tcu *transactionCommandUsecase) HandlePaymentNotification(notification midtrans.Notification) (entity.Transaction, error) {
+	errEmpty := validator.IsDataEmpty([]string{"order_id", "status_code", "gross_amount", "signature_key", "transaction_status"},
+		notification.OrderID, notification.StatusCode, notification.GrossAmount, notification.SignatureKey, notification.TransactionStatus)
+	if errEmpty != nil {
+		return entity.Transaction{}, errEmpty
+	}
+
+	if !tcu.paymentGateway.VerifyNotification(notification) {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_SIGNATURE)
+	}
+
+	transaction, errGetCode := tcu.transactionQueryRepository.GetTransactionByCode(notification.OrderID)
+	if errGetCode != nil {
+		return entity.Transaction{}, errGetCode
+	}
+
+	if !sameAmount(notification.GrossAmount, transaction.Amount) {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_AMOUNT)
+	}
+
+	// the notification only says that something changed; the status applied
+	// is the one Midtrans reports now, so a replayed or reordered notification
+	// cannot move the transaction to a state it is no longer in
+	paymentStatus, errStatus := tcu.paymentGateway.GetStatus(transaction.Code)
+	if errStatus != nil {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_STATUS)
+	}
+
+	if !sameAmount(paymentStatus.GrossAmount, transaction.Amount) {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_AMOUNT)
+	}
+
+	transactionEntity, errApply := tcu.applyPaymentStatus(transaction, paymentStatus)
+	if errApply != nil {
+		// providers retry and may deliver notifications out of order; a
+		// transition that is no longer allowed is acknowledged and ignored
+		if errApply.Error() == constant.ERROR_TRANSACTION_STATUS {
+			return tcu.transactionQueryRepository.GetTransactionByID(transaction.ID)
+		}
+		return entity.Transaction{}, errApply
+	}
+
+	return transactionEntity, nil
+}
+
+func (tcu *transactionCommandUsecase) SimulateTransactionPayment(id string, status string) (entity.Transaction, error) {
+	fakeGateway, ok := tcu.paymentGateway.(*midtrans.FakeGateway)
+	if !ok {
+		return entity.Transaction{}, errors.New(constant.ERROR_PAYMENT_SIMULATE)
+	}
+
+	if id == "" {
+		return entity.Transaction{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	validStatus := []interface{}{"pending", "settlement", "expire", "cancel", "deny"}
+	errStatus := validator.IsDataValid(status, validStatus, true)
+	if errStatus != nil {
+		return entity.Transaction{}, errStatus
+	}
+
+	transaction, errGetID := tcu.transactionQueryRepository.GetTransactionByID(id)
+	if errGetID != nil {
+		return entity.Transaction{}, errGetID
+	}
+
+	_, errSimulate := fakeGateway.SetStatus(transaction.Code, status)
+	if errSimulate != nil {
+		return entity.Transaction{}, errSimulate
+	}
+
+	notification, errNotification := fakeGateway.Notification(transaction.Code)
+	if errNotification != nil {
+		return entity.Transaction{}, errNotification
+	}
+
+	return tcu.HandlePaymentNotification(notification)
+}
+
+// ExpireTransaction closes a pending transaction before its payment window
+// ends. It is expired at Midtrans first so its Snap token can no longer be
+// paid.
+func (tcu *transactionCommandUsecase) ExpireTransaction(id string) (entity.Transaction, error) {
+	if id == "" {
+		return entity.Transaction{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	transaction, errGetID := tcu.transactionQueryRepository.GetTransactionByID(id)
+	if errGetID != nil {
+		return entity.Transaction{}, errGetID
+	}
+
+	if transaction.Status != constant.TRANSACTION_PENDING {
+		return transaction, nil
+	}
+
+	_, errExpire := tcu.paymentGateway.Expire(transaction.Code)
+	if errExpire != nil {
+		// the payment may have gone through in the meantime, in which case
+		// the status Midtrans reports is applied instead
+		paymentStatus, errStatus := tcu.paymentGateway.GetStatus(transaction.Code)
+		if errStatus == nil && transactionStatusFromPayment(paymentStatus) != constant.TRANSACTION_PENDING {
+			return tcu.applyPaymentStatus(transaction, paymentStatus)
+		}
+		// a charge whose payment page was never opened doesn't exist at
+		// Midtrans yet, so it is only closed here
+		logrus.Warnf("failed to expire transaction %s at the payment gateway: %v", transaction.Code, errExpire)
+	}
+
+	return tcu.applyTransactionStatus(transaction, constant.TRANSACTION_EXPIRED, "")
+}
+
+// ExpireSubscriptionTransactions expires every unpaid checkout of a
+// subscription.
+func (tcu *transactionCommandUsecase) ExpireSubscriptionTransactions(subscriptionID string) error {
+	transactions, errGet := tcu.transactionQueryRepository.GetPendingTransactionsBySubscriptionID(subscriptionID)
+	if errGet != nil {
+		return errGet
+	}
+
+	for _, transaction := range transactions {
+		_, errExpire := tcu.ExpireTransaction(transaction.ID)
+		if errExpire != nil {
+			return errExpire
+		}
+	}
+
+	return nil
+}
+
+func (tcu *transactionCommandUsecase) applyPaymentStatus(transaction entity.Transaction, paymentStatus midtrans.StatusResponse) (entity.Transaction, error) {
+	status := transactionStatusFromPayment(paymentStatus)
+
+	transactionEntity, errApply := tcu.applyTransactionStatus(transaction, status, paymentStatus.PaymentType)
+	if errApply != nil && errApply.Error() == constant.ERROR_TRANSACTION_STATUS && status == constant.TRANSACTION_PAID {
+		tcu.refundRejectedPayment(transaction.ID)
+	}
+
+	return transactionEntity, errApply
+}
+
+// refundRejectedPayment returns money that reached Midtrans after the
+// transaction was closed here, for instance once an unpaid booking released
+// its slot. The transaction itself stays expired or cancelled.
+func (tcu *transactionCommandUsecase) refundRejectedPayment(id string) {
+	transaction, errGetID := tcu.transactionQueryRepository.GetTransactionByID(id)
+	if errGetID != nil {
+		logrus.Errorf("failed to refund payment of closed transaction %s: %v", id, errGetID)
+		return
+	}
+
+	if transaction.Status != constant.TRANSACTION_EXPIRED && transaction.Status != constant.TRANSACTION_CANCELLED {
+		return
+	}
+
+	_, errRefund := tcu.paymentGateway.Refund(transaction.Code, transaction.Amount, "payment received after the transaction was "+transaction.Status)
+	if errRefund != nil {
+		logrus.Errorf("failed to refund payment of closed transaction %s: %v", transaction.Code, errRefund)
+	}
+}
+
+func (tcu *transactionCommandUsecase) applyTransactionStatus(transaction entity.Transaction, status string, method string) (entity.Transaction, error) {
+	transactionEntity, changed, errUpdate := tcu.transactionCommandRepository.UpdateTransactionStatus(transaction.ID, status, method)
+	if errUpdate != nil {
+		return entity.Transaction{}, errUpdate
+	}
+
+	if transactionEntity.Status == constant.TRANSACTION_PAID {
+		errPaid := tcu.onTransactionPaid(transactionEntity, changed)
+		if errPaid != nil {
+			return entity.Transaction{}, errPaid
+		}
+	}
+
+	if (transactionEntity.Status == constant.TRANSACTION_EXPIRED || transactionEntity.Status == constant.TRANSACTION_CANCELLED) && changed {
+		errClosed := tcu.onTransactionClosed(transactionEntity)
+		if errClosed != nil {
+			return entity.Transaction{}, errClosed
+		}
+	}
+
+	if transactionEntity.Status == constant.TRANSACTION_REFUNDED && changed {
+		errRefunded := tcu.onTransactionRefunded(transactionEntity)
+		if errRefunded != nil {
+			return entity.Transaction{}, errRefunded
+		}
+	}
+
+	return transactionEntity, nil
+}
+
+// onTransactionPaid runs the side effects of a settled payment. Opening the
+// consultation room and activating the subscription are idempotent so they
+// are retried on every paid notification, while the receipt is only sent on
+// the first one.
+func (tcu *transactionCommandUsecase) onTransactionPaid(transaction entity.Transaction, changed bool) error {
+	itemName := "-"
+
+	switch transaction.Type {
+	case constant.TRANSACTION_SUBSCRIPTION:
+		subscription, errActivate := tcu.subscriptionCommandRepository.ActivateSubscription(transaction.SubscriptionID, transaction.ID)
+		if errActivate != nil {
+			return errActivate
+		}
+		itemName = "TalkSpace Premium - " + subscription.Plan.Name
+	default:
+		consultation := ce.Consultation{
+			TransactionID: transaction.ID,
+			UserID:        transaction.UserID,
+			DoctorID:      transaction.DoctorID,
+			Status:        constant.CONSULTATION_BOOKED,
+		}
+
+		if transaction.AppointmentID != "" {
+			appointment, _, errConfirm := tcu.appointmentCommandRepository.ConfirmAppointment(transaction.AppointmentID)
+			if errConfirm != nil {
+				if errConfirm.Error() != constant.ERROR_APPOINTMENT_STATUS {
+					return errConfirm
+				}
+				// the booking was cancelled while the payment was still open
+				// and its slot may be taken by now, so the money goes back
+				if changed {
+					_, errRefund := tcu.RefundTransaction(transaction.ID, 0, "appointment cancelled before payment")
+					if errRefund != nil {
+						logrus.Errorf("failed to refund transaction %s of a cancelled appointment: %v", transaction.Code, errRefund)
+					}
+				}
+				return nil
+			}
+
+			consultation.AppointmentID = appointment.ID
+			consultation.IntakeID = appointment.IntakeID
+			consultation.StartAt = &appointment.StartAt
+			consultation.EndAt = &appointment.EndAt
+			consultation.DurationMinutes = int(appointment.EndAt.Sub(appointment.StartAt).Minutes())
+		}
+
+		_, errGetRoom := tcu.consultationQueryRepository.GetConsultationByTransactionID(transaction.ID)
+		if errGetRoom != nil {
+			if errGetRoom.Error() != constant.ERROR_DATA_NOTFOUND {
+				return errGetRoom
+			}
+
+			_, errCreateRoom := tcu.consultationCommandRepository.CreateConsultation(consultation)
+			if errCreateRoom != nil {
+				return errCreateRoom
+			}
+		}
+		if doctor, errGetDoctor := tcu.doctorQueryRepository.GetDoctorByID(transaction.DoctorID); errGetDoctor == nil {
+			itemName = "Consultation with " + doctor.Fullname
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	user, errGetUser := tcu.userQueryRepository.GetUserByID(transaction.UserID)
+	if errGetUser != nil {
+		logrus.Errorf("failed to send payment receipt for transaction %s: %v", transaction.Code, errGetUser)
+		return nil
+	}
+
+	paidAt := time.Now()
+	if transaction.PaidAt != nil {
+		paidAt = *transaction.PaidAt
+	}
+
+	mailer.SendEmailPaymentReceipt(user.Email, map[string]string{
+		"Fullname": user.Fullname,
+		"Code":     transaction.Code,
+		"Item":     itemName,
+		"Amount":   fmt.Sprintf("Rp%.0f", transaction.Amount),
+		"Method":   transaction.Method,
+		"PaidAt":   paidAt.Format("02 January 2006 15:04"),
+	})
+
+	return nil
+}
+
+// onTransactionClosed releases the slot held by a booking whose payment
+// expired or was cancelled.
+func (tcu *transactionCommandUsecase) onTransactionClosed(transaction entity.Transaction) error {
+	if transaction.AppointmentID == "" {
+		return nil
+	}
+
+	_, errCancel := tcu.appointmentCommandRepository.CancelAppointment(transaction.AppointmentID, constant.SYSTEM, "payment "+transaction.Status)
+	if errCancel != nil && errCancel.Error() != constant.ERROR_APPOINTMENT_STATUS {
+		return errCancel
+	}
+
+	return nil
+}
+
+// onTransactionRefunded takes back what a refunded payment granted.
+func (tcu *transactionCommandUsecase) onTransactionRefunded(transaction entity.Transaction) error {
+	if transaction.Type != constant.TRANSACTION_SUBSCRIPTION {
+		return tcu.closeConsultation(transaction)
+	}
+
+	subscription, errGetSubscription := tcu.subscriptionQueryRepository.GetSubscriptionByID(transaction.SubscriptionID)
+	if errGetSubscription != nil {
+		return errGetSubscription
+	}
+
+	if subscription.LastTransactionID != transaction.ID {
+		return nil
+	}
+
+	_, errRevoke := tcu.subscriptionCommandRepository.RevokeSubscription(subscription.ID)
+
+	return errRevoke
+}
+
+// closeConsultation cancels the appointment and closes the room opened by a
+// refunded consultation payment.
+func (tcu *transactionCommandUsecase) closeConsultation(transaction entity.Transaction) error {
+	errClosed := tcu.onTransactionClosed(transaction)
+	if errClosed != nil {
+		return errClosed
+	}
+
+	consultation, errGetRoom := tcu.consultationQueryRepository.GetConsultationByTransactionID(transaction.ID)
+	if errGetRoom != nil {
+		if errGetRoom.Error() == constant.ERROR_DATA_NOTFOUND {
+			return nil
+		}
+		return errGetRoom
+	}
+
+	_, _, errCancel := tcu.consultationCommandRepository.UpdateConsultationStatus(consultation.ID, constant.CONSULTATION_CANCELLED, constant.SYSTEM)
+	if errCancel != nil && errCancel.Error() != constant.ERROR_CONSULTATION_STATUS {
+		return errCancel
+	}
+
+	return nil
+}
+
+// sameAmount reports whether a Midtrans gross_amount such as "150000.00" is
+// the amount charged for the transaction, which is always sent in whole rupiah.
+func sameAmount(grossAmount string, amount float64) bool {
+	gross, err := strconv.ParseFloat(grossAmount, 64)
+	if err != nil {
+		return false
+	}
+
+	return math.Round(gross) == math.Round(amount)
+}
+
+func transactionStatusFromPayment(paymentStatus midtrans.StatusResponse) string {
+	switch paymentStatus.TransactionStatus {
+	case "capture":
+		if paymentStatus.FraudStatus == "accept" || paymentStatus.FraudStatus == "" {
+			return constant.TRANSACTION_PAID
+		}
+		return constant.TRANSACTION_PENDING
+	case "settlement":
+		return constant.TRANSACTION_PAID
+	case "expire":
+		return constant.TRANSACTION_EXPIRED
+	case "cancel", "deny", "failure":
+		return constant.TRANSACTION_CANCELLED
+	case "refund":
+		return constant.TRANSACTION_REFUNDED
+	case "partial_refund":
+		// only made outside the app, what was paid for stays granted
+		return constant.TRANSACTION_PAID
+	default:
+		return constant.TRANSACTION_PENDING
+	}
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/helper/midtrans"
+	"testing"
+)
+
+func TestTransactionStatusFromPayment(t *testing.T) {
+	tests := []struct {
+		name              string
+		transactionStatus string
+		fraudStatus       string
+		want              string
+	}{
+		{"settlement", "settlement", "", constant.TRANSACTION_PAID},
+		{"capture accepted", "capture", "accept", constant.TRANSACTION_PAID},
+		{"capture without fraud status", "capture", "", constant.TRANSACTION_PAID},
+		{"capture challenged", "capture", "challenge", constant.TRANSACTION_PENDING},
+		{"capture denied by fraud check", "capture", "deny", constant.TRANSACTION_PENDING},
+		{"pending", "pending", "", constant.TRANSACTION_PENDING},
+		{"deny", "deny", "", constant.TRANSACTION_CANCELLED},
+		{"cancel", "cancel", "", constant.TRANSACTION_CANCELLED},
+		{"failure", "failure", "", constant.TRANSACTION_CANCELLED},
+		{"expire", "expire", "", constant.TRANSACTION_EXPIRED},
+		{"refund", "refund", "", constant.TRANSACTION_REFUNDED},
+		{"partial refund keeps the payment", "partial_refund", "", constant.TRANSACTION_PAID},
+		{"unknown", "authorize", "", constant.TRANSACTION_PENDING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := transactionStatusFromPayment(midtrans.StatusResponse{
+				TransactionStatus: tt.transactionStatus,
+				FraudStatus:       tt.fraudStatus,
+			})
+			if got != tt.want {
+				t.Errorf("transactionStatusFromPayment(%q, %q) = %q, want %q", tt.transactionStatus, tt.fraudStatus, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSameAmount(t *testing.T) {
+	tests := []struct {
+		grossAmount string
+		amount      float64
+		want        bool
+	}{
+		{"150000.00", 150000, true},
+		{"150000", 150000, true},
+		{"150000.00", 149999.6, true},
+		{"1.00", 150000, false},
+		{"", 150000, false},
+		{"abc", 150000, false},
+	}
+
+	for _, tt := range tests {
+		got := sameAmount(tt.grossAmount, tt.amount)
+		if got != tt.want {
+			t.Errorf("sameAmount(%q, %v) = %v, want %v", tt.grossAmount, tt.amount, got, tt.want)
+		}
+	}
+}
@@ -1 +1,24 @@
-package usecase
\ No newline at end of file
+package usecase
+
+import (
+	"talkspace-api/modules/transaction/entity"
+	"talkspace-api/utils/helper/midtrans"
+)
+
+type TransactionCommandUsecaseInterface interface {
+	CreateTransaction(transaction entity.Transaction) (entity.Transaction, error)
+	CreateSubscriptionTransaction(transaction entity.Transaction, itemName string) (entity.Transaction, error)
+	UpdateTransactionStatus(id string, status string) (entity.Transaction, error)
+	SyncTransactionPayment(id string) (entity.Transaction, error)
+	RefundTransaction(id string, amount float64, reason string) (entity.Transaction, error)
+	HandlePaymentNotification(notification midtrans.Notification) (entity.Transaction, error)
+	SimulateTransactionPayment(id string, status string) (entity.Transaction, error)
+	ExpireTransaction(id string) (entity.Transaction, error)
+	ExpireSubscriptionTransactions(subscriptionID string) error
+}
+
+type TransactionQueryUsecaseInterface interface {
+	GetTransactionByID(id string) (entity.Transaction, error)
+	GetTransactionsByUserID(userID string, page, limit int) ([]entity.Transaction, int, error)
+	GetTransactionsByDoctorID(doctorID string, page, limit int) ([]entity.Transaction, int, error)
+}
@@ -1 +1,59 @@
-package usecase 
\ No newline at end of file
+package usecase
+
+import (
+	"errors"
+	"talkspace-api/modules/transaction/entity"
+	"talkspace-api/modules/transaction/repository"
+	"talkspace-api/utils/constant"
+)
+
+type transactionQueryUsecase struct {
+	transactionCommandRepository repository.TransactionCommandRepositoryInterface
+	transactionQueryRepository   repository.TransactionQueryRepositoryInterface
+}
+
+func NewTransactionQueryUsecase(tcr repository.TransactionCommandRepositoryInterface, tqr repository.TransactionQueryRepositoryInterface) TransactionQueryUsecaseInterface {
+	return &transactionQueryUsecase{
+		transactionCommandRepository: tcr,
+		transactionQueryRepository:   tqr,
+	}
+}
+
+func (tqu *transactionQueryUsecase) GetTransactionByID(id string) (entity.Transaction, error) {
+	if id == "" {
+		return entity.Transaction{}, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	transactionEntity, errGetID := tqu.transactionQueryRepository.GetTransactionByID(id)
+	if errGetID != nil {
+		return entity.Transaction{}, errGetID
+	}
+
+	return transactionEntity, nil
+}
+
+func (tqu *transactionQueryUsecase) GetTransactionsByUserID(userID string, page, limit int) ([]entity.Transaction, int, error) {
+	if userID == "" {
+		return nil, 0, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	transactions, totalItems, err := tqu.transactionQueryRepository.GetTransactionsByUserID(userID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, totalItems, nil
+}
+
+func (tqu *transactionQueryUsecase) GetTransactionsByDoctorID(doctorID string, page, limit int) ([]entity.Transaction, int, error) {
+	if doctorID == "" {
+		return nil, 0, errors.New(constant.ERROR_ID_INVALID)
+	}
+
+	transactions, totalItems, err := tqu.transactionQueryRepository.GetTransactionsByDoctorID(doctorID, page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, totalItems, nil
+}
@@ -129,15 +129,60 @@ func UserEntityToUserResponse(response entity.User) UserResponse {
 	}
 }
 
-func ListUserEntityToUserListResponse(response []entity.User) []UserListResponse {
-	var listUserListResponse []UserListResponse
-	for _, user := range response {
-			listUserListResponse = append(listUserListResponse, UserListResponse{
-				ID:            user.ID,
-				Fullname:      user.Fullname,
-				Email:         user.Email,
-				RequestPremium: user.RequestPremium,
-			})
-	}
-	return listUserListResponse
+// dateLayout is how journal dates are written in requests and responses.
+const dateLayout = "2006-01-02"
+
+func MoodEntryRequestToMoodEntryEntity(request MoodEntryRequest) entity.MoodEntry {
+	return entity.MoodEntry{
+		Score:   request.Score,
+		Tags:    request.Tags,
+		Content: request.Content,
+	}
+}
+
+func MoodEntryEntityToMoodEntryResponse(response entity.MoodEntry) MoodEntryResponse {
+	return MoodEntryResponse{
+		ID:             response.ID,
+		UserID:         response.UserID,
+		Date:           response.Date.Format(dateLayout),
+		Score:          response.Score,
+		Tags:           response.Tags,
+		Content:        response.Content,
+		SharedDoctorID: response.SharedDoctorID,
+		SharedAt:       response.SharedAt,
+		CreatedAt:      response.CreatedAt,
+		UpdatedAt:      response.UpdatedAt,
+	}
+}
+
+func ListMoodEntryEntityToMoodEntryResponse(response []entity.MoodEntry) []MoodEntryResponse {
+	moodEntryResponses := []MoodEntryResponse{}
+	for _, moodEntry := range response {
+		moodEntryResponse := MoodEntryEntityToMoodEntryResponse(moodEntry)
+		moodEntryResponses = append(moodEntryResponses, moodEntryResponse)
+	}
+	return moodEntryResponses
+}
+
+func MoodStreakEntityToMoodStreakResponse(response entity.MoodStreak) MoodStreakResponse {
+	streakResponse := MoodStreakResponse{
+		Current: response.Current,
+		Longest: response.Longest,
+	}
+	if response.LastDate != nil {
+		streakResponse.LastDate = response.LastDate.Format(dateLayout)
+	}
+	return streakResponse
+}
+
+func ListMoodAverageEntityToMoodAverageResponse(response []entity.MoodAverage) []MoodAverageResponse {
+	moodAverageResponses := []MoodAverageResponse{}
+	for _, average := range response {
+		moodAverageResponses = append(moodAverageResponses, MoodAverageResponse{
+			PeriodStart: average.PeriodStart.Format(dateLayout),
+			Average:     average.Average,
+			Entries:     average.Entries,
+		})
+	}
+	return moodAverageResponses
 }
@@ -43,11 +43,17 @@ type (
 		Email string `json:"email" form:"email"`
 		OTP   string `json:"otp" form:"otp"`
 	}
-
-	UserVerifyPremium struct {
-		UserID string `json:"user_id" form:"user_id"`
-		Status string `json:"status" form:"status"`
-	}
 )
 
+type (
+	MoodEntryRequest struct {
+		Date    string   `json:"date" form:"date"`
+		Score   int      `json:"score" form:"score"`
+		Tags    []string `json:"tags" form:"tags"`
+		Content string   `json:"content" form:"content"`
+	}
 
+	MoodEntryShareRequest struct {
+		DoctorID string `json:"doctor_id" form:"doctor_id"`
+	}
+)
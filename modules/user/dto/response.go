@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type (
 	UserRegisterResponse struct {
 		ID         string `json:"id"`
@@ -45,11 +47,31 @@ type (
 		Email      string `json:"email"`
 	}
 
-	UserListResponse struct {
-		ID         string `json:"id"`
-		Fullname   string `json:"fullname"`
-		Email      string `json:"email"`
-		RequestPremium string `json:"request_premium"`
+)
+
+type (
+	MoodEntryResponse struct {
+		ID             string     `json:"id"`
+		UserID         string     `json:"user_id"`
+		Date           string     `json:"date"`
+		Score          int        `json:"score"`
+		Tags           []string   `json:"tags"`
+		Content        string     `json:"content"`
+		SharedDoctorID string     `json:"shared_doctor_id,omitempty"`
+		SharedAt       *time.Time `json:"shared_at,omitempty"`
+		CreatedAt      time.Time  `json:"created_at"`
+		UpdatedAt      time.Time  `json:"updated_at"`
 	}
 
+	MoodStreakResponse struct {
+		Current  int    `json:"current"`
+		Longest  int    `json:"longest"`
+		LastDate string `json:"last_date,omitempty"`
+	}
+
+	MoodAverageResponse struct {
+		PeriodStart string  `json:"period_start"`
+		Average     float64 `json:"average"`
+		Entries     int     `json:"entries"`
+	}
 )
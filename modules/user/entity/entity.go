@@ -2,6 +2,24 @@ package entity
 
 import "time"
 
+const (
+	MoodScoreMin = 1
+	MoodScoreMax = 5
+	// JournalContentMaxLength is the longest journal text accepted, in bytes.
+	JournalContentMaxLength = 5000
+	// MoodPeriodWeek and MoodPeriodMonth are the periods averages are
+	// grouped by. Weeks start on monday.
+	MoodPeriodWeek  = "week"
+	MoodPeriodMonth = "month"
+)
+
+// JournalTags are the tags a check-in can be labelled with.
+var JournalTags = map[string]bool{
+	"sleep":   true,
+	"anxiety": true,
+	"energy":  true,
+}
+
 type User struct {
 	ID              string
 	Email           string
@@ -16,7 +34,6 @@ type User struct {
 	Height          int
 	Weight          int
 	Role            string
-	RequestPremium  string
 	PremiumExpired  time.Time
 	OTP             string
 	OTPExpiration   int64
@@ -24,3 +41,32 @@ type User struct {
 	UpdatedAt       time.Time
 	DeletedAt       *time.Time
 }
+
+// MoodEntry is a daily check-in. Date is the calendar day it is for, at
+// midnight UTC.
+type MoodEntry struct {
+	ID             string
+	UserID         string
+	Date           time.Time
+	Score          int
+	Tags           []string
+	Content        string
+	SharedDoctorID string
+	SharedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// MoodStreak counts consecutive days with a check-in. The current streak
+// is still alive when the last check-in was yesterday.
+type MoodStreak struct {
+	Current  int
+	Longest  int
+	LastDate *time.Time
+}
+
+type MoodAverage struct {
+	PeriodStart time.Time
+	Average     float64
+	Entries     int
+}
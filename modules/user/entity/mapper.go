@@ -1,6 +1,9 @@
 package entity
 
-import "talkspace-api/modules/user/model"
+import (
+	"encoding/json"
+	"talkspace-api/modules/user/model"
+)
 
 func UserEntityToUserModel(userEntity User) model.User {
 	var gender *string
@@ -27,7 +30,6 @@ func UserEntityToUserModel(userEntity User) model.User {
 		Height:         userEntity.Height,
 		Weight:         userEntity.Weight,
 		Role:           userEntity.Role,
-		RequestPremium: userEntity.RequestPremium,
 		PremiumExpired: userEntity.PremiumExpired,
 		OTP:            userEntity.OTP,
 		OTPExpiration:  userEntity.OTPExpiration,
@@ -69,7 +71,6 @@ func UserModelToUserEntity(userModel model.User) User {
 		Height:         userModel.Height,
 		Weight:         userModel.Weight,
 		Role:           userModel.Role,
-		RequestPremium: userModel.RequestPremium,
 		PremiumExpired: userModel.PremiumExpired,
 		OTP:            userModel.OTP,
 		OTPExpiration:  userModel.OTPExpiration,
@@ -88,3 +89,60 @@ func ListUserModelToUserEntity(userModel []model.User) []User {
 	}
 	return listUserEntity
 }
+
+func MoodEntryEntityToMoodEntryModel(moodEntryEntity MoodEntry) model.MoodEntry {
+	tags, _ := json.Marshal(moodEntryEntity.Tags)
+
+	var sharedDoctorID *string
+	if moodEntryEntity.SharedDoctorID != "" {
+		sharedDoctorIDValue := moodEntryEntity.SharedDoctorID
+		sharedDoctorID = &sharedDoctorIDValue
+	}
+
+	moodEntryModel := model.MoodEntry{
+		ID:             moodEntryEntity.ID,
+		UserID:         moodEntryEntity.UserID,
+		Date:           moodEntryEntity.Date,
+		Score:          moodEntryEntity.Score,
+		Tags:           string(tags),
+		Content:        moodEntryEntity.Content,
+		SharedDoctorID: sharedDoctorID,
+		SharedAt:       moodEntryEntity.SharedAt,
+		CreatedAt:      moodEntryEntity.CreatedAt,
+		UpdatedAt:      moodEntryEntity.UpdatedAt,
+	}
+	return moodEntryModel
+}
+
+func MoodEntryModelToMoodEntryEntity(moodEntryModel model.MoodEntry) MoodEntry {
+	tags := []string{}
+	json.Unmarshal([]byte(moodEntryModel.Tags), &tags)
+
+	var sharedDoctorID string
+	if moodEntryModel.SharedDoctorID != nil {
+		sharedDoctorID = *moodEntryModel.SharedDoctorID
+	}
+
+	moodEntryEntity := MoodEntry{
+		ID:             moodEntryModel.ID,
+		UserID:         moodEntryModel.UserID,
+		Date:           moodEntryModel.Date,
+		Score:          moodEntryModel.Score,
+		Tags:           tags,
+		Content:        moodEntryModel.Content,
+		SharedDoctorID: sharedDoctorID,
+		SharedAt:       moodEntryModel.SharedAt,
+		CreatedAt:      moodEntryModel.CreatedAt,
+		UpdatedAt:      moodEntryModel.UpdatedAt,
+	}
+	return moodEntryEntity
+}
+
+func ListMoodEntryModelToMoodEntryEntity(moodEntryModels []model.MoodEntry) []MoodEntry {
+	listMoodEntryEntity := []MoodEntry{}
+	for _, moodEntry := range moodEntryModels {
+		moodEntryEntity := MoodEntryModelToMoodEntryEntity(moodEntry)
+		listMoodEntryEntity = append(listMoodEntryEntity, moodEntryEntity)
+	}
+	return listMoodEntryEntity
+}
@@ -5,7 +5,6 @@ import "github.com/labstack/echo/v4"
 type UserHandlerInterface interface {
 	// Query
 	GetUserByID(c echo.Context) error
-	GetRequestPremiumUsers(c echo.Context) error
 
 	// Command
 	RegisterUser(c echo.Context) error
@@ -15,6 +14,19 @@ type UserHandlerInterface interface {
 	ForgotUserPassword(c echo.Context) error
 	NewUserPassword(c echo.Context) error
 	VerifyUserOTP(c echo.Context) error
-	RequestPremium(c echo.Context) error
-	UpdateUserPremiumExpired(c echo.Context) error
+}
+
+type JournalHandlerInterface interface {
+	// Query
+	GetMoodEntryByID(c echo.Context) error
+	GetMoodEntries(c echo.Context) error
+	GetSharedMoodEntries(c echo.Context) error
+	GetMoodStreak(c echo.Context) error
+	GetMoodAverages(c echo.Context) error
+
+	// Command
+	CreateMoodEntry(c echo.Context) error
+	UpdateMoodEntry(c echo.Context) error
+	ShareMoodEntry(c echo.Context) error
+	DeleteMoodEntry(c echo.Context) error
 }
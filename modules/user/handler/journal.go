@@ -0,0 +1,301 @@
+package handler
+
+import (
+	"net/http"
+	"talkspace-api/middlewares"
+	"talkspace-api/modules/user/dto"
+	"talkspace-api/modules/user/usecase"
+	"talkspace-api/utils/constant"
+	"talkspace-api/utils/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+type journalHandler struct {
+	journalCommandUsecase usecase.JournalCommandUsecaseInterface
+	journalQueryUsecase   usecase.JournalQueryUsecaseInterface
+}
+
+func NewJournalHandler(jcu usecase.JournalCommandUsecaseInterface, jqu usecase.JournalQueryUsecaseInterface) *journalHandler {
+	return &journalHandler{
+		journalCommandUsecase: jcu,
+		journalQueryUsecase:   jqu,
+	}
+}
+
+// Query
+func (jh *journalHandler) GetMoodEntryByID(c echo.Context) error {
+	entryIDParam := c.Param("entry_id")
+	if entryIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	tokenID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	moodEntry, errGetID := jh.journalQueryUsecase.GetMoodEntryByID(entryIDParam, tokenID, role)
+	if errGetID != nil {
+		return journalError(c, errGetID)
+	}
+
+	moodEntryResponse := dto.MoodEntryEntityToMoodEntryResponse(moodEntry)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, moodEntryResponse))
+}
+
+func (jh *journalHandler) GetMoodEntries(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	moodEntries, totalItems, errGet := jh.journalQueryUsecase.GetMoodEntries(userID, c.QueryParam("from"), c.QueryParam("to"), page, limit)
+	if errGet != nil {
+		return journalError(c, errGet)
+	}
+
+	if len(moodEntries) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	moodEntryResponses := dto.ListMoodEntryEntityToMoodEntryResponse(moodEntries)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		moodEntryResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetSharedMoodEntries lists the entries a user shared with the requesting
+// doctor.
+func (jh *journalHandler) GetSharedMoodEntries(c echo.Context) error {
+	userIDParam := c.Param("user_id")
+	if userIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	doctorID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.DOCTOR {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	page, limit := responses.Pagination(c.QueryParam("page"), c.QueryParam("limit"))
+
+	moodEntries, totalItems, errGet := jh.journalQueryUsecase.GetSharedMoodEntries(userIDParam, doctorID, page, limit)
+	if errGet != nil {
+		return journalError(c, errGet)
+	}
+
+	if len(moodEntries) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	moodEntryResponses := dto.ListMoodEntryEntityToMoodEntryResponse(moodEntries)
+
+	response := responses.SuccessResponsePage(
+		constant.SUCCESS_RETRIEVED,
+		page,
+		limit,
+		int64(totalItems),
+		moodEntryResponses,
+	)
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func (jh *journalHandler) GetMoodStreak(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	streak, errGet := jh.journalQueryUsecase.GetMoodStreak(userID)
+	if errGet != nil {
+		return journalError(c, errGet)
+	}
+
+	streakResponse := dto.MoodStreakEntityToMoodStreakResponse(streak)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, streakResponse))
+}
+
+func (jh *journalHandler) GetMoodAverages(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	averages, errGet := jh.journalQueryUsecase.GetMoodAverages(userID, c.QueryParam("period"), c.QueryParam("from"), c.QueryParam("to"))
+	if errGet != nil {
+		return journalError(c, errGet)
+	}
+
+	if len(averages) == 0 {
+		return c.JSON(http.StatusOK, responses.SuccessResponse(constant.ERROR_DATA_EMPTY, nil))
+	}
+
+	averageResponses := dto.ListMoodAverageEntityToMoodAverageResponse(averages)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_RETRIEVED, averageResponses))
+}
+
+// Command
+func (jh *journalHandler) CreateMoodEntry(c echo.Context) error {
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	moodEntryRequest := dto.MoodEntryRequest{}
+
+	errBind := c.Bind(&moodEntryRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	moodEntryEntity := dto.MoodEntryRequestToMoodEntryEntity(moodEntryRequest)
+	moodEntryEntity.UserID = userID
+
+	moodEntry, errCreate := jh.journalCommandUsecase.CreateMoodEntry(moodEntryEntity, moodEntryRequest.Date)
+	if errCreate != nil {
+		return journalError(c, errCreate)
+	}
+
+	moodEntryResponse := dto.MoodEntryEntityToMoodEntryResponse(moodEntry)
+
+	return c.JSON(http.StatusCreated, responses.SuccessResponse(constant.SUCCESS_CREATED, moodEntryResponse))
+}
+
+func (jh *journalHandler) UpdateMoodEntry(c echo.Context) error {
+	entryIDParam := c.Param("entry_id")
+	if entryIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	moodEntryRequest := dto.MoodEntryRequest{}
+
+	errBind := c.Bind(&moodEntryRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	moodEntryEntity := dto.MoodEntryRequestToMoodEntryEntity(moodEntryRequest)
+
+	moodEntry, errUpdate := jh.journalCommandUsecase.UpdateMoodEntry(entryIDParam, userID, moodEntryEntity)
+	if errUpdate != nil {
+		return journalError(c, errUpdate)
+	}
+
+	moodEntryResponse := dto.MoodEntryEntityToMoodEntryResponse(moodEntry)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, moodEntryResponse))
+}
+
+func (jh *journalHandler) ShareMoodEntry(c echo.Context) error {
+	entryIDParam := c.Param("entry_id")
+	if entryIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	shareRequest := dto.MoodEntryShareRequest{}
+
+	errBind := c.Bind(&shareRequest)
+	if errBind != nil {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(errBind.Error()))
+	}
+
+	moodEntry, errUpdate := jh.journalCommandUsecase.ShareMoodEntry(entryIDParam, userID, shareRequest.DoctorID)
+	if errUpdate != nil {
+		return journalError(c, errUpdate)
+	}
+
+	moodEntryResponse := dto.MoodEntryEntityToMoodEntryResponse(moodEntry)
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_UPDATED, moodEntryResponse))
+}
+
+func (jh *journalHandler) DeleteMoodEntry(c echo.Context) error {
+	entryIDParam := c.Param("entry_id")
+	if entryIDParam == "" {
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(constant.ERROR_ID_NOTFOUND))
+	}
+
+	userID, role, errExtract := middlewares.ExtractToken(c)
+	if errExtract != nil {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(errExtract.Error()))
+	}
+
+	if role != constant.USER {
+		return c.JSON(http.StatusUnauthorized, responses.ErrorResponse(constant.ERROR_ROLE_ACCESS))
+	}
+
+	errDelete := jh.journalCommandUsecase.DeleteMoodEntry(entryIDParam, userID)
+	if errDelete != nil {
+		return journalError(c, errDelete)
+	}
+
+	return c.JSON(http.StatusOK, responses.SuccessResponse(constant.SUCCESS_DELETED, nil))
+}
+
+func journalError(c echo.Context, err error) error {
+	switch err.Error() {
+	case constant.ERROR_ID_NOTFOUND:
+		return c.JSON(http.StatusNotFound, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_ROLE_ACCESS:
+		return c.JSON(http.StatusForbidden, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_JOURNAL_EXIST:
+		return c.JSON(http.StatusConflict, responses.ErrorResponse(err.Error()))
+	case constant.ERROR_MOOD_SCORE, constant.ERROR_JOURNAL_TAG, constant.ERROR_JOURNAL_TOO_LONG, constant.ERROR_JOURNAL_FUTURE,
+		constant.ERROR_PERIOD_INVALID, constant.ERROR_DOCTOR_UNRELATED, constant.ERROR_DATE_FORMAT, constant.ERROR_TIME_RANGE:
+		return c.JSON(http.StatusBadRequest, responses.ErrorResponse(err.Error()))
+	default:
+		return c.JSON(http.StatusInternalServerError, responses.ErrorResponse(err.Error()))
+	}
+}
@@ -37,6 +37,12 @@ func (u *User) BeforeCreate(tx *gorm.DB) (err error) {
 	return nil
 }
 
+func (me *MoodEntry) BeforeCreate(tx *gorm.DB) (err error) {
+	UUID := uuid.New()
+	me.ID = UUID.String()
+	return nil
+}
+
 /*
 CREATE TYPE gender AS ENUM ('male', 'female');
 CREATE TYPE blood_type AS ENUM ('A', 'B', 'O', 'AB');
@@ -14,7 +14,6 @@ type User struct {
 	Height         int
 	Weight         int
 	Role           string `gorm:"type:role;default:'user'"`
-	RequestPremium string
 	PremiumExpired time.Time
 	OTP            string `gorm:"not null"`
 	OTPExpiration  int64
@@ -22,3 +21,18 @@ type User struct {
 	UpdatedAt      time.Time
 	DeletedAt      *time.Time `gorm:"index"`
 }
+
+// MoodEntry is the daily check-in of a user. Tags is a json array. Entries
+// are private unless shared with a doctor, one entry at a time.
+type MoodEntry struct {
+	ID             string    `gorm:"primarykey"`
+	UserID         string    `gorm:"uniqueIndex:idx_mood_entries_user_date;not null"`
+	Date           time.Time `gorm:"type:date;uniqueIndex:idx_mood_entries_user_date;not null"`
+	Score          int       `gorm:"not null"`
+	Tags           string    `gorm:"type:jsonb;not null;default:'[]'"`
+	Content        string    `gorm:"type:text"`
+	SharedDoctorID *string   `gorm:"index;default:NULL"`
+	SharedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
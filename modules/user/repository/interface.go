@@ -3,6 +3,7 @@ package repository
 import (
 	"mime/multipart"
 	"talkspace-api/modules/user/entity"
+	"time"
 )
 
 type UserCommandRepositoryInterface interface {
@@ -14,12 +15,24 @@ type UserCommandRepositoryInterface interface {
 	SendUserOTP(email string, otp string, expired int64) (entity.User, error)
 	VerifyUserOTP(email, otp string) (entity.User, error)
 	ResetUserOTP(otp string) (entity.User, error)
-	RequestPremium(user entity.User, request_premium string) (entity.User, error)
-	UpdateUserPremiumExpired(id string, status string) (entity.User, error)
 }
 
 type UserQueryRepositoryInterface interface {
 	GetUserByID(id string) (entity.User, error)
 	GetUserByEmail(email string) (entity.User, error)
-	GetRequestPremiumUsers() ([]entity.User, error)
+}
+
+type JournalCommandRepositoryInterface interface {
+	CreateMoodEntry(entry entity.MoodEntry) (entity.MoodEntry, error)
+	UpdateMoodEntry(id string, entry entity.MoodEntry) (entity.MoodEntry, error)
+	UpdateMoodEntrySharing(id string, doctorID string, at time.Time) (entity.MoodEntry, error)
+	DeleteMoodEntry(id string) error
+}
+
+type JournalQueryRepositoryInterface interface {
+	GetMoodEntryByID(id string) (entity.MoodEntry, error)
+	GetMoodEntriesByUserID(userID string, from time.Time, to time.Time, page, limit int) ([]entity.MoodEntry, int, error)
+	GetSharedMoodEntries(userID string, doctorID string, page, limit int) ([]entity.MoodEntry, int, error)
+	GetMoodEntryDates(userID string) ([]time.Time, error)
+	GetMoodAverages(userID string, period string, from time.Time, to time.Time) ([]entity.MoodAverage, error)
 }
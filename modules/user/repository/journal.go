@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"errors"
+	"talkspace-api/modules/user/entity"
+	"talkspace-api/modules/user/model"
+	"talkspace-api/utils/constant"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dateLayout is how journal dates are passed to queries, so the database
+// never converts them between time zones.
+const dateLayout = "2006-01-02"
+
+type journalCommandRepository struct {
+	db *gorm.DB
+}
+
+func NewJournalCommandRepository(db *gorm.DB) JournalCommandRepositoryInterface {
+	return &journalCommandRepository{
+		db: db,
+	}
+}
+
+func (jcr *journalCommandRepository) CreateMoodEntry(entry entity.MoodEntry) (entity.MoodEntry, error) {
+	moodEntryModel := entity.MoodEntryEntityToMoodEntryModel(entry)
+
+	errTx := jcr.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		result := tx.Model(&model.MoodEntry{}).Where("user_id = ? AND date = ?", entry.UserID, entry.Date.Format(dateLayout)).Count(&existing)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if existing > 0 {
+			return errors.New(constant.ERROR_JOURNAL_EXIST)
+		}
+
+		return tx.Create(&moodEntryModel).Error
+	})
+	if errTx != nil {
+		return entity.MoodEntry{}, errTx
+	}
+
+	moodEntryEntity := entity.MoodEntryModelToMoodEntryEntity(moodEntryModel)
+
+	return moodEntryEntity, nil
+}
+
+func (jcr *journalCommandRepository) UpdateMoodEntry(id string, entry entity.MoodEntry) (entity.MoodEntry, error) {
+	return jcr.updateMoodEntry(id, func(moodEntry *model.MoodEntry) {
+		update := entity.MoodEntryEntityToMoodEntryModel(entry)
+		moodEntry.Score = update.Score
+		moodEntry.Tags = update.Tags
+		moodEntry.Content = update.Content
+	})
+}
+
+// UpdateMoodEntrySharing shares an entry with a doctor, or makes it private
+// again when doctorID is empty.
+func (jcr *journalCommandRepository) UpdateMoodEntrySharing(id string, doctorID string, at time.Time) (entity.MoodEntry, error) {
+	return jcr.updateMoodEntry(id, func(moodEntry *model.MoodEntry) {
+		moodEntry.SharedDoctorID = nil
+		moodEntry.SharedAt = nil
+		if doctorID != "" {
+			moodEntry.SharedDoctorID = &doctorID
+			moodEntry.SharedAt = &at
+		}
+	})
+}
+
+func (jcr *journalCommandRepository) updateMoodEntry(id string, update func(moodEntry *model.MoodEntry)) (entity.MoodEntry, error) {
+	moodEntryModel := model.MoodEntry{}
+
+	errTx := jcr.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&moodEntryModel)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return errors.New(constant.ERROR_ID_NOTFOUND)
+			}
+			return result.Error
+		}
+
+		update(&moodEntryModel)
+
+		return tx.Save(&moodEntryModel).Error
+	})
+	if errTx != nil {
+		return entity.MoodEntry{}, errTx
+	}
+
+	moodEntryEntity := entity.MoodEntryModelToMoodEntryEntity(moodEntryModel)
+
+	return moodEntryEntity, nil
+}
+
+func (jcr *journalCommandRepository) DeleteMoodEntry(id string) error {
+	result := jcr.db.Where("id = ?", id).Delete(&model.MoodEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New(constant.ERROR_ID_NOTFOUND)
+	}
+
+	return nil
+}
+
+type journalQueryRepository struct {
+	db *gorm.DB
+}
+
+func NewJournalQueryRepository(db *gorm.DB) JournalQueryRepositoryInterface {
+	return &journalQueryRepository{
+		db: db,
+	}
+}
+
+func (jqr *journalQueryRepository) GetMoodEntryByID(id string) (entity.MoodEntry, error) {
+	moodEntryModel := model.MoodEntry{}
+	result := jqr.db.Where("id = ?", id).First(&moodEntryModel)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return entity.MoodEntry{}, errors.New(constant.ERROR_ID_NOTFOUND)
+		}
+		return entity.MoodEntry{}, result.Error
+	}
+
+	moodEntryEntity := entity.MoodEntryModelToMoodEntryEntity(moodEntryModel)
+
+	return moodEntryEntity, nil
+}
+
+// GetMoodEntriesByUserID returns the entries between two days, both
+// inclusive, newest first.
+func (jqr *journalQueryRepository) GetMoodEntriesByUserID(userID string, from time.Time, to time.Time, page, limit int) ([]entity.MoodEntry, int, error) {
+	return jqr.getMoodEntries(func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ? AND date >= ? AND date <= ?", userID, from.Format(dateLayout), to.Format(dateLayout))
+	}, page, limit)
+}
+
+func (jqr *journalQueryRepository) GetSharedMoodEntries(userID string, doctorID string, page, limit int) ([]entity.MoodEntry, int, error) {
+	return jqr.getMoodEntries(func(db *gorm.DB) *gorm.DB {
+		return db.Where("user_id = ? AND shared_doctor_id = ?", userID, doctorID)
+	}, page, limit)
+}
+
+func (jqr *journalQueryRepository) getMoodEntries(scope func(db *gorm.DB) *gorm.DB, page, limit int) ([]entity.MoodEntry, int, error) {
+	offset := (page - 1) * limit
+
+	var totalItems int64
+	result := jqr.db.Model(&model.MoodEntry{}).Scopes(scope).Count(&totalItems)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var moodEntryModels []model.MoodEntry
+	result = jqr.db.Scopes(scope).Order("date DESC").Offset(offset).Limit(limit).Find(&moodEntryModels)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	moodEntries := entity.ListMoodEntryModelToMoodEntryEntity(moodEntryModels)
+
+	return moodEntries, int(totalItems), nil
+}
+
+// GetMoodEntryDates returns every day the user checked in, oldest first.
+func (jqr *journalQueryRepository) GetMoodEntryDates(userID string) ([]time.Time, error) {
+	var dates []time.Time
+	result := jqr.db.Model(&model.MoodEntry{}).Where("user_id = ?", userID).Order("date ASC").Pluck("date", &dates)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return dates, nil
+}
+
+// GetMoodAverages groups the scores between two days, both inclusive, by
+// week or month.
+func (jqr *journalQueryRepository) GetMoodAverages(userID string, period string, from time.Time, to time.Time) ([]entity.MoodAverage, error) {
+	var rows []struct {
+		PeriodStart time.Time
+		Average     float64
+		Entries     int
+	}
+
+	result := jqr.db.Model(&model.MoodEntry{}).
+		Select("date_trunc(?, date)::date AS period_start, ROUND(AVG(score)::numeric, 2) AS average, COUNT(*) AS entries", period).
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, from.Format(dateLayout), to.Format(dateLayout)).
+		Group("period_start").
+		Order("period_start ASC").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	averages := []entity.MoodAverage{}
+	for _, row := range rows {
+		averages = append(averages, entity.MoodAverage{
+			PeriodStart: row.PeriodStart,
+			Average:     row.Average,
+			Entries:     row.Entries,
+		})
+	}
+
+	return averages, nil
+}
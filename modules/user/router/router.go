@@ -2,6 +2,7 @@ package router
 
 import (
 	"talkspace-api/middlewares"
+	cr "talkspace-api/modules/consultation/repository"
 	"talkspace-api/modules/user/handler"
 	"talkspace-api/modules/user/repository"
 	"talkspace-api/modules/user/usecase"
@@ -14,11 +15,17 @@ import (
 func UserRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
 	userQueryRepository := repository.NewUserQueryRepository(db, rdb)
 	userCommandRepository := repository.NewUserCommandRepository(db, rdb)
+	journalQueryRepository := repository.NewJournalQueryRepository(db)
+	journalCommandRepository := repository.NewJournalCommandRepository(db)
+	consultationQueryRepository := cr.NewConsultationQueryRepository(db)
 
 	userQueryUsecase := usecase.NewUserQueryUsecase(userCommandRepository, userQueryRepository)
 	userCommandUsecase := usecase.NewUserCommandUsecase(userCommandRepository, userQueryRepository)
+	journalQueryUsecase := usecase.NewJournalQueryUsecase(journalQueryRepository)
+	journalCommandUsecase := usecase.NewJournalCommandUsecase(journalCommandRepository, journalQueryRepository, consultationQueryRepository)
 
 	userHandler := handler.NewUserHandler(userCommandUsecase, userQueryUsecase)
+	journalHandler := handler.NewJournalHandler(journalCommandUsecase, journalQueryUsecase)
 
 	account := e.Group("/account")
 	account.POST("/register", userHandler.RegisterUser)
@@ -34,9 +41,14 @@ func UserRoutes(e *echo.Group, db *gorm.DB, rdb *redis.Client) {
 	profile.GET("/:user_id", userHandler.GetUserByID)
 	profile.PUT("/:user_id", userHandler.UpdateUserProfile)
 
-	premium := e.Group("/premium", middlewares.JWTMiddleware(false))
-	premium.POST("/request-premium/:request_premium", userHandler.RequestPremium)
-	premium.PATCH("/update-expired", userHandler.UpdateUserPremiumExpired)
-
-	e.GET("", userHandler.GetRequestPremiumUsers)
+	journal := e.Group("/journal", middlewares.JWTMiddleware(false))
+	journal.POST("", journalHandler.CreateMoodEntry)
+	journal.GET("", journalHandler.GetMoodEntries)
+	journal.GET("/streak", journalHandler.GetMoodStreak)
+	journal.GET("/averages", journalHandler.GetMoodAverages)
+	journal.GET("/shared/:user_id", journalHandler.GetSharedMoodEntries)
+	journal.GET("/:entry_id", journalHandler.GetMoodEntryByID)
+	journal.PUT("/:entry_id", journalHandler.UpdateMoodEntry)
+	journal.PATCH("/:entry_id/share", journalHandler.ShareMoodEntry)
+	journal.DELETE("/:entry_id", journalHandler.DeleteMoodEntry)
 }
@@ -13,11 +13,23 @@ type UserCommandUsecaseInterface interface {
 	NewUserPassword(email string, password entity.User) (entity.User, error)
 	SendUserOTP(email string) (entity.User, error)
 	VerifyUserOTP(email, otp string) (string, error)
-	RequestPremium(user entity.User, request_premium string) (entity.User, error)
-	UpdateUserPremiumExpired(id string, status string) (entity.User, error)
 }
 
 type UserQueryUsecaseInterface interface {
 	GetUserByID(id string) (entity.User, error)
-	GetRequestPremiumUsers() ([]entity.User, error)
+}
+
+type JournalCommandUsecaseInterface interface {
+	CreateMoodEntry(entry entity.MoodEntry, date string) (entity.MoodEntry, error)
+	UpdateMoodEntry(id string, userID string, entry entity.MoodEntry) (entity.MoodEntry, error)
+	ShareMoodEntry(id string, userID string, doctorID string) (entity.MoodEntry, error)
+	DeleteMoodEntry(id string, userID string) error
+}
+
+type JournalQueryUsecaseInterface interface {
+	GetMoodEntryByID(id string, requesterID string, role string) (entity.MoodEntry, error)
+	GetMoodEntries(userID string, from string, to string, page, limit int) ([]entity.MoodEntry, int, error)
+	GetSharedMoodEntries(userID string, doctorID string, page, limit int) ([]entity.MoodEntry, int, error)
+	GetMoodStreak(userID string) (entity.MoodStreak, error)
+	GetMoodAverages(userID string, period string, from string, to string) ([]entity.MoodAverage, error)
 }
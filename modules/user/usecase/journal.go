@@ -0,0 +1,313 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	ae "talkspace-api/modules/appointment/entity"
+	cr "talkspace-api/modules/consultation/repository"
+	"talkspace-api/modules/user/entity"
+	"talkspace-api/modules/user/repository"
+	"talkspace-api/utils/constant"
+	"time"
+)
+
+type journalCommandUsecase struct {
+	journalCommandRepository    repository.JournalCommandRepositoryInterface
+	journalQueryRepository      repository.JournalQueryRepositoryInterface
+	consultationQueryRepository cr.ConsultationQueryRepositoryInterface
+}
+
+func NewJournalCommandUsecase(jcr repository.JournalCommandRepositoryInterface, jqr repository.JournalQueryRepositoryInterface, cqr cr.ConsultationQueryRepositoryInterface) JournalCommandUsecaseInterface {
+	return &journalCommandUsecase{
+		journalCommandRepository:    jcr,
+		journalQueryRepository:      jqr,
+		consultationQueryRepository: cqr,
+	}
+}
+
+// CreateMoodEntry records the check-in of a day, today when date is empty.
+// New entries are always private.
+func (jcu *journalCommandUsecase) CreateMoodEntry(entry entity.MoodEntry, date string) (entity.MoodEntry, error) {
+	entry, errValidate := validateMoodEntry(entry)
+	if errValidate != nil {
+		return entity.MoodEntry{}, errValidate
+	}
+
+	today := journalDay(time.Now())
+	day, errParse := parseJournalDay(date, today)
+	if errParse != nil {
+		return entity.MoodEntry{}, errParse
+	}
+
+	if day.After(today) {
+		return entity.MoodEntry{}, errors.New(constant.ERROR_JOURNAL_FUTURE)
+	}
+
+	entry.Date = day
+	entry.SharedDoctorID = ""
+	entry.SharedAt = nil
+
+	moodEntryEntity, errCreate := jcu.journalCommandRepository.CreateMoodEntry(entry)
+	if errCreate != nil {
+		return entity.MoodEntry{}, errCreate
+	}
+
+	return moodEntryEntity, nil
+}
+
+func (jcu *journalCommandUsecase) UpdateMoodEntry(id string, userID string, entry entity.MoodEntry) (entity.MoodEntry, error) {
+	entry, errValidate := validateMoodEntry(entry)
+	if errValidate != nil {
+		return entity.MoodEntry{}, errValidate
+	}
+
+	errOwner := jcu.checkOwner(id, userID)
+	if errOwner != nil {
+		return entity.MoodEntry{}, errOwner
+	}
+
+	moodEntryEntity, errUpdate := jcu.journalCommandRepository.UpdateMoodEntry(id, entry)
+	if errUpdate != nil {
+		return entity.MoodEntry{}, errUpdate
+	}
+
+	return moodEntryEntity, nil
+}
+
+// ShareMoodEntry lets one doctor the user has consulted read the entry. An
+// empty doctorID makes it private again.
+func (jcu *journalCommandUsecase) ShareMoodEntry(id string, userID string, doctorID string) (entity.MoodEntry, error) {
+	errOwner := jcu.checkOwner(id, userID)
+	if errOwner != nil {
+		return entity.MoodEntry{}, errOwner
+	}
+
+	if doctorID != "" {
+		related, errCheck := jcu.consultationQueryRepository.IsPatientOf(userID, doctorID)
+		if errCheck != nil {
+			return entity.MoodEntry{}, errCheck
+		}
+
+		if !related {
+			return entity.MoodEntry{}, errors.New(constant.ERROR_DOCTOR_UNRELATED)
+		}
+	}
+
+	moodEntryEntity, errUpdate := jcu.journalCommandRepository.UpdateMoodEntrySharing(id, doctorID, time.Now())
+	if errUpdate != nil {
+		return entity.MoodEntry{}, errUpdate
+	}
+
+	return moodEntryEntity, nil
+}
+
+func (jcu *journalCommandUsecase) DeleteMoodEntry(id string, userID string) error {
+	errOwner := jcu.checkOwner(id, userID)
+	if errOwner != nil {
+		return errOwner
+	}
+
+	return jcu.journalCommandRepository.DeleteMoodEntry(id)
+}
+
+func (jcu *journalCommandUsecase) checkOwner(id string, userID string) error {
+	moodEntry, errGetID := jcu.journalQueryRepository.GetMoodEntryByID(id)
+	if errGetID != nil {
+		return errGetID
+	}
+
+	if moodEntry.UserID != userID {
+		return errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+
+	return nil
+}
+
+type journalQueryUsecase struct {
+	journalQueryRepository repository.JournalQueryRepositoryInterface
+}
+
+func NewJournalQueryUsecase(jqr repository.JournalQueryRepositoryInterface) JournalQueryUsecaseInterface {
+	return &journalQueryUsecase{
+		journalQueryRepository: jqr,
+	}
+}
+
+// GetMoodEntryByID returns an entry to its author, or to the doctor it was
+// shared with.
+func (jqu *journalQueryUsecase) GetMoodEntryByID(id string, requesterID string, role string) (entity.MoodEntry, error) {
+	moodEntry, errGetID := jqu.journalQueryRepository.GetMoodEntryByID(id)
+	if errGetID != nil {
+		return entity.MoodEntry{}, errGetID
+	}
+
+	switch {
+	case role == constant.USER && moodEntry.UserID == requesterID:
+		return moodEntry, nil
+	case role == constant.DOCTOR && moodEntry.SharedDoctorID == requesterID:
+		return moodEntry, nil
+	default:
+		return entity.MoodEntry{}, errors.New(constant.ERROR_ROLE_ACCESS)
+	}
+}
+
+// GetMoodEntries returns the entries between two "2006-01-02" dates, both
+// inclusive. Both are optional.
+func (jqu *journalQueryUsecase) GetMoodEntries(userID string, from string, to string, page, limit int) ([]entity.MoodEntry, int, error) {
+	end, errParse := parseJournalDay(to, journalDay(time.Now()))
+	if errParse != nil {
+		return nil, 0, errParse
+	}
+
+	start, errParse := parseJournalDay(from, time.Time{})
+	if errParse != nil {
+		return nil, 0, errParse
+	}
+
+	moodEntries, totalItems, errGet := jqu.journalQueryRepository.GetMoodEntriesByUserID(userID, start, end, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return moodEntries, totalItems, nil
+}
+
+func (jqu *journalQueryUsecase) GetSharedMoodEntries(userID string, doctorID string, page, limit int) ([]entity.MoodEntry, int, error) {
+	moodEntries, totalItems, errGet := jqu.journalQueryRepository.GetSharedMoodEntries(userID, doctorID, page, limit)
+	if errGet != nil {
+		return nil, 0, errGet
+	}
+
+	return moodEntries, totalItems, nil
+}
+
+func (jqu *journalQueryUsecase) GetMoodStreak(userID string) (entity.MoodStreak, error) {
+	dates, errGet := jqu.journalQueryRepository.GetMoodEntryDates(userID)
+	if errGet != nil {
+		return entity.MoodStreak{}, errGet
+	}
+
+	return moodStreak(dates, journalDay(time.Now())), nil
+}
+
+// GetMoodAverages averages the scores per week or month. Without dates it
+// covers the last 12 periods up to today.
+func (jqu *journalQueryUsecase) GetMoodAverages(userID string, period string, from string, to string) ([]entity.MoodAverage, error) {
+	if period == "" {
+		period = entity.MoodPeriodWeek
+	}
+
+	end, errParse := parseJournalDay(to, journalDay(time.Now()))
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	var fallback time.Time
+	switch period {
+	case entity.MoodPeriodWeek:
+		fallback = end.AddDate(0, 0, -7*12)
+	case entity.MoodPeriodMonth:
+		fallback = end.AddDate(0, -12, 0)
+	default:
+		return nil, errors.New(constant.ERROR_PERIOD_INVALID)
+	}
+
+	start, errParse := parseJournalDay(from, fallback)
+	if errParse != nil {
+		return nil, errParse
+	}
+
+	if start.After(end) {
+		return nil, errors.New(constant.ERROR_TIME_RANGE)
+	}
+
+	averages, errGet := jqu.journalQueryRepository.GetMoodAverages(userID, period, start, end)
+	if errGet != nil {
+		return nil, errGet
+	}
+
+	return averages, nil
+}
+
+func validateMoodEntry(entry entity.MoodEntry) (entity.MoodEntry, error) {
+	if entry.Score < entity.MoodScoreMin || entry.Score > entity.MoodScoreMax {
+		return entry, errors.New(constant.ERROR_MOOD_SCORE)
+	}
+
+	entry.Content = strings.TrimSpace(entry.Content)
+	if len(entry.Content) > entity.JournalContentMaxLength {
+		return entry, errors.New(constant.ERROR_JOURNAL_TOO_LONG)
+	}
+
+	tags := []string{}
+	seen := map[string]bool{}
+	for _, tag := range entry.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !entity.JournalTags[tag] {
+			return entry, errors.New(constant.ERROR_JOURNAL_TAG)
+		}
+
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	entry.Tags = tags
+
+	return entry, nil
+}
+
+// journalDay returns the calendar day of moment in the time zone of the
+// app, at midnight UTC like the days stored in the journal.
+func journalDay(moment time.Time) time.Time {
+	local := moment.In(ae.Location())
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// parseJournalDay reads a "2006-01-02" date, returning fallback for an empty
+// value.
+func parseJournalDay(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	day, errParse := ae.ParseDate(value)
+	if errParse != nil {
+		return time.Time{}, errParse
+	}
+
+	return journalDay(day), nil
+}
+
+// moodStreak walks the check-in days, oldest first, and measures the runs
+// of consecutive days.
+func moodStreak(dates []time.Time, today time.Time) entity.MoodStreak {
+	streak := entity.MoodStreak{}
+
+	run := 0
+	var previous time.Time
+	for i, date := range dates {
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		if i > 0 && day.Equal(previous.AddDate(0, 0, 1)) {
+			run++
+		} else {
+			run = 1
+		}
+
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+		previous = day
+	}
+
+	if len(dates) > 0 {
+		lastDate := previous
+		streak.LastDate = &lastDate
+
+		if !lastDate.Before(today.AddDate(0, 0, -1)) {
+			streak.Current = run
+		}
+	}
+
+	return streak
+}
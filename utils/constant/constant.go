@@ -5,6 +5,99 @@ const (
 	USER    = "user"
 	DOCTOR  = "doctor"
 	ADMIN   = "admin"
+	SYSTEM  = "system"
+)
+
+// Transaction Status
+const (
+	TRANSACTION_PENDING   = "pending"
+	TRANSACTION_PAID      = "paid"
+	TRANSACTION_EXPIRED   = "expired"
+	TRANSACTION_CANCELLED = "cancelled"
+	TRANSACTION_REFUNDED  = "refunded"
+)
+
+// Transaction Type
+const (
+	TRANSACTION_CONSULTATION = "consultation"
+	TRANSACTION_SUBSCRIPTION = "subscription"
+)
+
+// Subscription Status
+const (
+	SUBSCRIPTION_PENDING   = "pending"
+	SUBSCRIPTION_ACTIVE    = "active"
+	SUBSCRIPTION_GRACE     = "grace"
+	SUBSCRIPTION_CANCELLED = "cancelled"
+	SUBSCRIPTION_EXPIRED   = "expired"
+)
+
+// Appointment Status
+const (
+	APPOINTMENT_PENDING   = "pending"
+	APPOINTMENT_CONFIRMED = "confirmed"
+	APPOINTMENT_CANCELLED = "cancelled"
+)
+
+// Appointment
+const (
+	APPOINTMENT_TIMEZONE = "Asia/Jakarta"
+)
+
+// Consultation Status
+const (
+	CONSULTATION_BOOKED    = "booked"
+	CONSULTATION_WAITING   = "waiting"
+	CONSULTATION_ACTIVE    = "active"
+	CONSULTATION_COMPLETED = "completed"
+	CONSULTATION_CANCELLED = "cancelled"
+	CONSULTATION_NO_SHOW   = "no_show"
+)
+
+// Consultation Event
+const (
+	EVENT_MESSAGE    = "message"
+	EVENT_ATTACHMENT = "attachment"
+	EVENT_TYPING     = "typing"
+	EVENT_READ       = "read"
+	EVENT_DELIVERED  = "delivered"
+	EVENT_PRESENCE   = "presence"
+	EVENT_SYSTEM     = "system"
+	EVENT_ERROR      = "error"
+	EVENT_CRISIS     = "crisis"
+)
+
+// Stream Event
+const (
+	STREAM_START = "start"
+	STREAM_DELTA = "delta"
+	STREAM_DONE  = "done"
+	STREAM_ERROR = "error"
+)
+
+// Escalation Status
+const (
+	ESCALATION_OPEN         = "open"
+	ESCALATION_ACKNOWLEDGED = "acknowledged"
+	ESCALATION_RESOLVED     = "resolved"
+)
+
+// Escalation Source
+const (
+	ESCALATION_CONSULTATION = "consultation"
+	ESCALATION_TALKBOT      = "talkbot"
+)
+
+// Talkbot Role
+const (
+	TALKBOT_USER      = "user"
+	TALKBOT_ASSISTANT = "assistant"
+)
+
+// Doctor Sort
+const (
+	SORT_RATING  = "rating"
+	SORT_REVIEWS = "reviews"
 )
 
 // Success
@@ -22,8 +115,16 @@ const (
 	SUCCESS_OTP_VERIFIED      = "otp verification successfully"
 	SUCCESS_VERIFICATION      = "verification successfully"
 	SUCCESS_STATUS_UPDATED    = "status updated successfully"
-	SUCCESS_REQUEST_PREMIUM   = "request premium successfully"
-	SUCCESS_PREMIUM_EXPIRED   = "premium expired successfully"
+	SUCCESS_TRANSACTION       = "transaction created successfully"
+	SUCCESS_PAYMENT_REFUNDED  = "payment refunded successfully"
+	SUCCESS_NOTIFICATION      = "notification processed successfully"
+	SUCCESS_SUBSCRIPTION      = "subscription created successfully"
+	SUCCESS_RENEWED           = "renewal created successfully"
+	SUCCESS_CANCELLED         = "cancelled successfully"
+	SUCCESS_APPOINTMENT       = "appointment booked successfully"
+	SUCCESS_RESCHEDULED       = "appointment rescheduled successfully"
+	SUCCESS_SESSION_ENDED     = "session ended successfully"
+	SUCCESS_REVIEWED          = "review submitted successfully"
 )
 
 // Error
@@ -68,5 +169,74 @@ const (
 	ERROR_STATUS_INVALID       = "invalid status"
 	ERROR_UPLOAD_IMAGE         = "failed to upload profile picture"
 	ERROR_UPLOAD_IMAGE_S3 	   = "failed to upload profile picture to s3"
-	ERROR_REQUEST_PREMIUM      = "failed to request premium"
+	ERROR_DOCTOR_INACTIVE      = "doctor is not available"
+	ERROR_TRANSACTION_CODE     = "failed to generate transaction code"
+	ERROR_PAYMENT_CHARGE       = "failed to create payment"
+	ERROR_PAYMENT_STATUS       = "failed to retrieve payment status"
+	ERROR_PAYMENT_REFUND       = "failed to refund payment"
+	ERROR_REFUND_AMOUNT        = "refund amount must be the full amount paid"
+	ERROR_PAYMENT_UNPAID       = "transaction has not been paid"
+	ERROR_PAYMENT_SIMULATE     = "payment simulation is only available with the fake gateway"
+	ERROR_PAYMENT_SIGNATURE    = "invalid payment notification signature"
+	ERROR_PAYMENT_AMOUNT       = "payment amount doesn't match the transaction"
+	ERROR_TRANSACTION_STATUS   = "invalid transaction status transition"
+	ERROR_PLAN_INACTIVE        = "subscription plan is not available"
+	ERROR_PLAN_PRICE           = "subscription plan price must be greater than zero"
+	ERROR_PLAN_DURATION        = "subscription plan duration must be at least one month"
+	ERROR_SUBSCRIPTION_EXIST   = "user already has an active subscription"
+	ERROR_SUBSCRIPTION_STATUS  = "subscription cannot be changed in its current status"
+	ERROR_TIME_FORMAT          = "invalid time format. expected format: '09:00'"
+	ERROR_DATETIME_FORMAT      = "invalid datetime format. expected format: '2000-12-30T09:00:00+07:00'"
+	ERROR_WEEKDAY_INVALID      = "weekday must be between 0 (sunday) and 6 (saturday)"
+	ERROR_TIME_RANGE           = "end time must be after start time"
+	ERROR_AVAILABILITY_OVERLAP = "availability windows must not overlap"
+	ERROR_SLOT_DURATION        = "slot duration must be between 15 and 240 minutes"
+	ERROR_SLOT_UNAVAILABLE     = "selected slot is not available"
+	ERROR_SLOT_BOOKED          = "selected slot is already booked"
+	ERROR_APPOINTMENT_STATUS   = "appointment cannot be changed in its current status"
+	ERROR_APPOINTMENT_CUTOFF   = "appointment can no longer be changed this close to its start"
+	ERROR_ROOM_CLOSED          = "consultation room is not open at this time"
+	ERROR_MESSAGE_EMPTY        = "message content is empty"
+	ERROR_MESSAGE_TOO_LONG     = "message content is too long"
+	ERROR_ATTACHMENT_TYPE      = "invalid attachment format. supported formats: .jpg, .jpeg, .png, .pdf"
+	ERROR_ATTACHMENT_SIZE      = "attachment exceeds the maximum allowed size of 10MB"
+	ERROR_EVENT_INVALID        = "invalid event, expected a json object with a known type"
+	ERROR_CURSOR_INVALID       = "invalid cursor, use either before or after with a message of this room"
+	ERROR_TICKET_INVALID       = "invalid or expired join ticket"
+	ERROR_HUB_CLOSED           = "consultation service is shutting down"
+	ERROR_CONSULTATION_STATUS  = "invalid consultation status transition"
+	ERROR_SORT_INVALID         = "invalid sort. allowed sort: rating, reviews"
+	ERROR_RATING_RANGE         = "rating must be between 1 and 5"
+	ERROR_REVIEW_TOO_LONG      = "review is too long"
+	ERROR_REVIEW_EXIST         = "consultation has already been reviewed"
+	ERROR_REVIEW_STATUS        = "only completed consultations can be reviewed"
+	ERROR_NOTE_EXIST           = "consultation already has a session note"
+	ERROR_NOTE_STATUS          = "session notes can only be written once the consultation has started"
+	ERROR_NOTE_EMPTY           = "session note needs at least one section"
+	ERROR_NOTE_TOO_LONG        = "session note section is too long"
+	ERROR_NOTE_VERSION         = "session note was changed by another edit, reload it and try again"
+	ERROR_INSTRUMENT_NOTFOUND  = "assessment instrument not found"
+	ERROR_ANSWERS_INVALID      = "answers must contain one listed option for every question"
+	ERROR_MOOD_SCORE           = "mood score must be between 1 and 5"
+	ERROR_JOURNAL_TAG          = "invalid tag. allowed tags: sleep, anxiety, energy"
+	ERROR_JOURNAL_TOO_LONG     = "journal entry is too long"
+	ERROR_JOURNAL_EXIST        = "a check-in already exists for this date"
+	ERROR_JOURNAL_FUTURE       = "check-ins cannot be dated in the future"
+	ERROR_PERIOD_INVALID       = "invalid period. allowed period: week, month"
+	ERROR_DOCTOR_UNRELATED     = "entries can only be shared with a doctor you have consulted"
+	ERROR_ESCALATION_STATUS    = "escalation cannot move back to an earlier status"
+	ERROR_ESCALATION_TOO_LONG  = "escalation note is too long"
+	ERROR_INTAKE_REQUIRED      = "answer the intake form before booking, answers older than 30 days have to be renewed"
+	ERROR_INTAKE_OUTDATED      = "the intake form has changed, reload it and answer again"
+	ERROR_INTAKE_ANSWERS       = "answers must fill every required question with a valid value"
+	ERROR_INTAKE_TOO_LONG      = "intake answer is too long"
+	ERROR_INTAKE_CONSENT       = "consent is required to share the intake with your doctor"
+	ERROR_INTAKE_FORM          = "invalid intake form. every field needs a unique key, label, known section and type, and one required consent question"
+	ERROR_TALKBOT_TIMEOUT      = "talkbot took too long to answer, try again"
+	ERROR_TALKBOT_BUSY         = "talkbot is busy right now, try again in a moment"
+	ERROR_TALKBOT_UNAVAILABLE  = "talkbot is unavailable right now, try again later"
+	ERROR_TALKBOT_REJECTED     = "talkbot could not answer this message"
+	ERROR_CONVERSATION_TITLE   = "conversation title must be between 1 and 50 characters"
+	ERROR_TALKBOT_QUOTA        = "talkbot quota is used up, try again once it resets"
+	ERROR_QUOTA_LIMITS         = "quota limits must be zero or more"
 )
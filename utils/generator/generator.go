@@ -9,6 +9,7 @@ import (
 	"html/template"
 	"math/big"
 	"path/filepath"
+	"time"
 )
 
 func GenerateRandomCode() (string, error) {
@@ -66,3 +67,20 @@ func GenerateEmailTemplate(fileTemplate string, data interface{}) (string, error
 
 	return templateBuffer.String(), nil
 }
+
+func GenerateTransactionCode() (string, error) {
+	const charset = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	codeLength := 6
+
+	randomBytes := make([]byte, codeLength)
+
+	for i := range randomBytes {
+		randIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		randomBytes[i] = charset[randIndex.Int64()]
+	}
+
+	return "TS-" + time.Now().Format("20060102150405") + "-" + string(randomBytes), nil
+}
@@ -0,0 +1,139 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"talkspace-api/app/configs"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	PROVIDER_OPENAI = "openai"
+	PROVIDER_FAKE   = "fake"
+
+	// SERVER_DEVELOPMENT is the only SERVER_ENVIRONMENT the fake provider is
+	// allowed to run in
+	SERVER_DEVELOPMENT = "development"
+)
+
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+const (
+	defaultModel      = "gpt-3.5-turbo"
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 2
+)
+
+// Errors a provider returns, wrapping what went wrong upstream. Callers map
+// them to their own messages instead of showing provider errors to users.
+var (
+	// ErrTimeout is returned when the provider did not answer in time.
+	ErrTimeout = errors.New("chat: provider timed out")
+	// ErrRateLimited is returned when the provider keeps refusing requests
+	// for going over its rate limit.
+	ErrRateLimited = errors.New("chat: provider rate limited")
+	// ErrUnavailable is returned when the provider cannot be reached, fails
+	// on its side or rejects the credentials.
+	ErrUnavailable = errors.New("chat: provider unavailable")
+	// ErrRejected is returned when the provider refuses the request itself,
+	// such as a prompt over the context length or a content filter.
+	ErrRejected = errors.New("chat: request rejected")
+)
+
+// ChatProvider completes a conversation with a chat model. Stream passes
+// the reply to onDelta piece by piece as it arrives and returns the whole
+// reply once it is done, an error from onDelta stops the stream. A stream
+// that breaks off after part of the reply was passed to onDelta returns
+// that part with the error.
+type ChatProvider interface {
+	Complete(ctx context.Context, request Request) (Response, error)
+	Stream(ctx context.Context, request Request, onDelta func(delta string) error) (Response, error)
+}
+
+type (
+	Message struct {
+		Role    string
+		Content string
+	}
+
+	// Request is a conversation to complete. MaxTokens caps the reply, zero
+	// leaves it to the provider.
+	Request struct {
+		Messages  []Message
+		MaxTokens int
+	}
+
+	Response struct {
+		Content          string
+		PromptTokens     int
+		CompletionTokens int
+	}
+)
+
+var (
+	provider ChatProvider
+	once     sync.Once
+)
+
+// NewChatProvider returns the process-wide provider selected by
+// OPENAI_PROVIDER. The instance is shared so that the fake provider keeps a
+// single script across modules. The fake only runs in development, and a
+// provider name it does not know stops the server.
+func NewChatProvider() ChatProvider {
+	once.Do(func() {
+		config, err := configs.LoadConfig()
+		if err != nil {
+			logrus.Fatalf("failed to load OpenAI configuration: %v", err)
+		}
+
+		model := config.OPENAI.OPENAI_MODEL
+		if model == "" {
+			model = defaultModel
+		}
+
+		timeout := defaultTimeout
+		if seconds, err := strconv.Atoi(config.OPENAI.OPENAI_TIMEOUT); err == nil && seconds > 0 {
+			timeout = time.Duration(seconds) * time.Second
+		}
+
+		maxRetries := defaultMaxRetries
+		if retries, err := strconv.Atoi(config.OPENAI.OPENAI_MAX_RETRIES); err == nil && retries >= 0 {
+			maxRetries = retries
+		}
+
+		name := config.OPENAI.OPENAI_PROVIDER
+		if name == "" {
+			name = PROVIDER_OPENAI
+		}
+
+		switch name {
+		case PROVIDER_FAKE:
+			if config.SERVER.SERVER_ENVIRONMENT != SERVER_DEVELOPMENT {
+				logrus.Fatalf("failed to initialize chat provider: the fake provider only runs when SERVER_ENVIRONMENT is %s", SERVER_DEVELOPMENT)
+			}
+			provider = NewFakeProvider()
+		case PROVIDER_OPENAI:
+			provider = NewOpenAIProvider(OpenAIOptions{
+				APIKey:     config.OPENAI.OPENAI_API_KEY,
+				BaseURL:    config.OPENAI.OPENAI_BASE_URL,
+				Model:      model,
+				Timeout:    timeout,
+				MaxRetries: maxRetries,
+			})
+		default:
+			logrus.Fatalf("failed to initialize chat provider: unknown OPENAI_PROVIDER %q", name)
+		}
+
+		logrus.Infof("chat provider initialized in %s mode", name)
+	})
+
+	return provider
+}
@@ -0,0 +1,114 @@
+package chat
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FakeReply is one scripted answer of the fake provider, an error when Err
+// is set.
+type FakeReply struct {
+	Content string
+	Err     error
+}
+
+// FakeProvider answers from a script so TalkBot can run in development and
+// tests without a model. Scripted replies are used in order, after that every
+// request is answered with DefaultFakeReply. Requests are recorded so a test
+// can check the prompt that was built.
+type FakeProvider struct {
+	mu       sync.Mutex
+	script   []FakeReply
+	requests []Request
+}
+
+const DefaultFakeReply = "Terima kasih sudah bercerita. Aku di sini untuk mendengarkan, boleh ceritakan lebih banyak?"
+
+func NewFakeProvider(script ...FakeReply) *FakeProvider {
+	return &FakeProvider{
+		script: script,
+	}
+}
+
+func (fp *FakeProvider) Complete(ctx context.Context, request Request) (Response, error) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.requests = append(fp.requests, request)
+
+	if err := ctx.Err(); err != nil {
+		return Response{}, err
+	}
+
+	reply := FakeReply{Content: DefaultFakeReply}
+	if len(fp.script) > 0 {
+		reply = fp.script[0]
+		fp.script = fp.script[1:]
+	}
+
+	if reply.Err != nil {
+		return Response{}, reply.Err
+	}
+
+	promptTokens := 0
+	for _, message := range request.Messages {
+		promptTokens += fakeTokens(message.Content)
+	}
+
+	return Response{
+		Content:          reply.Content,
+		PromptTokens:     promptTokens,
+		CompletionTokens: fakeTokens(reply.Content),
+	}, nil
+}
+
+// Stream answers like Complete and relays the reply one word at a time. A
+// stream that is stopped returns the words relayed so far.
+func (fp *FakeProvider) Stream(ctx context.Context, request Request, onDelta func(delta string) error) (Response, error) {
+	response, err := fp.Complete(ctx, request)
+	if err != nil {
+		return Response{}, err
+	}
+
+	relayed := strings.Builder{}
+	for _, delta := range strings.SplitAfter(response.Content, " ") {
+		err := ctx.Err()
+		if err == nil {
+			relayed.WriteString(delta)
+			err = onDelta(delta)
+		}
+
+		if err != nil {
+			response.Content = relayed.String()
+			response.CompletionTokens = fakeTokens(response.Content)
+			return response, err
+		}
+	}
+
+	return response, nil
+}
+
+// Script queues more replies after those not used yet.
+func (fp *FakeProvider) Script(replies ...FakeReply) {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	fp.script = append(fp.script, replies...)
+}
+
+// Requests returns the requests the provider received, oldest first.
+func (fp *FakeProvider) Requests() []Request {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+
+	requests := make([]Request, len(fp.requests))
+	copy(requests, fp.requests)
+
+	return requests
+}
+
+func fakeTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
@@ -0,0 +1,57 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeProviderScript(t *testing.T) {
+	fp := NewFakeProvider(FakeReply{Content: "Halo"}, FakeReply{Err: ErrRateLimited})
+	request := Request{Messages: []Message{{Role: RoleUser, Content: "Apa kabar?"}}}
+
+	response, err := fp.Complete(context.Background(), request)
+	if err != nil || response.Content != "Halo" {
+		t.Fatalf("first Complete() = %q, %v, want %q", response.Content, err, "Halo")
+	}
+	if response.PromptTokens != fakeTokens("Apa kabar?") || response.CompletionTokens != fakeTokens("Halo") {
+		t.Errorf("first Complete() usage = %d + %d", response.PromptTokens, response.CompletionTokens)
+	}
+
+	_, err = fp.Complete(context.Background(), request)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("second Complete() error = %v, want %v", err, ErrRateLimited)
+	}
+
+	response, err = fp.Complete(context.Background(), request)
+	if err != nil || response.Content != DefaultFakeReply {
+		t.Fatalf("Complete() after the script = %q, %v, want the default reply", response.Content, err)
+	}
+
+	if got := len(fp.Requests()); got != 3 {
+		t.Errorf("Requests() holds %d requests, want 3", got)
+	}
+}
+
+func TestFakeProviderStreamStopped(t *testing.T) {
+	fp := NewFakeProvider(FakeReply{Content: "Aku di sini untukmu"})
+	errStop := errors.New("client left")
+
+	deltas := 0
+	response, err := fp.Stream(context.Background(), Request{}, func(delta string) error {
+		deltas++
+		if deltas == 2 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) {
+		t.Fatalf("Stream() error = %v, want %v", err, errStop)
+	}
+	if response.Content != "Aku di " {
+		t.Errorf("Stream() returned %q, want the words relayed before it stopped", response.Content)
+	}
+	if response.CompletionTokens != fakeTokens("Aku di ") {
+		t.Errorf("Stream() completion tokens = %d, want %d", response.CompletionTokens, fakeTokens("Aku di "))
+	}
+}
@@ -0,0 +1,285 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+	"github.com/sirupsen/logrus"
+)
+
+// backoffBase is the wait before the first retry, doubled for every retry
+// after it.
+const backoffBase = 500 * time.Millisecond
+
+// OpenAIOptions configures a provider for the OpenAI API or any server that
+// speaks it, such as a model served locally. An empty BaseURL uses OpenAI.
+type OpenAIOptions struct {
+	APIKey     string
+	BaseURL    string
+	Model      string
+	Timeout    time.Duration
+	MaxRetries int
+}
+
+type openAIProvider struct {
+	client     *openai.Client
+	model      string
+	timeout    time.Duration
+	maxRetries int
+}
+
+func NewOpenAIProvider(options OpenAIOptions) ChatProvider {
+	config := openai.DefaultConfig(options.APIKey)
+	if options.BaseURL != "" {
+		config.BaseURL = options.BaseURL
+	}
+
+	return &openAIProvider{
+		client:     openai.NewClientWithConfig(config),
+		model:      options.Model,
+		timeout:    options.Timeout,
+		maxRetries: options.MaxRetries,
+	}
+}
+
+// Complete asks the model for a reply. Every attempt gets its own timeout,
+// and timeouts, rate limits and server errors are retried with exponential
+// backoff until MaxRetries is used up or ctx is done.
+func (op *openAIProvider) Complete(ctx context.Context, request Request) (Response, error) {
+	completionRequest := op.completionRequest(request)
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		var response Response
+		var retry bool
+		response, retry, err = op.complete(ctx, completionRequest)
+		if err == nil {
+			return response, nil
+		}
+
+		if !retry || attempt >= op.maxRetries {
+			break
+		}
+
+		logrus.Warnf("chat: attempt %d failed, retrying: %v", attempt+1, err)
+
+		if !sleep(ctx, backoff(attempt)) {
+			break
+		}
+	}
+
+	return Response{}, err
+}
+
+func (op *openAIProvider) complete(ctx context.Context, request openai.ChatCompletionRequest) (Response, bool, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, op.timeout)
+	defer cancel()
+
+	completion, err := op.client.CreateChatCompletion(attemptCtx, request)
+	if err != nil {
+		retry, err := classify(ctx, err)
+		return Response{}, retry, err
+	}
+
+	if len(completion.Choices) == 0 {
+		return Response{}, true, fmt.Errorf("%w: empty completion", ErrUnavailable)
+	}
+
+	return Response{
+		Content:          completion.Choices[0].Message.Content,
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+	}, false, nil
+}
+
+// Stream asks the model for a reply and relays it as it arrives. The timeout
+// applies to the wait for every next piece rather than the whole reply.
+// Failures are retried like Complete until the first piece was relayed,
+// after that the reply cannot be taken back and the error is returned with
+// the part of the reply that was relayed.
+func (op *openAIProvider) Stream(ctx context.Context, request Request, onDelta func(delta string) error) (Response, error) {
+	completionRequest := op.completionRequest(request)
+	completionRequest.Stream = true
+	completionRequest.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	started := false
+	relay := func(delta string) error {
+		started = true
+		return onDelta(delta)
+	}
+
+	var response Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retry bool
+		response, retry, err = op.stream(ctx, completionRequest, relay)
+		if err == nil {
+			return response, nil
+		}
+
+		if started {
+			return response, err
+		}
+
+		if !retry || attempt >= op.maxRetries {
+			break
+		}
+
+		logrus.Warnf("chat: attempt %d failed, retrying: %v", attempt+1, err)
+
+		if !sleep(ctx, backoff(attempt)) {
+			break
+		}
+	}
+
+	return Response{}, err
+}
+
+func (op *openAIProvider) stream(ctx context.Context, request openai.ChatCompletionRequest, onDelta func(delta string) error) (Response, bool, error) {
+	attemptCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	idle := time.AfterFunc(op.timeout, func() {
+		cancel(ErrTimeout)
+	})
+	defer idle.Stop()
+
+	stream, err := op.client.CreateChatCompletionStream(attemptCtx, request)
+	if err != nil {
+		retry, err := op.streamError(ctx, attemptCtx, err)
+		return Response{}, retry, err
+	}
+	defer stream.Close()
+
+	content := strings.Builder{}
+	response := Response{}
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			retry, err := op.streamError(ctx, attemptCtx, err)
+			response.Content = content.String()
+			return response, retry, err
+		}
+
+		idle.Reset(op.timeout)
+
+		if chunk.Usage != nil {
+			response.PromptTokens = chunk.Usage.PromptTokens
+			response.CompletionTokens = chunk.Usage.CompletionTokens
+		}
+
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+
+		delta := chunk.Choices[0].Delta.Content
+		content.WriteString(delta)
+
+		if err := onDelta(delta); err != nil {
+			response.Content = content.String()
+			return response, false, err
+		}
+	}
+
+	if content.Len() == 0 {
+		return Response{}, true, fmt.Errorf("%w: empty completion", ErrUnavailable)
+	}
+
+	response.Content = content.String()
+
+	return response, false, nil
+}
+
+// streamError is classify for a stream, which times out by being cancelled
+// when no piece arrived in time.
+func (op *openAIProvider) streamError(ctx context.Context, attemptCtx context.Context, err error) (bool, error) {
+	if ctx.Err() == nil && errors.Is(context.Cause(attemptCtx), ErrTimeout) {
+		return true, fmt.Errorf("%w: nothing received for %s", ErrTimeout, op.timeout)
+	}
+
+	return classify(ctx, err)
+}
+
+func (op *openAIProvider) completionRequest(request Request) openai.ChatCompletionRequest {
+	messages := []openai.ChatCompletionMessage{}
+	for _, message := range request.Messages {
+		messages = append(messages, openai.ChatCompletionMessage{
+			Role:    message.Role,
+			Content: message.Content,
+		})
+	}
+
+	return openai.ChatCompletionRequest{
+		Model:     op.model,
+		Messages:  messages,
+		MaxTokens: request.MaxTokens,
+	}
+}
+
+// classify wraps an error of the client in the matching provider error and
+// tells whether asking again may help. ctx is the context of the caller,
+// whose own cancellation is passed on as is.
+func classify(ctx context.Context, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true, fmt.Errorf("%w: %v", ErrTimeout, err)
+	}
+
+	status := 0
+	apiErr := &openai.APIError{}
+	requestErr := &openai.RequestError{}
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.HTTPStatusCode
+	case errors.As(err, &requestErr):
+		status = requestErr.HTTPStatusCode
+	}
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		return true, fmt.Errorf("%w: %v", ErrRateLimited, err)
+	case status == http.StatusRequestTimeout:
+		return true, fmt.Errorf("%w: %v", ErrTimeout, err)
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		// bad credentials will not get better by asking again
+		return false, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	case status >= http.StatusBadRequest && status < http.StatusInternalServerError:
+		return false, fmt.Errorf("%w: %v", ErrRejected, err)
+	default:
+		// network and server errors
+		return true, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+}
+
+// backoff is the wait before retry attempt+1, with up to half of it added
+// as jitter so clients do not retry in lockstep.
+func backoff(attempt int) time.Duration {
+	wait := backoffBase << attempt
+	return wait + time.Duration(rand.Int63n(int64(wait)/2+1))
+}
+
+// sleep waits for d, or returns false when ctx is done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,173 @@
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantRetry bool
+		wantErr   error
+	}{
+		{"deadline", context.DeadlineExceeded, true, ErrTimeout},
+		{"rate limited", &openai.APIError{HTTPStatusCode: http.StatusTooManyRequests}, true, ErrRateLimited},
+		{"request timeout", &openai.APIError{HTTPStatusCode: http.StatusRequestTimeout}, true, ErrTimeout},
+		{"unauthorized", &openai.APIError{HTTPStatusCode: http.StatusUnauthorized}, false, ErrUnavailable},
+		{"forbidden", &openai.APIError{HTTPStatusCode: http.StatusForbidden}, false, ErrUnavailable},
+		{"bad request", &openai.APIError{HTTPStatusCode: http.StatusBadRequest}, false, ErrRejected},
+		{"server error", &openai.APIError{HTTPStatusCode: http.StatusInternalServerError}, true, ErrUnavailable},
+		{"bad gateway", &openai.RequestError{HTTPStatusCode: http.StatusBadGateway, Err: io.ErrUnexpectedEOF}, true, ErrUnavailable},
+		{"network", io.ErrUnexpectedEOF, true, ErrUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry, err := classify(context.Background(), tt.err)
+			if retry != tt.wantRetry {
+				t.Errorf("classify(%v) retry = %v, want %v", tt.err, retry, tt.wantRetry)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("classify(%v) = %v, want %v", tt.err, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClassifyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	retry, err := classify(ctx, &openai.APIError{HTTPStatusCode: http.StatusInternalServerError})
+	if retry || !errors.Is(err, context.Canceled) {
+		t.Errorf("classify after cancel = %v, %v, want false, %v", retry, err, context.Canceled)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 0; attempt < 4; attempt++ {
+		wait := backoffBase << attempt
+		for i := 0; i < 100; i++ {
+			got := backoff(attempt)
+			if got < wait || got > wait+wait/2 {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", attempt, got, wait, wait+wait/2)
+			}
+		}
+	}
+}
+
+func TestOpenAIProviderRetries(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		wantErr    error
+		wantCalls  int32
+	}{
+		{"server error then reply", []int{http.StatusInternalServerError, http.StatusOK}, 1, nil, 2},
+		{"rate limited until retries run out", []int{http.StatusTooManyRequests, http.StatusTooManyRequests}, 1, ErrRateLimited, 2},
+		{"rejected request is not retried", []int{http.StatusBadRequest}, 2, ErrRejected, 1},
+		{"bad credentials are not retried", []int{http.StatusUnauthorized}, 2, ErrUnavailable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.statuses[calls.Add(1)-1]
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				if status != http.StatusOK {
+					fmt.Fprint(w, `{"error":{"message":"failed","type":"error"}}`)
+					return
+				}
+				fmt.Fprint(w, `{"choices":[{"index":0,"message":{"role":"assistant","content":"Halo"}}],"usage":{"prompt_tokens":5,"completion_tokens":1}}`)
+			}))
+			defer server.Close()
+
+			provider := NewOpenAIProvider(OpenAIOptions{
+				BaseURL:    server.URL,
+				Timeout:    time.Second,
+				MaxRetries: tt.maxRetries,
+			})
+
+			response, err := provider.Complete(context.Background(), Request{Messages: []Message{{Role: RoleUser, Content: "Halo"}}})
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Complete() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Complete() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (response.Content != "Halo" || response.PromptTokens != 5 || response.CompletionTokens != 1) {
+				t.Errorf("Complete() = %+v", response)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("provider was called %d times, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestOpenAIProviderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider(OpenAIOptions{
+		BaseURL: server.URL,
+		Timeout: 50 * time.Millisecond,
+	})
+
+	_, err := provider.Complete(context.Background(), Request{Messages: []Message{{Role: RoleUser, Content: "Halo"}}})
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("Complete() error = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestOpenAIProviderStreamBrokenOff(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Halo \"}}]}\n\n")
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}))
+	defer server.Close()
+
+	provider := NewOpenAIProvider(OpenAIOptions{
+		BaseURL:    server.URL,
+		Timeout:    time.Second,
+		MaxRetries: 2,
+	})
+
+	relayed := ""
+	response, err := provider.Stream(context.Background(), Request{Messages: []Message{{Role: RoleUser, Content: "Halo"}}}, func(delta string) error {
+		relayed += delta
+		return nil
+	})
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("Stream() error = %v, want %v", err, ErrUnavailable)
+	}
+	if relayed != "Halo " || response.Content != relayed {
+		t.Errorf("Stream() relayed %q and returned %q, want %q for both", relayed, response.Content, "Halo ")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("a stream that started was retried, provider was called %d times", got)
+	}
+}
@@ -0,0 +1,108 @@
+package cloud
+
+import (
+	"io"
+	"mime"
+	"time"
+
+	"talkspace-api/app/configs"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/sirupsen/logrus"
+)
+
+// UploadPrivateFileToS3 stores a file under key without a public URL. It is
+// only reachable through the links made by PresignS3URL.
+func UploadPrivateFileToS3(body io.ReadSeeker, key string, contentType string) error {
+	svc, bucketName, err := newS3Client()
+	if err != nil {
+		return err
+	}
+
+	params := &s3.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(key),
+		Body:        body,
+		ContentType: aws.String(contentType),
+		ACL:         aws.String(s3.ObjectCannedACLPrivate),
+	}
+
+	_, err = svc.PutObject(params)
+	if err != nil {
+		logrus.Error("failed to upload file to S3:", err)
+		return err
+	}
+
+	return nil
+}
+
+// DeleteFileFromS3 removes a file stored under key.
+func DeleteFileFromS3(key string) error {
+	svc, bucketName, err := newS3Client()
+	if err != nil {
+		return err
+	}
+
+	_, err = svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		logrus.Error("failed to delete file from S3:", err)
+		return err
+	}
+
+	return nil
+}
+
+// PresignS3URL returns a download link for a private file that stops
+// working after expiry. fileName is suggested to the browser when saving,
+// quoted or encoded as needed so any name makes a valid header.
+func PresignS3URL(key string, fileName string, expiry time.Duration) (string, error) {
+	svc, bucketName, err := newS3Client()
+	if err != nil {
+		return "", err
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": fileName})
+	if disposition == "" {
+		// a name that cannot be encoded at all is left to the browser
+		disposition = "attachment"
+	}
+
+	req, _ := svc.GetObjectRequest(&s3.GetObjectInput{
+		Bucket:                     aws.String(bucketName),
+		Key:                        aws.String(key),
+		ResponseContentDisposition: aws.String(disposition),
+	})
+
+	url, err := req.Presign(expiry)
+	if err != nil {
+		logrus.Error("failed to presign S3 url:", err)
+		return "", err
+	}
+
+	return url, nil
+}
+
+func newS3Client() (*s3.S3, string, error) {
+	config, err := configs.LoadConfig()
+	if err != nil {
+		logrus.Error("failed to load configuration:", err)
+		return nil, "", err
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Region:      aws.String(config.CLOUDSTORAGE.AWS_REGION),
+		Credentials: credentials.NewStaticCredentials(config.CLOUDSTORAGE.AWS_ACCESS_KEY_ID, config.CLOUDSTORAGE.AWS_SECRET_ACCESS_KEY, ""),
+	})
+	if err != nil {
+		logrus.Error("failed to create AWS session:", err)
+		return nil, "", err
+	}
+
+	return s3.New(sess), config.CLOUDSTORAGE.AWS_BUCKET_NAME, nil
+}
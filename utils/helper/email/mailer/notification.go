@@ -125,3 +125,67 @@ func SendEmailPaymentConfirmation(email string) {
 		}
 	}()
 }
+
+func SendEmailPaymentReceipt(email string, data map[string]string) {
+	go func() {
+		filePath := "utils/helper/email/template/payment-receipt.html"
+		emailTemplate, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Printf("failed to load email template: %v", err)
+			return
+		}
+
+		success, errEmail := EmailNotificationAccount([]string{email}, string(emailTemplate), data)
+		if !success || errEmail != nil {
+			log.Printf("failed to send notification email to %s: %v", email, errEmail)
+		}
+	}()
+}
+
+func SendEmailRenewalReminder(email string, data map[string]string) {
+	go func() {
+		filePath := "utils/helper/email/template/renewal-reminder.html"
+		emailTemplate, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Printf("failed to load email template: %v", err)
+			return
+		}
+
+		success, errEmail := EmailNotificationAccount([]string{email}, string(emailTemplate), data)
+		if !success || errEmail != nil {
+			log.Printf("failed to send notification email to %s: %v", email, errEmail)
+		}
+	}()
+}
+
+func SendEmailPremiumExpired(email string, data map[string]string) {
+	go func() {
+		filePath := "utils/helper/email/template/premium-expired.html"
+		emailTemplate, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Printf("failed to load email template: %v", err)
+			return
+		}
+
+		success, errEmail := EmailNotificationAccount([]string{email}, string(emailTemplate), data)
+		if !success || errEmail != nil {
+			log.Printf("failed to send notification email to %s: %v", email, errEmail)
+		}
+	}()
+}
+
+func SendEmailCrisisEscalation(to []string, data map[string]string) {
+	go func() {
+		filePath := "utils/helper/email/template/crisis-escalation.html"
+		emailTemplate, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Printf("failed to load email template: %v", err)
+			return
+		}
+
+		success, errEmail := EmailNotificationAccount(to, string(emailTemplate), data)
+		if !success || errEmail != nil {
+			log.Printf("failed to send crisis escalation %s: %v", data["ID"], errEmail)
+		}
+	}()
+}
@@ -0,0 +1,160 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"talkspace-api/app/configs"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Cipher encrypts short text fields with AES-256-GCM before they are stored.
+// Every ciphertext starts with the id of the key that made it, so keys can
+// be rotated while data encrypted with an older one stays readable.
+//
+// The context is authenticated but not stored: a value only decrypts with
+// the context it was encrypted with, which stops a ciphertext from being
+// copied into another record or column.
+type Cipher interface {
+	Encrypt(plaintext string, context string) (string, error)
+	Decrypt(ciphertext string, context string) (string, error)
+}
+
+type aesCipher struct {
+	keyID string
+	keys  map[string]cipher.AEAD
+}
+
+var (
+	defaultCipher Cipher
+	once          sync.Once
+)
+
+// NewCipher returns the process-wide cipher built from ENCRYPTION_KEY_ID,
+// ENCRYPTION_KEY and ENCRYPTION_PREVIOUS_KEYS. It stops the server when the
+// keys are missing or invalid rather than store anything in plain text.
+func NewCipher() Cipher {
+	once.Do(func() {
+		config, err := configs.LoadConfig()
+		if err != nil {
+			logrus.Fatalf("failed to load encryption configuration: %v", err)
+		}
+
+		c, err := NewAESCipher(config.ENCRYPTION.ENCRYPTION_KEY_ID, config.ENCRYPTION.ENCRYPTION_KEY, config.ENCRYPTION.ENCRYPTION_PREVIOUS_KEYS)
+		if err != nil {
+			logrus.Fatalf("failed to initialize encryption: %v", err)
+		}
+
+		defaultCipher = c
+	})
+
+	return defaultCipher
+}
+
+// NewAESCipher builds a cipher that encrypts with key and can still decrypt
+// with the previous keys, given as "id:base64key" pairs separated by commas.
+func NewAESCipher(keyID string, key string, previousKeys string) (Cipher, error) {
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return nil, errors.New("encryption key id must be set and must not contain ':'")
+	}
+
+	c := &aesCipher{
+		keyID: keyID,
+		keys:  map[string]cipher.AEAD{},
+	}
+
+	if err := c.addKey(keyID, key); err != nil {
+		return nil, err
+	}
+
+	for _, pair := range strings.Split(previousKeys, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		id, previousKey, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("previous encryption key %q must be written as id:key", id)
+		}
+
+		if err := c.addKey(id, previousKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (c *aesCipher) addKey(id string, key string) error {
+	raw, err := base64.StdEncoding.DecodeString(key)
+	if err != nil || len(raw) != 32 {
+		return fmt.Errorf("encryption key %s must be 32 bytes encoded in base64", id)
+	}
+
+	block, err := aes.NewCipher(raw)
+	if err != nil {
+		return err
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	c.keys[id] = aead
+	return nil
+}
+
+// Encrypt returns "keyID:base64(nonce|ciphertext)". An empty plaintext stays
+// empty so optional fields do not need a ciphertext.
+func (c *aesCipher) Encrypt(plaintext string, context string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := c.keys[c.keyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(context))
+
+	return c.keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *aesCipher) Decrypt(ciphertext string, context string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+
+	keyID, encoded, ok := strings.Cut(ciphertext, ":")
+	if !ok {
+		return "", errors.New("malformed ciphertext")
+	}
+
+	aead, ok := c.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("unknown encryption key %s", keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed ciphertext")
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(context))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt with key %s: %w", keyID, err)
+	}
+
+	return string(plaintext), nil
+}
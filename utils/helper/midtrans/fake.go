@@ -0,0 +1,199 @@
+package midtrans
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+type fakeCharge struct {
+	amount    float64
+	status    string
+	expiresAt time.Time
+}
+
+// FakeGateway keeps charges in memory so the booking-and-pay flow can run in
+// development and tests without a Midtrans account. Charges start as pending
+// and move when SetStatus is called or their expiry passes.
+type FakeGateway struct {
+	mu        sync.Mutex
+	serverKey string
+	charges   map[string]*fakeCharge
+}
+
+// NewFakeGateway signs notifications with serverKey. The key must be set
+// because the notification endpoint is public and a well-known default would
+// let anyone forge a paid notification.
+func NewFakeGateway(serverKey string) (*FakeGateway, error) {
+	if serverKey == "" {
+		return nil, errors.New("midtrans: server key is empty")
+	}
+
+	return &FakeGateway{
+		serverKey: serverKey,
+		charges:   make(map[string]*fakeCharge),
+	}, nil
+}
+
+func (fg *FakeGateway) CreateCharge(request ChargeRequest) (ChargeResponse, error) {
+	if request.OrderID == "" {
+		return ChargeResponse{}, errors.New("midtrans: order id is empty")
+	}
+
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	if _, ok := fg.charges[request.OrderID]; ok {
+		return ChargeResponse{}, errors.New("midtrans: order id has already been taken")
+	}
+
+	charge := &fakeCharge{
+		amount: request.Amount,
+		status: "pending",
+	}
+	if request.Expiry > 0 {
+		charge.expiresAt = time.Now().Add(request.Expiry)
+	}
+	fg.charges[request.OrderID] = charge
+
+	return ChargeResponse{
+		Token:       "fake-" + request.OrderID,
+		RedirectURL: "http://localhost/fake-payment/" + request.OrderID,
+	}, nil
+}
+
+func (fg *FakeGateway) GetStatus(orderID string) (StatusResponse, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	charge, ok := fg.charge(orderID)
+	if !ok {
+		return StatusResponse{}, errors.New("midtrans: transaction doesn't exist")
+	}
+
+	return fg.statusResponse(orderID, charge), nil
+}
+
+func (fg *FakeGateway) Refund(orderID string, amount float64, reason string) (StatusResponse, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	charge, ok := fg.charge(orderID)
+	if !ok {
+		return StatusResponse{}, errors.New("midtrans: transaction doesn't exist")
+	}
+
+	if charge.status != "settlement" && charge.status != "capture" {
+		return StatusResponse{}, errors.New("midtrans: transaction status cannot be refunded")
+	}
+
+	if amount > charge.amount {
+		return StatusResponse{}, errors.New("midtrans: refund amount exceeds transaction amount")
+	}
+
+	charge.status = "refund"
+	if amount < charge.amount {
+		charge.status = "partial_refund"
+	}
+
+	return fg.statusResponse(orderID, charge), nil
+}
+
+func (fg *FakeGateway) Expire(orderID string) (StatusResponse, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	charge, ok := fg.charge(orderID)
+	if !ok {
+		return StatusResponse{}, errors.New("midtrans: transaction doesn't exist")
+	}
+
+	if charge.status != "pending" {
+		return StatusResponse{}, errors.New("midtrans: transaction status cannot be expired")
+	}
+
+	charge.status = "expire"
+
+	return fg.statusResponse(orderID, charge), nil
+}
+
+func (fg *FakeGateway) VerifyNotification(notification Notification) bool {
+	return verifySignature(notification, fg.serverKey)
+}
+
+// Notification builds the signed HTTP notification Midtrans would send for
+// the current state of a fake charge.
+func (fg *FakeGateway) Notification(orderID string) (Notification, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	charge, ok := fg.charge(orderID)
+	if !ok {
+		return Notification{}, errors.New("midtrans: transaction doesn't exist")
+	}
+
+	status := fg.statusResponse(orderID, charge)
+
+	return Notification{
+		OrderID:           status.OrderID,
+		StatusCode:        status.StatusCode,
+		GrossAmount:       status.GrossAmount,
+		SignatureKey:      signature(status.OrderID, status.StatusCode, status.GrossAmount, fg.serverKey),
+		PaymentType:       status.PaymentType,
+		TransactionStatus: status.TransactionStatus,
+		FraudStatus:       status.FraudStatus,
+	}, nil
+}
+
+// SetStatus moves a fake charge to the given Midtrans transaction status,
+// standing in for the customer completing or abandoning the payment.
+func (fg *FakeGateway) SetStatus(orderID string, status string) (StatusResponse, error) {
+	fg.mu.Lock()
+	defer fg.mu.Unlock()
+
+	charge, ok := fg.charge(orderID)
+	if !ok {
+		return StatusResponse{}, errors.New("midtrans: transaction doesn't exist")
+	}
+
+	charge.status = status
+
+	return fg.statusResponse(orderID, charge), nil
+}
+
+// charge looks up a charge and expires it when it was left pending past its
+// expiry. The caller holds fg.mu.
+func (fg *FakeGateway) charge(orderID string) (*fakeCharge, bool) {
+	charge, ok := fg.charges[orderID]
+	if !ok {
+		return nil, false
+	}
+
+	if charge.status == "pending" && !charge.expiresAt.IsZero() && time.Now().After(charge.expiresAt) {
+		charge.status = "expire"
+	}
+
+	return charge, true
+}
+
+func (fg *FakeGateway) statusResponse(orderID string, charge *fakeCharge) StatusResponse {
+	statusCode := "200"
+	switch charge.status {
+	case "pending":
+		statusCode = "201"
+	case "deny", "cancel", "expire", "failure":
+		statusCode = "202"
+	}
+
+	return StatusResponse{
+		OrderID:           orderID,
+		StatusCode:        statusCode,
+		GrossAmount:       fmt.Sprintf("%d.00", int64(math.Round(charge.amount))),
+		PaymentType:       "fake",
+		TransactionStatus: charge.status,
+		FraudStatus:       "accept",
+		StatusMessage:     "Success, transaction is found",
+	}
+}
@@ -1 +1,128 @@
-package midtrans
\ No newline at end of file
+package midtrans
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"sync"
+	"talkspace-api/app/configs"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	ENVIRONMENT_PRODUCTION = "production"
+	ENVIRONMENT_SANDBOX    = "sandbox"
+	ENVIRONMENT_FAKE       = "fake"
+
+	// SERVER_DEVELOPMENT is the only SERVER_ENVIRONMENT the fake gateway is
+	// allowed to run in
+	SERVER_DEVELOPMENT = "development"
+)
+
+type PaymentGateway interface {
+	CreateCharge(request ChargeRequest) (ChargeResponse, error)
+	GetStatus(orderID string) (StatusResponse, error)
+	Refund(orderID string, amount float64, reason string) (StatusResponse, error)
+	Expire(orderID string) (StatusResponse, error)
+	VerifyNotification(notification Notification) bool
+}
+
+type (
+	ChargeRequest struct {
+		OrderID       string
+		Amount        float64
+		ItemID        string
+		ItemName      string
+		CustomerName  string
+		CustomerEmail string
+		// Expiry closes the payment page this long after the charge is
+		// created. Midtrans keeps it open for a day when it is zero.
+		Expiry time.Duration
+	}
+
+	ChargeResponse struct {
+		Token       string
+		RedirectURL string
+	}
+
+	StatusResponse struct {
+		OrderID           string `json:"order_id"`
+		StatusCode        string `json:"status_code"`
+		GrossAmount       string `json:"gross_amount"`
+		PaymentType       string `json:"payment_type"`
+		TransactionStatus string `json:"transaction_status"`
+		FraudStatus       string `json:"fraud_status"`
+		StatusMessage     string `json:"status_message"`
+	}
+
+	Notification struct {
+		OrderID           string
+		StatusCode        string
+		GrossAmount       string
+		SignatureKey      string
+		PaymentType       string
+		TransactionStatus string
+		FraudStatus       string
+	}
+)
+
+var (
+	gateway PaymentGateway
+	once    sync.Once
+)
+
+// NewPaymentGateway returns the process-wide gateway selected by
+// MIDTRANS_ENVIRONMENT. The instance is shared so that the fake gateway keeps
+// a single view of its charges across modules.
+func NewPaymentGateway() PaymentGateway {
+	once.Do(func() {
+		config, err := configs.LoadConfig()
+		if err != nil {
+			logrus.Fatalf("failed to load Midtrans configuration: %v", err)
+		}
+
+		serverKey := config.MIDTRANS.MIDTRANS_SERVER_KEY
+		if serverKey == "" {
+			logrus.Fatal("failed to initialize payment gateway: MIDTRANS_SERVER_KEY is empty")
+		}
+
+		switch config.MIDTRANS.MIDTRANS_ENVIRONMENT {
+		case ENVIRONMENT_FAKE:
+			if config.SERVER.SERVER_ENVIRONMENT != SERVER_DEVELOPMENT {
+				logrus.Fatalf("failed to initialize payment gateway: the fake gateway only runs when SERVER_ENVIRONMENT is %s", SERVER_DEVELOPMENT)
+			}
+			fakeGateway, errFake := NewFakeGateway(serverKey)
+			if errFake != nil {
+				logrus.Fatalf("failed to initialize payment gateway: %v", errFake)
+			}
+			gateway = fakeGateway
+		case ENVIRONMENT_PRODUCTION:
+			gateway = NewMidtransClient(serverKey, true)
+		default:
+			gateway = NewMidtransClient(serverKey, false)
+		}
+
+		logrus.Infof("payment gateway initialized in %s mode", config.MIDTRANS.MIDTRANS_ENVIRONMENT)
+	})
+
+	return gateway
+}
+
+// signature computes the notification signature_key Midtrans sends with every
+// HTTP notification: SHA512(order_id + status_code + gross_amount + server_key).
+func signature(orderID, statusCode, grossAmount, serverKey string) string {
+	hash := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(hash[:])
+}
+
+func verifySignature(notification Notification, serverKey string) bool {
+	if serverKey == "" || notification.SignatureKey == "" {
+		return false
+	}
+
+	expected := signature(notification.OrderID, notification.StatusCode, notification.GrossAmount, serverKey)
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(notification.SignatureKey)) == 1
+}
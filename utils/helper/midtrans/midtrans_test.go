@@ -0,0 +1,77 @@
+package midtrans
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"testing"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const serverKey = "server-key"
+
+	hash := sha512.Sum512([]byte("TS-001" + "200" + "150000.00" + serverKey))
+	valid := Notification{
+		OrderID:      "TS-001",
+		StatusCode:   "200",
+		GrossAmount:  "150000.00",
+		SignatureKey: hex.EncodeToString(hash[:]),
+	}
+
+	tests := []struct {
+		name         string
+		notification func(Notification) Notification
+		serverKey    string
+		want         bool
+	}{
+		{"valid", func(n Notification) Notification { return n }, serverKey, true},
+		{"other server key", func(n Notification) Notification { return n }, "other-key", false},
+		{"empty server key", func(n Notification) Notification { return n }, "", false},
+		{"empty signature", func(n Notification) Notification { n.SignatureKey = ""; return n }, serverKey, false},
+		{"tampered order id", func(n Notification) Notification { n.OrderID = "TS-002"; return n }, serverKey, false},
+		{"tampered status code", func(n Notification) Notification { n.StatusCode = "201"; return n }, serverKey, false},
+		{"tampered gross amount", func(n Notification) Notification { n.GrossAmount = "1.00"; return n }, serverKey, false},
+		{"uppercase signature", func(n Notification) Notification { n.SignatureKey = "A" + n.SignatureKey[1:]; return n }, serverKey, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := verifySignature(tt.notification(valid), tt.serverKey)
+			if got != tt.want {
+				t.Errorf("verifySignature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFakeGatewayNotification(t *testing.T) {
+	if _, err := NewFakeGateway(""); err == nil {
+		t.Fatal("NewFakeGateway(\"\") error = nil, want an error")
+	}
+
+	fakeGateway, err := NewFakeGateway("server-key")
+	if err != nil {
+		t.Fatalf("NewFakeGateway() error = %v", err)
+	}
+
+	if _, err := fakeGateway.CreateCharge(ChargeRequest{OrderID: "TS-001", Amount: 150000}); err != nil {
+		t.Fatalf("CreateCharge() error = %v", err)
+	}
+
+	if _, err := fakeGateway.SetStatus("TS-001", "settlement"); err != nil {
+		t.Fatalf("SetStatus() error = %v", err)
+	}
+
+	notification, err := fakeGateway.Notification("TS-001")
+	if err != nil {
+		t.Fatalf("Notification() error = %v", err)
+	}
+
+	if !fakeGateway.VerifyNotification(notification) {
+		t.Error("VerifyNotification() = false for a notification signed by the fake")
+	}
+
+	other, _ := NewFakeGateway("other-key")
+	if other.VerifyNotification(notification) {
+		t.Error("VerifyNotification() = true for a notification signed with another key")
+	}
+}
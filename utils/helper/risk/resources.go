@@ -0,0 +1,30 @@
+package risk
+
+// Resource is a place a person at risk can reach for help right away.
+type Resource struct {
+	Name        string `json:"name"`
+	Contact     string `json:"contact"`
+	Description string `json:"description"`
+}
+
+// SupportMessage goes with the hotlines shown to a user.
+const SupportMessage = "You don't have to go through this alone. If you are thinking about hurting yourself, please reach out to one of these services now."
+
+// Hotlines are shown to a user whose message was flagged.
+var Hotlines = []Resource{
+	{
+		Name:        "Layanan SEJIWA",
+		Contact:     "119 ext. 8",
+		Description: "Free mental health support line of the Indonesian Ministry of Health",
+	},
+	{
+		Name:        "Emergency Services",
+		Contact:     "112",
+		Description: "Call if you or someone near you is in immediate danger",
+	},
+	{
+		Name:        "Find A Helpline",
+		Contact:     "https://findahelpline.com",
+		Description: "Free, confidential helplines in other countries",
+	},
+}
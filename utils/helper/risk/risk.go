@@ -0,0 +1,181 @@
+package risk
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Risk levels, from least to most urgent.
+const (
+	LevelElevated = "elevated"
+	LevelHigh     = "high"
+)
+
+// Detector screens a piece of text for signs of self-harm risk. The rule
+// based detector is the default, a model based classifier can be plugged in
+// next to it with Chain.
+type Detector interface {
+	Detect(text string) (Assessment, error)
+}
+
+// Assessment is what a detector concluded about a text. Matches names the
+// rules that fired, never the text itself.
+type Assessment struct {
+	Flagged bool
+	Level   string
+	Matches []Match
+}
+
+type Match struct {
+	Rule     string
+	Language string
+	Level    string
+}
+
+// Rule flags a text when its pattern matches. Patterns are matched against
+// the lower cased text with whitespace collapsed.
+type Rule struct {
+	Name     string
+	Language string
+	Level    string
+	Pattern  *regexp.Regexp
+}
+
+type ruleDetector struct {
+	rules []Rule
+}
+
+var (
+	defaultDetector Detector
+	once            sync.Once
+)
+
+// NewDetector returns the process-wide detector running DefaultRules.
+func NewDetector() Detector {
+	once.Do(func() {
+		defaultDetector = NewRuleDetector(DefaultRules())
+	})
+
+	return defaultDetector
+}
+
+func NewRuleDetector(rules []Rule) Detector {
+	return &ruleDetector{
+		rules: rules,
+	}
+}
+
+func (rd *ruleDetector) Detect(text string) (Assessment, error) {
+	normalized := strings.Join(strings.Fields(strings.ToLower(text)), " ")
+
+	assessment := Assessment{}
+	for _, rule := range rd.rules {
+		if !rule.Pattern.MatchString(normalized) {
+			continue
+		}
+
+		assessment.add(Match{
+			Rule:     rule.Name,
+			Language: rule.Language,
+			Level:    rule.Level,
+		})
+	}
+
+	return assessment, nil
+}
+
+func (a *Assessment) add(match Match) {
+	a.Flagged = true
+	a.Matches = append(a.Matches, match)
+	if rank(match.Level) > rank(a.Level) {
+		a.Level = match.Level
+	}
+}
+
+// LevelsFrom returns level and every level more urgent than it.
+func LevelsFrom(level string) []string {
+	levels := []string{}
+	for _, l := range []string{LevelElevated, LevelHigh} {
+		if rank(l) >= rank(level) {
+			levels = append(levels, l)
+		}
+	}
+	return levels
+}
+
+func rank(level string) int {
+	switch level {
+	case LevelHigh:
+		return 2
+	case LevelElevated:
+		return 1
+	default:
+		return 0
+	}
+}
+
+type chain []Detector
+
+// Chain runs every detector on the text and merges what they found, so a
+// text is flagged when any of them flags it. A detector that fails, such as
+// a classifier that cannot be reached, fails the whole chain.
+func Chain(detectors ...Detector) Detector {
+	return chain(detectors)
+}
+
+func (c chain) Detect(text string) (Assessment, error) {
+	assessment := Assessment{}
+	for _, detector := range c {
+		result, err := detector.Detect(text)
+		if err != nil {
+			return Assessment{}, err
+		}
+
+		for _, match := range result.Matches {
+			assessment.add(match)
+		}
+
+		// a classifier may flag a text without naming a rule
+		if result.Flagged && len(result.Matches) == 0 {
+			assessment.add(Match{Level: result.Level})
+		}
+	}
+
+	return assessment, nil
+}
+
+func rule(name string, language string, level string, pattern string) Rule {
+	return Rule{
+		Name:     name,
+		Language: language,
+		Level:    level,
+		Pattern:  regexp.MustCompile(pattern),
+	}
+}
+
+// DefaultRules are the keyword rules in Indonesian and English, including
+// the informal spellings common in chat.
+func DefaultRules() []Rule {
+	return []Rule{
+		// Indonesian
+		rule("id_suicide", "id", LevelHigh, `\bbunuh\s?diri\b`),
+		rule("id_end_life", "id", LevelHigh, `\b(meng)?akhiri\s(hidup|nyawa)`),
+		rule("id_hanging", "id", LevelHigh, `\bgantung\s?diri\b`),
+		rule("id_overdose", "id", LevelHigh, `\b(overdosis|minum racun)\b`),
+		rule("id_self_harm", "id", LevelHigh, `\b(melukai|menyakiti|menyayat|nyakitin|ngelukain|nyayat)\s(diri|tangan)\b`),
+		rule("id_want_to_die", "id", LevelElevated, `\b(ingin|pengen|pengin|mau|pingin|kepengen)\s(mati|mampus)\b`),
+		rule("id_better_dead", "id", LevelElevated, `\blebih\sbaik\s((aku|saya|gue|gw)\s)?mati\b`),
+		rule("id_no_will_to_live", "id", LevelElevated, `\b(tidak|tak|ga|gak|nggak|ngga|enggak)\s(ingin|mau|pengen|kuat)\shidup\b`),
+		rule("id_no_reason", "id", LevelElevated, `\b(tidak|tak|ga|gak|nggak)\sada\salasan\s(untuk|buat)\shidup\b`),
+
+		// English
+		rule("en_suicide", "en", LevelHigh, `\b(suicide|suicidal|kill myself|killing myself)\b`),
+		rule("en_end_life", "en", LevelHigh, `\b(end|ending|take|taking) my (own )?life\b`),
+		rule("en_overdose", "en", LevelHigh, `\boverdos(e|ing)\b`),
+		rule("en_self_harm", "en", LevelHigh, `\b(self[- ]?harm(ing)?|hurt(ing)? myself|cut(ting)? myself)\b`),
+		rule("en_want_to_die", "en", LevelElevated, `\b(want|wanna|wish) to die\b|\bwanna die\b|\bwish i (was|were) dead\b`),
+		rule("en_better_dead", "en", LevelElevated, `\bbetter off dead\b`),
+		rule("en_no_reason", "en", LevelElevated, `\bno (reason|point) (to|in) (live|living)\b|\bdon'?t want to live\b`),
+	}
+}
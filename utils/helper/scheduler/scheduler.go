@@ -0,0 +1,210 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RUN_SUCCESS = "success"
+	RUN_FAILED  = "failed"
+)
+
+// Job does the work of a scheduled task and returns a short summary that is
+// stored in the run history.
+type Job func(ctx context.Context) (string, error)
+
+// Run describes one execution of a job on one instance.
+type Run struct {
+	Job         string
+	Instance    string
+	Status      string
+	Message     string
+	ScheduledAt time.Time
+	StartedAt   time.Time
+	FinishedAt  time.Time
+}
+
+// Recorder persists run history.
+type Recorder interface {
+	RecordRun(run Run) error
+}
+
+type entry struct {
+	name     string
+	spec     string
+	schedule Schedule
+	timeout  time.Duration
+	job      Job
+}
+
+// Scheduler runs cron-style jobs inside the API process. Every activation
+// takes a Redis lock keyed on the job and its scheduled minute, so when
+// several replicas are running only the first one to claim it does the work.
+type Scheduler struct {
+	rdb      *redis.Client
+	recorder Recorder
+	instance string
+	entries  []*entry
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+}
+
+func New(rdb *redis.Client, recorder Recorder) *Scheduler {
+	hostname, _ := os.Hostname()
+
+	return &Scheduler{
+		rdb:      rdb,
+		recorder: recorder,
+		instance: hostname + "-" + strconv.Itoa(os.Getpid()) + "-" + uuid.NewString()[:8],
+	}
+}
+
+// Register adds a job. timeout bounds a single run and is also how long the
+// lock is held, so it should be longer than the job ever takes.
+func (s *Scheduler) Register(name string, spec string, timeout time.Duration, job Job) error {
+	schedule, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if e.name == name {
+			return fmt.Errorf("scheduler: job %s is already registered", name)
+		}
+	}
+
+	s.entries = append(s.entries, &entry{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		timeout:  timeout,
+		job:      job,
+	})
+
+	return nil
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, e := range s.entries {
+		s.running.Add(1)
+		go s.loop(ctx, e)
+		logrus.Infof("scheduler: job %s registered with schedule %q", e.name, e.spec)
+	}
+}
+
+// Stop stops scheduling new runs and waits for running jobs to return or for
+// ctx to expire.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.running.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	defer s.running.Done()
+
+	for {
+		next := e.schedule.Next(time.Now())
+		if next.IsZero() {
+			logrus.Warnf("scheduler: job %s has no upcoming run", e.name)
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.run(ctx, e, next)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, e *entry, scheduledAt time.Time) {
+	lockKey := fmt.Sprintf("scheduler:lock:%s:%d", e.name, scheduledAt.Unix())
+
+	acquired, err := s.rdb.SetNX(ctx, lockKey, s.instance, e.timeout).Result()
+	if err != nil {
+		logrus.Errorf("scheduler: failed to acquire lock for job %s: %v", e.name, err)
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	run := Run{
+		Job:         e.name,
+		Instance:    s.instance,
+		ScheduledAt: scheduledAt,
+		StartedAt:   time.Now(),
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, e.timeout)
+	message, errJob := execute(jobCtx, e.job)
+	cancel()
+
+	run.FinishedAt = time.Now()
+	run.Message = message
+	run.Status = RUN_SUCCESS
+	if errJob != nil {
+		run.Status = RUN_FAILED
+		run.Message = errJob.Error()
+		logrus.Errorf("scheduler: job %s failed: %v", e.name, errJob)
+	}
+
+	if s.recorder != nil {
+		if errRecord := s.recorder.RecordRun(run); errRecord != nil {
+			logrus.Errorf("scheduler: failed to record run of job %s: %v", e.name, errRecord)
+		}
+	}
+}
+
+func execute(ctx context.Context, job Job) (message string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return job(ctx)
+}
@@ -0,0 +1,50 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Stream writes Server-Sent Events to a response, flushing every event so
+// it reaches the client right away.
+type Stream struct {
+	response *echo.Response
+}
+
+// NewStream starts an event stream on the response. Nothing else may be
+// written to the response after it.
+func NewStream(response *echo.Response) *Stream {
+	header := response.Header()
+	header.Set(echo.HeaderContentType, "text/event-stream")
+	header.Set(echo.HeaderCacheControl, "no-cache")
+	header.Set(echo.HeaderConnection, "keep-alive")
+	// keep proxies such as nginx from buffering the stream
+	header.Set("X-Accel-Buffering", "no")
+
+	response.WriteHeader(http.StatusOK)
+	response.Flush()
+
+	return &Stream{
+		response: response,
+	}
+}
+
+// Send writes an event with data encoded as json.
+func (s *Stream) Send(event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(s.response, "event: %s\ndata: %s\n\n", event, payload)
+	if err != nil {
+		return err
+	}
+
+	s.response.Flush()
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package responses
+
+import "strconv"
+
+// Pagination parses the page and limit query parameters, falling back to the
+// first page of ten items when they are missing or invalid.
+func Pagination(pageParam string, limitParam string) (int, int) {
+	page := 1
+	if value, err := strconv.Atoi(pageParam); err == nil && value > 0 {
+		page = value
+	}
+
+	limit := 10
+	if value, err := strconv.Atoi(limitParam); err == nil && value > 0 {
+		limit = value
+	}
+
+	return page, limit
+}
@@ -81,4 +81,36 @@ func SuccessResponsePage(message string, page int, limit int, totaldata int64, d
 		},
 		Results: data,
 	}
-}
\ No newline at end of file
+}
+
+// Cursor pagination
+type TResponseMetaCursor struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Limit   int    `json:"limit"`
+	HasMore bool   `json:"has_more"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+}
+
+type TSuccessResponseCursor struct {
+	Meta    TResponseMetaCursor `json:"meta"`
+	Results interface{}         `json:"results"`
+}
+
+// SuccessResponseCursor answers a cursor paginated request. before and after
+// are the cursors of the first and last item, to be sent back to fetch the
+// neighbouring pages.
+func SuccessResponseCursor(message string, limit int, hasMore bool, before string, after string, data interface{}) TSuccessResponseCursor {
+	return TSuccessResponseCursor{
+		Meta: TResponseMetaCursor{
+			Success: true,
+			Message: message,
+			Limit:   limit,
+			HasMore: hasMore,
+			Before:  before,
+			After:   after,
+		},
+		Results: data,
+	}
+}